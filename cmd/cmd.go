@@ -2,11 +2,26 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"github.com/aeramu/menfess-backend/constants"
 	"github.com/aeramu/menfess-backend/infra/graphql"
+	"github.com/aeramu/menfess-backend/infra/worker"
+	"github.com/aeramu/menfess-backend/modules/attachment"
+	"github.com/aeramu/menfess-backend/modules/audit"
 	"github.com/aeramu/menfess-backend/modules/auth"
+	"github.com/aeramu/menfess-backend/modules/avatar"
+	"github.com/aeramu/menfess-backend/modules/bookmark"
+	"github.com/aeramu/menfess-backend/modules/linkpreview"
 	logModule "github.com/aeramu/menfess-backend/modules/log"
+	"github.com/aeramu/menfess-backend/modules/media"
+	"github.com/aeramu/menfess-backend/modules/moderation"
 	"github.com/aeramu/menfess-backend/modules/notification"
 	"github.com/aeramu/menfess-backend/modules/post"
+	"github.com/aeramu/menfess-backend/modules/ratelimit"
+	"github.com/aeramu/menfess-backend/modules/redaction"
+	"github.com/aeramu/menfess-backend/modules/report"
+	"github.com/aeramu/menfess-backend/modules/storage"
+	"github.com/aeramu/menfess-backend/modules/trending"
 	"github.com/aeramu/menfess-backend/modules/user"
 	"github.com/aeramu/menfess-backend/service"
 	"github.com/aeramu/menfess-backend/utils/playground"
@@ -14,6 +29,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 )
 
 func Run() {
@@ -27,27 +43,131 @@ func Run() {
 		dbName = "menfess"
 	}
 	db := mongolib.NewDatabase(client, dbName)
+	storageModule, err := newStorageModule()
+	if err != nil {
+		log.Fatalln("[Init Storage]", err)
+	}
+	moderationModule, err := newModerationModule()
+	if err != nil {
+		log.Fatalln("[Init Moderation]", err)
+	}
 	adapter := service.Adapter{
 		UserModule:         user.NewUserModule(db),
 		PostModule:         post.NewPostModule(db),
 		AuthModule:         auth.NewAuthModule(),
 		NotificationModule: notification.NewNotificationModule(db),
 		LogModule:          logModule.NewLogModule(),
+		TrendingModule:     trending.NewTrendingModule(db),
+		BookmarkModule:     bookmark.NewBookmarkModule(db),
+		StorageModule:      storageModule,
+		AttachmentModule:   attachment.NewAttachmentModule(db),
+		MediaModule:        media.NewMediaModule(),
+		AvatarModule:       avatar.NewAvatarModule(db),
+		LinkPreviewModule:  newLinkPreviewModule(db),
+		ReportModule:       report.NewReportModule(db),
+		AuditModule:        audit.NewAuditModule(db),
+		ModerationModule:   moderationModule,
+		RedactionModule:    redaction.NewRedactionModule(redaction.DefaultRules()),
+		RateLimitModule:    ratelimit.NewRateLimitModule(db),
+	}
+	if err := avatar.Seed(context.Background(), db, constants.DefaultAvatars); err != nil {
+		log.Fatalln("[Seed Avatar]", err)
+	}
+	if err := user.EnsureIndex(context.Background(), db); err != nil {
+		log.Fatalln("[Ensure User Index]", err)
+	}
+	if err := user.MigrateFollow(context.Background(), db); err != nil {
+		log.Fatalln("[Migrate Follow]", err)
 	}
-	svc := service.NewService(adapter)
-	srv, err := graphql.NewServer(svc)
+	if err := report.EnsureIndex(context.Background(), db); err != nil {
+		log.Fatalln("[Ensure Report Index]", err)
+	}
+	if err := audit.EnsureIndex(context.Background(), db); err != nil {
+		log.Fatalln("[Ensure Audit Log Index]", err)
+	}
+	if err := ratelimit.EnsureIndex(context.Background(), db); err != nil {
+		log.Fatalln("[Ensure Rate Limit Index]", err)
+	}
+	svc := service.NewService(adapter, service.Config{
+		ReportHideThreshold: getEnvInt("REPORT_HIDE_THRESHOLD"),
+	})
+	worker.NewWorker(svc).Start(context.Background())
+	srv, err := graphql.NewServer(svc, getEnvInt("TRUSTED_PROXY_HOPS"))
 	if err != nil {
 		log.Fatalln("[Init Server]", err)
 	}
 	pg := playground.Handler("Playground", "/")
 	http.Handle("/", srv)
 	http.Handle("/playground", pg)
+	if os.Getenv("STORAGE") != "s3" {
+		http.Handle("/uploads/", http.StripPrefix("/uploads/", http.FileServer(http.Dir(uploadDir()))))
+	}
 
 	port := getPort()
 	log.Println("Server started at", port)
 	log.Fatal(http.ListenAndServe(port, nil))
 }
 
+// newStorageModule use s3-compatible storage if STORAGE=s3, otherwise store upload on local disk
+func newStorageModule() (service.StorageModule, error) {
+	if os.Getenv("STORAGE") == "s3" {
+		return storage.NewS3StorageModule(storage.S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    os.Getenv("S3_REGION"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			PublicURL: os.Getenv("S3_PUBLIC_URL"),
+		})
+	}
+	baseURL := os.Getenv("BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost" + getPort()
+	}
+	return storage.NewFileSystemStorageModule(uploadDir(), baseURL+"/uploads"), nil
+}
+
+// newLinkPreviewModule fetch the linked page unless LINK_PREVIEW=fake, which is useful when running offline
+func newLinkPreviewModule(db *mongolib.Database) service.LinkPreviewModule {
+	if os.Getenv("LINK_PREVIEW") == "fake" {
+		return linkpreview.NewFakeLinkPreviewModule(nil)
+	}
+	return linkpreview.NewLinkPreviewModule(db)
+}
+
+// newModerationModule read the content filter rules from the json file in MODERATION_RULES_FILE,
+// without it only the personal data and link limit rules apply
+func newModerationModule() (service.ModerationModule, error) {
+	var config moderation.RuleConfig
+	if path := os.Getenv("MODERATION_RULES_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, err
+		}
+	}
+	return moderation.NewRuleModerationModule(config)
+}
+
+func uploadDir() string {
+	dir := os.Getenv("UPLOAD_DIR")
+	if dir == "" {
+		dir = "uploads"
+	}
+	return dir
+}
+
+// getEnvInt return 0 if the env is not set or not a number, so the default is used
+func getEnvInt(key string) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
 func getPort() string {
 	port := os.Getenv("PORT")
 	if port == "" {
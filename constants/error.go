@@ -1,14 +1,27 @@
 package constants
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 var (
 	ErrInternalServerError = errors.New("internal server error")
+	ErrRateLimited = errors.New("too many requests")
 
 	ErrUserNotFound = errors.New("user not found")
 	ErrInvalidID = errors.New("id is not valid")
 	ErrInvalidUserID = errors.New("user id is not valid")
 	ErrInvalidFollowedID = errors.New("followed id is not valid")
+	ErrFollowSelf = errors.New("can't follow yourself")
+	ErrBlockSelf = errors.New("can't block yourself")
+	ErrMuteSelf = errors.New("can't mute yourself")
+	ErrBlocked = errors.New("you are blocked by the user")
+	ErrInvalidMutedWord = errors.New("muted word is not valid")
+	ErrTooManyMutedWords = errors.New("too many muted words")
 	ErrInvalidEmail = errors.New("email is not valid")
 	ErrEmailAlreadyRegistered = errors.New("email already registered")
 	ErrInvalidPassword = errors.New("password is not valid")
@@ -16,11 +29,129 @@ var (
 	ErrInvalidPushToken = errors.New("push token is not valid")
 	ErrInvalidName = errors.New("name is not valid")
 	ErrInvalidAvatar = errors.New("avatar is not valid")
+	ErrAvatarNotFound = errors.New("avatar not found")
+	ErrForbidden = errors.New("forbidden")
+	ErrInvalidSensitiveContent = errors.New("sensitive content setting is not valid")
+	ErrInvalidHandle = errors.New("handle is not valid")
+	ErrHandleReserved = errors.New("handle is reserved")
+	ErrHandleTaken = errors.New("handle already taken")
+	ErrHandleChangeTooSoon = errors.New("handle was changed recently")
+	ErrAccountSuspended = errors.New("account suspended")
+	ErrAccountBanned = errors.New("account banned")
+	ErrBannedDeviceNotFound = errors.New("banned device not found")
+	ErrInvalidModerateAction = errors.New("moderate action is not valid")
+	ErrInvalidModerateReason = errors.New("moderate reason is not valid")
+	ErrInvalidTargetID = errors.New("target id is not valid")
+	ErrInvalidAuditLogFilter = errors.New("audit log filter is not valid")
+	ErrTooManyAuditLogs = errors.New("too many audit logs to export, narrow the filter")
 
 	ErrPostNotFound = errors.New("post not found")
 	ErrInvalidPostID = errors.New("post id is not valid")
 	ErrInvalidBody = errors.New("body is not valid")
+	ErrQuoteReply = errors.New("reply can't quote other post")
+	ErrInvalidPublishAt = errors.New("publish time is not valid")
+	ErrScheduleReply = errors.New("reply can't be scheduled")
+	ErrInvalidExpiresIn = errors.New("expiry duration is not valid")
+	ErrTooManyAttachments = errors.New("too many attachments")
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrInvalidContentWarning = errors.New("content warning is not valid")
+	ErrLinkPreviewUnavailable = errors.New("link preview is not available")
+	ErrPostRejected = errors.New("post rejected by content filter")
+	ErrPersonalDataUnconfirmed = errors.New("post contains personal data, confirm to post it")
+	ErrDuplicatePost = errors.New("post is too similar to your recent post")
+
+	ErrReportNotFound = errors.New("report not found")
+	ErrInvalidReportID = errors.New("report id is not valid")
+	ErrInvalidReportReason = errors.New("report reason is not valid")
+	ErrInvalidReportDetails = errors.New("report details is not valid")
+	ErrInvalidReportAction = errors.New("report action is not valid")
+	ErrInvalidSuspendDuration = errors.New("suspend duration is not valid")
+	ErrReportOwnPost = errors.New("can't report your own post")
+	ErrAlreadyReported = errors.New("already reported")
+	ErrReportAlreadyResolved = errors.New("report already resolved")
+
+	ErrInvalidImage = errors.New("image is not valid")
+	ErrImageTooLarge = errors.New("image is too large")
+	ErrUnsupportedImageType = errors.New("image type is not supported")
+
+	ErrInvalidPoll = errors.New("poll is not valid")
+	ErrInvalidPollOption = errors.New("poll option is not valid")
+	ErrPostHasNoPoll = errors.New("post doesn't have poll")
+	ErrPollClosed = errors.New("poll already closed")
+	ErrAlreadyVoted = errors.New("already voted")
 
 	ErrInvalidFeedType = errors.New("invalid feed type")
 	ErrUserNotFollowAnyone = errors.New("user not following anyone")
+	ErrInvalidTrendingWindow = errors.New("trending window is not valid")
+	ErrEngagementSnapshotNotFound = errors.New("engagement snapshot not found")
 )
+
+// RestrictionError wrap ErrAccountSuspended or ErrAccountBanned with the reason shown to the user,
+// use errors.Is to tell which one it is
+type RestrictionError struct {
+	Err    error
+	Reason string
+	// Until is the unix time the suspension end, 0 if banned
+	Until  int64
+}
+
+func (e *RestrictionError) Error() string {
+	message := e.Err.Error()
+	if e.Until != 0 {
+		message += " until " + time.Unix(e.Until, 0).UTC().Format(time.RFC3339)
+	}
+	if e.Reason != "" {
+		message += ": " + e.Reason
+	}
+	return message
+}
+
+func (e *RestrictionError) Unwrap() error {
+	return e.Err
+}
+
+// PersonalDataError wrap ErrPersonalDataUnconfirmed with the found text, so the client can point them out
+type PersonalDataError struct {
+	Texts []string
+}
+
+func (e *PersonalDataError) Error() string {
+	quoted := make([]string, len(e.Texts))
+	for i, v := range e.Texts {
+		quoted[i] = strconv.Quote(v)
+	}
+	return ErrPersonalDataUnconfirmed.Error() + ": " + strings.Join(quoted, ", ")
+}
+
+func (e *PersonalDataError) Unwrap() error {
+	return ErrPersonalDataUnconfirmed
+}
+
+// ModerationError wrap ErrPostRejected with the constants.ModerationReason* the post is rejected for
+type ModerationError struct {
+	Reasons []string
+}
+
+func (e *ModerationError) Error() string {
+	if len(e.Reasons) == 0 {
+		return ErrPostRejected.Error()
+	}
+	return ErrPostRejected.Error() + ": " + strings.Join(e.Reasons, ", ")
+}
+
+func (e *ModerationError) Unwrap() error {
+	return ErrPostRejected
+}
+
+// RateLimitError wrap ErrRateLimited with how long (in second) the client should wait before trying again
+type RateLimitError struct {
+	RetryAfter int64
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s, retry after %d seconds", ErrRateLimited.Error(), e.RetryAfter)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
@@ -5,3 +5,17 @@ var AuthorizationKey = struct {
 }{
 	Key: "Authorization",
 }
+
+// AccountStatusKey hold the RestrictionError of the token user, set once per request
+var AccountStatusKey = struct {
+	Key string
+}{
+	Key: "AccountStatus",
+}
+
+// ClientIPKey hold the client ip of the request, taken from the trusted proxy hop and never from a header the client set
+var ClientIPKey = struct {
+	Key string
+}{
+	Key: "ClientIP",
+}
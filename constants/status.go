@@ -3,4 +3,10 @@ package constants
 const (
 	FollowStatusActive = "active"
 	FollowStatusInactive = "inactive"
+
+	BlockStatusActive = "active"
+	BlockStatusInactive = "inactive"
+
+	MuteStatusActive = "active"
+	MuteStatusInactive = "inactive"
 )
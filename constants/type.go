@@ -4,3 +4,328 @@ const(
 	FeedTypeAll = "all"
 	FeedTypeFollow = "follow"
 )
+
+const (
+	RoleUser      = ""
+	RoleAdmin     = "admin"
+	// RoleModerator can review reported post, admin can do everything moderator can
+	RoleModerator = "moderator"
+)
+
+const (
+	ContentWarningSelfHarm = "self_harm"
+	ContentWarningNSFW     = "nsfw"
+	ContentWarningSpoiler  = "spoiler"
+)
+
+// ContentWarnings is the list of content warning category a post can be marked with
+var ContentWarnings = []string{ContentWarningSelfHarm, ContentWarningNSFW, ContentWarningSpoiler}
+
+// SensitiveContent* is the user setting of how post with content warning shown in the feed
+const (
+	SensitiveContentHide = "hide"
+	SensitiveContentBlur = "blur"
+	SensitiveContentShow = "show"
+	// SensitiveContentDefault is used when the user never change the setting
+	SensitiveContentDefault = SensitiveContentBlur
+)
+
+const (
+	TrendingWindowDefault = 24
+	TrendingLimit         = 50
+)
+
+// TrendingWindows is the list of window (in hours) computed by trending job
+var TrendingWindows = []int{6, 24, 72}
+
+const (
+	PollMinOptions      = 2
+	PollMaxOptions      = 6
+	PollMaxOptionLength = 100
+	PollMaxDuration     = 7 * 24 * 3600
+)
+
+// ScheduleMaxAhead is how far (in second) a post can be scheduled
+const ScheduleMaxAhead = 30 * 24 * 3600
+
+// PostMinLifetime and PostMaxLifetime bound (in second) how long a self-destructing post can live
+const (
+	PostMinLifetime = 5 * 60
+	PostMaxLifetime = 30 * 24 * 3600
+)
+
+const (
+	HandleMinLength = 3
+	HandleMaxLength = 20
+	// HandleChangeCooldown is how long (in second) the user wait before changing the handle again
+	HandleChangeCooldown = 30 * 24 * 3600
+	// PostMaxMentions is how many mentioned user in a post get notified, the rest is treated as plain text
+	PostMaxMentions = 10
+)
+
+const (
+	MutedWordMaxLength   = 100
+	// MutedWordMaxCount is how many muted word a user can have, expired one isn't counted
+	MutedWordMaxCount    = 200
+	MutedWordMaxDuration = 365 * 24 * 3600
+)
+
+const (
+	ReportReasonSpam         = "spam"
+	ReportReasonHarassment   = "harassment"
+	ReportReasonPersonalData = "personal_data"
+	ReportReasonHateSpeech   = "hate_speech"
+	ReportReasonSensitive    = "sensitive"
+	ReportReasonOther        = "other"
+	// ReportReasonAutomated is the report filed by the content filter, the user can't pick it
+	ReportReasonAutomated    = "automated"
+)
+
+// ReportReasons is the list of reason a post can be reported for
+var ReportReasons = []string{
+	ReportReasonSpam,
+	ReportReasonHarassment,
+	ReportReasonPersonalData,
+	ReportReasonHateSpeech,
+	ReportReasonSensitive,
+	ReportReasonOther,
+}
+
+const (
+	ReportStatusPending  = "pending"
+	ReportStatusResolved = "resolved"
+)
+
+// ReportAction* is what the moderator do with the reported post
+const (
+	ReportActionDismiss     = "dismiss"
+	ReportActionHidePost    = "hide_post"
+	ReportActionDeletePost  = "delete_post"
+	ReportActionWarnUser    = "warn_user"
+	ReportActionSuspendUser = "suspend_user"
+)
+
+// ReportActions is the list of action to resolve a report with
+var ReportActions = []string{
+	ReportActionDismiss,
+	ReportActionHidePost,
+	ReportActionDeletePost,
+	ReportActionWarnUser,
+	ReportActionSuspendUser,
+}
+
+// ModerateAction* is what the admin do to a user account directly
+const (
+	ModerateActionSuspend = "suspend"
+	ModerateActionBan     = "ban"
+	// ModerateActionShadowban keep the user posting, but only the user can see the posts and likes
+	ModerateActionShadowban = "shadowban"
+	// ModerateActionLift remove the suspension, the ban and the shadowban
+	ModerateActionLift    = "lift"
+)
+
+// ModerateActions is the list of action an admin can do to a user account
+var ModerateActions = []string{ModerateActionSuspend, ModerateActionBan, ModerateActionShadowban, ModerateActionLift}
+
+// AuditAction* is the action recorded in the audit log
+const (
+	AuditActionSuspendUser      = "suspend_user"
+	AuditActionBanUser          = "ban_user"
+	AuditActionShadowbanUser    = "shadowban_user"
+	AuditActionLiftRestriction  = "lift_restriction"
+	AuditActionWarnUser         = "warn_user"
+	// AuditActionDismissReport unhide the reported post, for the post flagged by the content filter it's the approval
+	AuditActionDismissReport    = "dismiss_report"
+	AuditActionHidePost         = "hide_post"
+	AuditActionDeletePost       = "delete_post"
+	AuditActionSetContentWarnings = "set_content_warnings"
+	AuditActionAddAvatar        = "add_avatar"
+	AuditActionRemoveAvatar     = "remove_avatar"
+	// AuditActionFailed follow the entry of the same target whose change failed after it was written,
+	// the reason is the failed action and the before is the after that wasn't applied
+	AuditActionFailed           = "failed"
+)
+
+// AuditTarget* is the type of the audit log target
+const (
+	AuditTargetUser   = "user"
+	AuditTargetPost   = "post"
+	AuditTargetAvatar = "avatar"
+)
+
+const (
+	// AuditLogExportMaxEntries bound the exported log, a larger export needs narrower filter
+	AuditLogExportMaxEntries = 10000
+	AuditLogExportBatchSize  = 500
+)
+
+const (
+	ModerateReasonMaxLength    = 500
+	ReportDetailsMaxLength     = 500
+	// ReportHideThresholdDefault is how many pending report hide the post until it's reviewed
+	ReportHideThresholdDefault = 5
+	// SuspendDurationDefault and SuspendMaxDuration (in second) bound the suspension from a report
+	SuspendDurationDefault     = 7 * 24 * 3600
+	SuspendMaxDuration         = 365 * 24 * 3600
+)
+
+// ModerationDecision* is the content filter decision on a new post
+const (
+	ModerationDecisionAllow  = "allow"
+	// ModerationDecisionFlag publish the post hidden until a moderator review it
+	ModerationDecisionFlag   = "flag"
+	ModerationDecisionReject = "reject"
+)
+
+// ModerationReason* is why the content filter flag or reject a post
+const (
+	ModerationReasonBannedWord    = "banned_word"
+	ModerationReasonBannedPattern = "banned_pattern"
+	ModerationReasonPhoneNumber   = "phone_number"
+	// ModerationReasonNIK is the Indonesian national identity number
+	ModerationReasonNIK           = "nik"
+	ModerationReasonStudentID     = "student_id"
+	ModerationReasonTooManyLinks  = "too_many_links"
+)
+
+// PersonalData* is the type of personal data the redaction detect in a post
+const (
+	PersonalDataPhoneNumber     = "phone_number"
+	// PersonalDataNIK is the Indonesian national identity number
+	PersonalDataNIK             = "nik"
+	PersonalDataStudentID       = "student_id"
+	PersonalDataInstagramHandle = "instagram_handle"
+	PersonalDataFullName        = "full_name"
+)
+
+// RedactionAction* is what the redaction do with the personal data found
+const (
+	RedactionActionMask    = "mask"
+	// RedactionActionConfirm keep the data as is, but the post is only saved after the user confirm it
+	RedactionActionConfirm = "confirm"
+	// RedactionMask replace the masked personal data
+	RedactionMask          = "[redacted]"
+)
+
+const (
+	// ModerationMaxLinksDefault is how many url a post can have before it's flagged
+	ModerationMaxLinksDefault = 5
+)
+
+// RateLimit allow Limit action in every Window (in second)
+type RateLimit struct {
+	Limit  int
+	Window int64
+}
+
+// RateLimit* bound how often the action is done by a user and by a client ip,
+// the ip limit is higher since many accounts may share a network like the campus wifi
+var (
+	RateLimitCreatePostUser = RateLimit{Limit: 10, Window: 10 * 60}
+	RateLimitCreatePostIP   = RateLimit{Limit: 60, Window: 10 * 60}
+	RateLimitLikePostUser   = RateLimit{Limit: 60, Window: 60}
+	RateLimitLikePostIP     = RateLimit{Limit: 300, Window: 60}
+	RateLimitFollowUser     = RateLimit{Limit: 30, Window: 10 * 60}
+	RateLimitFollowIP       = RateLimit{Limit: 120, Window: 10 * 60}
+	RateLimitRegisterIP     = RateLimit{Limit: 10, Window: 60 * 60}
+	// RateLimitRegisterDevice bound the new account from a single push token, the device id sent on registration
+	RateLimitRegisterDevice = RateLimit{Limit: 3, Window: 24 * 60 * 60}
+	// RateLimitRegisterSubnet bound the new account from the whole RateLimitSubnetIPv4Bits or RateLimitSubnetIPv6Bits network,
+	// so a bot farm rotating its address is still caught without blocking the registration of everyone else
+	RateLimitRegisterSubnet = RateLimit{Limit: 30, Window: 60 * 60}
+)
+
+const (
+	// RateLimitIPv6Bits is the prefix counted as a single client ip, a single host usually get a whole /64
+	RateLimitIPv6Bits       = 64
+	RateLimitSubnetIPv4Bits = 24
+	RateLimitSubnetIPv6Bits = 48
+)
+
+const (
+	// PostDuplicateWindow (in second) and PostDuplicateLookback bound the recent posts of the user a new post is compared with
+	PostDuplicateWindow      = 60 * 60
+	PostDuplicateLookback    = 20
+	// PostDuplicateMaxDistance is the max simhash distance of the near-duplicate post
+	PostDuplicateMaxDistance = 10
+	// PostDuplicateMinLength (in rune) skip the short body, reply like "setuju" is repeated legitimately
+	PostDuplicateMinLength   = 20
+)
+
+const (
+	// PostMaxLinkPreviews is how many url in the post body get a preview, the rest is ignored
+	PostMaxLinkPreviews    = 3
+	// LinkPreviewBatchSize is how many post the link preview job process each run
+	LinkPreviewBatchSize   = 20
+	// LinkPreviewMaxAttempts is how many failed lookup before the post is saved without preview
+	LinkPreviewMaxAttempts = 5
+	// LinkPreviewRetryDelay is the seconds before the first retry, doubled on each failed attempt
+	LinkPreviewRetryDelay  = 60
+)
+
+const (
+	ImageMaxSize       = 5 << 20
+	PostMaxAttachments = 4
+	// ImageMaxDimension is the max width or height of stored full size image, larger image is downscaled
+	ImageMaxDimension  = 2048
+	// ImageMaxPixels is the max width x height of an upload, checked before decoding,
+	// a small compressed file can decode into gigabytes of pixels
+	ImageMaxPixels     = 24000000
+	// ImageMaxFrames and ImageMaxTotalFramePixels limit the gif, every frame of it is decoded
+	ImageMaxFrames           = 300
+	ImageMaxTotalFramePixels = 60000000
+)
+
+const (
+	ImageVariantOriginal  = "original"
+	ImageVariantMedium    = "medium"
+	ImageVariantThumbnail = "thumbnail"
+)
+
+// ImageVariantSizes is the max dimension of each resized variant generated on upload, largest first
+var ImageVariantSizes = []struct {
+	Name string
+	Size int
+}{
+	{Name: ImageVariantMedium, Size: 1080},
+	{Name: ImageVariantThumbnail, Size: 320},
+}
+
+// ImageExtensions map the allowed upload content type to its file extension
+var ImageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// ReservedHandles can't be taken as handle, also when it's only decorated with digits or underscores like admin_01
+var ReservedHandles = map[string]bool{
+	"admin":         true,
+	"administrator": true,
+	"api":           true,
+	"help":          true,
+	"menfess":       true,
+	"mod":           true,
+	"moderator":     true,
+	"null":          true,
+	"official":      true,
+	"root":          true,
+	"staff":         true,
+	"support":       true,
+	"system":        true,
+	"undefined":     true,
+}
+
+// DefaultAvatars is seeded into the avatar catalog when it's still empty
+var DefaultAvatars = []string{
+	"https://i.ibb.co/R2xRyg3/upin.jpg",
+	"https://i.ibb.co/4jRdmh5/spiderman.jpg",
+	"https://i.ibb.co/3pDTY1f/saitama.jpg",
+	"https://i.ibb.co/WsK9bLP/ronald.jpg",
+	"https://i.ibb.co/xMXNWBj/mrbean.jpg",
+	"https://i.ibb.co/72TNcHd/monalisa.jpg",
+	"https://i.ibb.co/t2M7zY5/kaonashi.jpg",
+	"https://i.ibb.co/Gv5RSgs/ipin.jpg",
+	"https://i.ibb.co/Vmm19Q4/einstein.jpg",
+	"https://i.ibb.co/84ypfNc/batman.jpg",
+}
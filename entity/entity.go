@@ -1,30 +1,235 @@
 package entity
 
 type User struct {
-	ID      string
-	Account Account
-	Profile Profile
+	ID       string
+	Account  Account
+	Profile  Profile
+	Settings Settings
 }
 
 type Account struct {
 	Email    string
 	Password string
+	Role     string
+	// HandleChangedAt is the unix time the handle last set, used to limit how often it's changed
+	HandleChangedAt int64
+	// SuspendedUntil is the unix time the suspension end, 0 if never suspended
+	SuspendedUntil   int64
+	SuspensionReason string
+	// BannedAt is the unix time the account banned, 0 if not banned
+	BannedAt  int64
+	BanReason string
+	// ShadowbannedAt is the unix time the account shadowbanned, 0 if not shadowbanned
+	ShadowbannedAt int64
+}
+
+// BannedDevice is the push token of a banned user device, it can't register or login to other account
+type BannedDevice struct {
+	PushToken string
+	UserID    string
+	Reason    string
+}
+
+type AuditLog struct {
+	ID         string
+	ActorID    string
+	Action     string
+	// TargetType is one of constants.AuditTarget*
+	TargetType string
+	TargetID   string
+	// Before and After is the target fields touched by the action, nil if the target didn't exist
+	Before     Snapshot
+	After      Snapshot
+	Reason     string
+	Timestamp  int64
+}
+
+// Snapshot is the flat field to value map of the audited target
+type Snapshot map[string]interface{}
+
+// AuditLogFilter only filter the non zero field
+type AuditLogFilter struct {
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	// From and To bound the log unix time, both inclusive
+	From       int64
+	To         int64
 }
 
 type Profile struct {
 	Name   string
+	// Handle is the unique lowercase username used to mention the user, empty if not set yet
+	Handle string
 	Avatar string
 	Bio    string
 }
 
+type Settings struct {
+	// SensitiveContent is how post with content warning shown in the feed, hide, blur or show
+	SensitiveContent string
+}
+
+type MutedWord struct {
+	Word string
+	// ExpiredAt is 0 if the word is muted forever
+	ExpiredAt int64
+}
+
 type Post struct {
 	ID           string
 	Body         string
 	Timestamp    int64
+	ExpiredAt    int64
 	RepliesCount int
 	LikesCount   int
 	IsLiked      bool
+	IsBookmarked bool
+	IsRepost     bool
+	IsScheduled  bool
+	// ContentWarnings is the content warning categories the post marked with
+	ContentWarnings []string
+	// MentionIDs is the id of user mentioned in the body
+	MentionIDs   []string
+	// Links is the url found in the body, its preview is fetched after the post created
+	Links        []string
+	// LinkPreviewAttempts is how many times looking up the preview failed, only filled for the pending post
+	LinkPreviewAttempts int
+	LinkPreviews []LinkPreview
+	// IsBlurred tell client to blur the post until the user choose to see it
+	IsBlurred    bool
+	// IsHidden is set by moderator or by enough reports, hidden post is only shown in the moderation queue
+	IsHidden     bool
+	// IsShadowbanned mark the post of a shadowbanned user, it's only shown to the user, never exposed to client
+	IsShadowbanned bool
 	Parent       *Post
+	QuotedPost   *Post
+	Poll         *Poll
+	Attachments  []Attachment
 	Author       User
 	User         User
 }
+
+type Poll struct {
+	Options        []PollOption
+	MultipleChoice bool
+	ExpiredAt      int64
+	VotersCount    int
+	MyVotes        []string
+}
+
+// IsClosed report whether the poll no longer accept vote, poll without expiry never close
+func (p Poll) IsClosed(now int64) bool {
+	return p.ExpiredAt != 0 && now >= p.ExpiredAt
+}
+
+// IsResultVisible report whether the result can be shown, user only see it after vote or the poll closed
+func (p Poll) IsResultVisible(now int64) bool {
+	return len(p.MyVotes) > 0 || p.IsClosed(now)
+}
+
+type PollOption struct {
+	ID         string
+	Text       string
+	VotesCount int
+}
+
+type LinkPreview struct {
+	URL         string
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+}
+
+// PersonalData is the personal data found in a text
+type PersonalData struct {
+	// Type is one of constants.PersonalData*
+	Type string
+	Text string
+}
+
+// Redaction is the text with its personal data masked
+type Redaction struct {
+	Text        string
+	Masked      []PersonalData
+	// Unconfirmed is the personal data kept as is, the user has to confirm posting it
+	Unconfirmed []PersonalData
+}
+
+// ModerationResult is the content filter review of a post before it's published
+type ModerationResult struct {
+	// Decision is one of constants.ModerationDecision*
+	Decision string
+	// Reasons is the constants.ModerationReason* the post is flagged or rejected for
+	Reasons  []string
+}
+
+type Report struct {
+	ID     string
+	PostID string
+	// ReportedUserID is the user who posted it, kept so the user can be acted on after the post deleted
+	ReportedUserID string
+	ReporterID     string
+	Reason         string
+	Details        string
+	Status         string
+	// Action is what the moderator did, empty while pending
+	Action     string
+	ResolvedBy string
+	ResolvedAt int64
+	Timestamp  int64
+	// Post is only filled for the moderation queue, nil if the post already deleted
+	Post *Post
+}
+
+type Avatar struct {
+	ID  string
+	URL string
+}
+
+type Attachment struct {
+	ID          string
+	URL         string
+	ContentType string
+	Size        int
+	Width       int
+	Height      int
+	BlurHash    string
+	Variants    []ImageVariant
+	OwnerID     string
+}
+
+// ProcessedImage is uploaded image re-encoded into its variants, the first variant is the full size image
+type ProcessedImage struct {
+	BlurHash string
+	Variants []ImageVariant
+}
+
+type ImageVariant struct {
+	Name        string
+	URL         string
+	ContentType string
+	Width       int
+	Height      int
+	// Data is the encoded image, only filled before it's stored
+	Data        []byte
+}
+
+type TrendingPost struct {
+	PostID string
+	Score  float64
+}
+
+// EngagementSnapshot is likes + replies by post id at the time it's taken
+type EngagementSnapshot struct {
+	TakenAt    int64
+	Engagement map[string]int
+}
+
+type Topic struct {
+	Name       string
+	PostsCount int
+	Score      float64
+}
@@ -4,10 +4,12 @@ go 1.16
 
 require (
 	github.com/aeramu/mongolib v1.2.0
+	github.com/aws/aws-sdk-go v1.34.28
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/graph-gophers/graphql-go v1.1.0
 	github.com/sirupsen/logrus v1.4.2
 	github.com/stretchr/testify v1.7.0
 	go.mongodb.org/mongo-driver v1.4.4
 	golang.org/x/crypto v0.0.0-20190820162420-60c769a6c586
+	golang.org/x/text v0.3.3
 )
@@ -1,15 +1,28 @@
 package graphql
 
+import (
+	"errors"
+	"github.com/aeramu/menfess-backend/constants"
+)
+
 type Err struct {
 	IsError bool
 	Message string
+	// RetryAfter is how many second to wait before retrying the rate limited request
+	RetryAfter *int32
 }
 
 func Error(err error) Err {
-	return Err{
+	e := Err{
 		IsError: true,
 		Message: err.Error(),
 	}
+	var rateLimitErr *constants.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		retryAfter := int32(rateLimitErr.RetryAfter)
+		e.RetryAfter = &retryAfter
+	}
+	return e
 }
 
 var NoError = Err{
@@ -38,7 +51,7 @@ type FeedResponse struct {
 }
 
 type MeResponse struct {
-	Payload User
+	Payload Me
 	Error Err
 }
 
@@ -50,4 +63,48 @@ type MenfessResponse struct {
 type AvatarsResponse struct {
 	Payload []string
 	Error Err
-}
\ No newline at end of file
+}
+
+type TrendingTopicsResponse struct {
+	Payload []Topic
+	Error Err
+}
+type UploadImageResponse struct {
+	Payload Attachment
+	Error Err
+}
+
+type UploadAvatarResponse struct {
+	Payload string
+	Error Err
+}
+
+type UserResponse struct {
+	Payload User
+	Error Err
+}
+
+type UserProfileResponse struct {
+	Payload UserProfile
+	Error Err
+}
+
+type HandleAvailableResponse struct {
+	Payload bool
+	Error Err
+}
+
+type ModerationQueueResponse struct {
+	Payload ReportConnection
+	Error Err
+}
+
+type AuditLogResponse struct {
+	Payload AuditLogConnection
+	Error Err
+}
+
+type ExportResponse struct {
+	Payload string
+	Error Err
+}
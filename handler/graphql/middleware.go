@@ -5,13 +5,33 @@ import (
 	"errors"
 	"github.com/aeramu/menfess-backend/constants"
 	"github.com/aeramu/menfess-backend/modules/auth"
+	"github.com/aeramu/menfess-backend/service"
+	"github.com/aeramu/menfess-backend/service/api"
 )
 
 type Token struct {
 	UserID string
 }
 
+// DecodeToken also return the RestrictionError if the user is suspended or banned
 func DecodeToken(ctx context.Context) (*Token, error) {
+	token, err := decodeToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err, ok := ctx.Value(constants.AccountStatusKey).(error); ok {
+		return nil, err
+	}
+	return token, nil
+}
+
+// ClientIP return the client ip of the request, empty if the server didn't set it
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(constants.ClientIPKey).(string)
+	return ip
+}
+
+func decodeToken(ctx context.Context) (*Token, error) {
 	tokenString, ok := ctx.Value(constants.AuthorizationKey).(string)
 	if !ok {
 		return nil, errors.New("token is required")
@@ -24,3 +44,17 @@ func DecodeToken(ctx context.Context) (*Token, error) {
 		UserID: claim.UserID,
 	}, nil
 }
+
+// WithAccountStatus check the token user once per request, so DecodeToken doesn't hit the database
+// on every resolver. Only suspension and ban are kept, other error let the request through.
+func WithAccountStatus(ctx context.Context, svc service.Service) context.Context {
+	token, err := decodeToken(ctx)
+	if err != nil {
+		return ctx
+	}
+	_, err = svc.CheckAccountStatus(ctx, api.CheckAccountStatusReq{UserID: token.UserID})
+	if errors.Is(err, constants.ErrAccountSuspended) || errors.Is(err, constants.ErrAccountBanned) {
+		return context.WithValue(ctx, constants.AccountStatusKey, err)
+	}
+	return ctx
+}
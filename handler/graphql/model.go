@@ -2,19 +2,215 @@ package graphql
 
 import (
 	"context"
+	"encoding/json"
+	"github.com/aeramu/menfess-backend/constants"
 	"github.com/aeramu/menfess-backend/entity"
 	"github.com/aeramu/menfess-backend/service/api"
 	"github.com/graph-gophers/graphql-go"
+	"strings"
+	"time"
 )
 
 type User struct {
 	ID         graphql.ID
 	Name       string
+	Handle     string
 	Avatar     string
 	Bio        string
 	IsFollowed bool
 }
 
+type Me struct {
+	*Resolver
+	ID         graphql.ID
+	Name       string
+	Handle     string
+	Avatar     string
+	Bio        string
+	IsFollowed bool
+	SensitiveContent string
+}
+
+// sensitiveContent fill the default setting for user who never change it
+func sensitiveContent(settings entity.Settings) string {
+	if settings.SensitiveContent == "" {
+		return constants.SensitiveContentDefault
+	}
+	return settings.SensitiveContent
+}
+
+func (m Me) Bookmarks(ctx context.Context, input struct{
+	First int32
+	After *graphql.ID
+}) PostConnection {
+	req := api.GetBookmarkListReq{
+		UserID:     string(m.ID),
+		Pagination: api.PaginationReq{
+			First: int(input.First),
+		},
+	}
+	if input.After != nil {
+		req.Pagination.After = string(*input.After)
+	}
+	res, err := m.svc.GetBookmarkList(ctx, req)
+	if err != nil {
+		return PostConnection{}
+	}
+
+	return PostConnection{
+		Edges:    ResolvePostEdges(m.Resolver, res.PostList),
+		PageInfo: PageInfo{
+			EndCursor:   graphql.ID(res.Pagination.EndCursor),
+			HasNextPage: res.Pagination.HasNextPage,
+		},
+	}
+}
+
+func (m Me) ScheduledPosts(ctx context.Context, input struct{
+	First int32
+	After *graphql.ID
+}) PostConnection {
+	req := api.GetScheduledPostListReq{
+		UserID:     string(m.ID),
+		Pagination: api.PaginationReq{
+			First: int(input.First),
+		},
+	}
+	if input.After != nil {
+		req.Pagination.After = string(*input.After)
+	}
+	res, err := m.svc.GetScheduledPostList(ctx, req)
+	if err != nil {
+		return PostConnection{}
+	}
+
+	return PostConnection{
+		Edges:    ResolvePostEdges(m.Resolver, res.PostList),
+		PageInfo: PageInfo{
+			EndCursor:   graphql.ID(res.Pagination.EndCursor),
+			HasNextPage: res.Pagination.HasNextPage,
+		},
+	}
+}
+
+type UserProfile struct {
+	*Resolver
+	ID             graphql.ID
+	Name           string
+	Handle         string
+	Avatar         string
+	Bio            string
+	FollowersCount int32
+	FollowingCount int32
+	PostsCount     int32
+	IsFollowed     bool
+	FollowsYou     bool
+}
+
+func ResolveUserProfile(r *Resolver, res api.GetUserProfileRes) UserProfile {
+	return UserProfile{
+		Resolver:       r,
+		ID:             graphql.ID(res.User.ID),
+		Name:           res.User.Profile.Name,
+		Handle:         res.User.Profile.Handle,
+		Avatar:         res.User.Profile.Avatar,
+		Bio:            res.User.Profile.Bio,
+		FollowersCount: int32(res.FollowersCount),
+		FollowingCount: int32(res.FollowingCount),
+		PostsCount:     int32(res.PostsCount),
+		IsFollowed:     res.IsFollowed,
+		FollowsYou:     res.FollowsYou,
+	}
+}
+
+func (u UserProfile) Followers(ctx context.Context, input struct{
+	First int32
+	After *graphql.ID
+}) UserConnection {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return UserConnection{}
+	}
+	req := api.GetFollowerListReq{
+		ID:         string(u.ID),
+		UserID:     token.UserID,
+		Pagination: api.PaginationReq{
+			First: int(input.First),
+		},
+	}
+	if input.After != nil {
+		req.Pagination.After = string(*input.After)
+	}
+	res, err := u.svc.GetFollowerList(ctx, req)
+	if err != nil {
+		return UserConnection{}
+	}
+
+	return UserConnection{
+		Edges:    ResolveUserEdges(res.UserList, res.FollowedIDs),
+		PageInfo: PageInfo{
+			EndCursor:   graphql.ID(res.Pagination.EndCursor),
+			HasNextPage: res.Pagination.HasNextPage,
+		},
+	}
+}
+
+func (u UserProfile) Following(ctx context.Context, input struct{
+	First int32
+	After *graphql.ID
+}) UserConnection {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return UserConnection{}
+	}
+	req := api.GetFollowingListReq{
+		ID:         string(u.ID),
+		UserID:     token.UserID,
+		Pagination: api.PaginationReq{
+			First: int(input.First),
+		},
+	}
+	if input.After != nil {
+		req.Pagination.After = string(*input.After)
+	}
+	res, err := u.svc.GetFollowingList(ctx, req)
+	if err != nil {
+		return UserConnection{}
+	}
+
+	return UserConnection{
+		Edges:    ResolveUserEdges(res.UserList, res.FollowedIDs),
+		PageInfo: PageInfo{
+			EndCursor:   graphql.ID(res.Pagination.EndCursor),
+			HasNextPage: res.Pagination.HasNextPage,
+		},
+	}
+}
+
+type MutedWord struct {
+	Word      string
+	ExpiresAt *int32
+}
+
+func (m Me) MutedWords(ctx context.Context) []MutedWord {
+	res, err := m.svc.GetMutedWordList(ctx, api.GetMutedWordListReq{
+		UserID: string(m.ID),
+	})
+	if err != nil {
+		return []MutedWord{}
+	}
+
+	result := make([]MutedWord, len(res.MutedWordList))
+	for i, v := range res.MutedWordList {
+		result[i] = MutedWord{Word: v.Word}
+		if v.ExpiredAt != 0 {
+			expiresAt := int32(v.ExpiredAt)
+			result[i].ExpiresAt = &expiresAt
+		}
+	}
+	return result
+}
+
 type UserEdge struct {
 	Node   User
 	Cursor graphql.ID
@@ -29,6 +225,7 @@ func ResolveUser(user entity.User, isFollowed bool) User {
 	return User{
 		ID:         graphql.ID(user.ID),
 		Name:       user.Profile.Name,
+		Handle:     user.Profile.Handle,
 		Avatar:     user.Profile.Avatar,
 		Bio:        user.Profile.Bio,
 		IsFollowed: isFollowed,
@@ -55,10 +252,21 @@ type Post struct {
 	ID           graphql.ID
 	Body         string
 	Timestamp    int32
+	ExpiresAt    *int32
 	Author       User
 	LikesCount   int32
 	RepliesCount int32
 	IsLiked      bool
+	IsBookmarked bool
+	IsRepost     bool
+	IsScheduled  bool
+	QuotedPost   *Post
+	Poll         *Poll
+	Attachments  []Attachment
+	ContentWarnings []string
+	IsBlurred    bool
+	IsHidden     bool
+	LinkPreviews []LinkPreview
 }
 
 func (p Post) Replies(ctx context.Context, input struct{
@@ -104,18 +312,47 @@ type PostConnection struct {
 }
 
 func ResolvePost(r *Resolver, post entity.Post) Post {
+	var quotedPost *Post
+	if post.QuotedPost != nil {
+		quoted := ResolvePost(r, *post.QuotedPost)
+		quotedPost = &quoted
+	}
+	var expiresAt *int32
+	if post.ExpiredAt != 0 {
+		expiredAt := int32(post.ExpiredAt)
+		expiresAt = &expiredAt
+	}
 	return Post{
 		Resolver:     r,
 		ID:           graphql.ID(post.ID),
 		Body:         post.Body,
 		Timestamp:    int32(post.Timestamp),
+		ExpiresAt:    expiresAt,
 		Author:       ResolveUser(post.Author, false),
 		LikesCount:   int32(post.LikesCount),
 		RepliesCount: int32(post.RepliesCount),
 		IsLiked:      post.IsLiked,
+		IsBookmarked: post.IsBookmarked,
+		IsRepost:     post.IsRepost,
+		IsScheduled:  post.IsScheduled,
+		QuotedPost:   quotedPost,
+		Poll:         ResolvePoll(post.Poll),
+		Attachments:  ResolveAttachments(post.Attachments),
+		ContentWarnings: contentWarnings(post.ContentWarnings),
+		IsBlurred:    post.IsBlurred,
+		IsHidden:     post.IsHidden,
+		LinkPreviews: ResolveLinkPreviews(post.LinkPreviews),
 	}
 }
 
+// contentWarnings never return nil, the schema field is non null list
+func contentWarnings(warnings []string) []string {
+	if warnings == nil {
+		return []string{}
+	}
+	return warnings
+}
+
 func ResolvePostEdges(r *Resolver, posts []entity.Post) []PostEdge {
 	var edges []PostEdge
 	for _, v := range posts {
@@ -127,7 +364,330 @@ func ResolvePostEdges(r *Resolver, posts []entity.Post) []PostEdge {
 	return edges
 }
 
+type Report struct {
+	ID           graphql.ID
+	Reason       string
+	Details      string
+	Timestamp    int32
+	ReportsCount int32
+	Post         *Post
+}
+
+type ReportEdge struct {
+	Node   Report
+	Cursor graphql.ID
+}
+
+type ReportConnection struct {
+	Edges    []ReportEdge
+	PageInfo PageInfo
+}
+
+func ResolveReportEdges(r *Resolver, res api.GetModerationQueueRes) []ReportEdge {
+	edges := make([]ReportEdge, len(res.ReportList))
+	for i, v := range res.ReportList {
+		var post *Post
+		if v.Post != nil {
+			resolved := ResolvePost(r, *v.Post)
+			post = &resolved
+		}
+		edges[i] = ReportEdge{
+			Node:   Report{
+				ID:           graphql.ID(v.ID),
+				Reason:       v.Reason,
+				Details:      v.Details,
+				Timestamp:    int32(v.Timestamp),
+				ReportsCount: int32(res.ReportsCount[v.PostID]),
+				Post:         post,
+			},
+			Cursor: graphql.ID(v.ID),
+		}
+	}
+	return edges
+}
+
+type AuditLog struct {
+	ID         graphql.ID
+	ActorID    graphql.ID
+	Action     string
+	TargetType string
+	TargetID   graphql.ID
+	Before     *string
+	After      *string
+	Reason     string
+	Timestamp  int32
+}
+
+type AuditLogEdge struct {
+	Node   AuditLog
+	Cursor graphql.ID
+}
+
+type AuditLogConnection struct {
+	Edges    []AuditLogEdge
+	PageInfo PageInfo
+}
+
+func ResolveAuditLogEdges(logs []entity.AuditLog) []AuditLogEdge {
+	edges := make([]AuditLogEdge, len(logs))
+	for i, v := range logs {
+		edges[i] = AuditLogEdge{
+			Node:   AuditLog{
+				ID:         graphql.ID(v.ID),
+				ActorID:    graphql.ID(v.ActorID),
+				Action:     v.Action,
+				TargetType: v.TargetType,
+				TargetID:   graphql.ID(v.TargetID),
+				Before:     snapshotJSON(v.Before),
+				After:      snapshotJSON(v.After),
+				Reason:     v.Reason,
+				Timestamp:  int32(v.Timestamp),
+			},
+			Cursor: graphql.ID(v.ID),
+		}
+	}
+	return edges
+}
+
+// snapshotJSON return nil for the target that didn't exist
+func snapshotJSON(snapshot entity.Snapshot) *string {
+	if snapshot == nil {
+		return nil
+	}
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil
+	}
+	result := string(b)
+	return &result
+}
+
+// auditLogLine is the exported audit log entry, the snapshot is kept as JSON object
+type auditLogLine struct {
+	ID         string          `json:"id"`
+	ActorID    string          `json:"actorID"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"targetType"`
+	TargetID   string          `json:"targetID"`
+	Before     entity.Snapshot `json:"before"`
+	After      entity.Snapshot `json:"after"`
+	Reason     string          `json:"reason"`
+	Timestamp  int64           `json:"timestamp"`
+}
+
+// AuditLogJSONLines encode each log as a JSON object on its own line
+func AuditLogJSONLines(logs []entity.AuditLog) (string, error) {
+	var b strings.Builder
+	encoder := json.NewEncoder(&b)
+	for _, v := range logs {
+		if err := encoder.Encode(auditLogLine{
+			ID:         v.ID,
+			ActorID:    v.ActorID,
+			Action:     v.Action,
+			TargetType: v.TargetType,
+			TargetID:   v.TargetID,
+			Before:     v.Before,
+			After:      v.After,
+			Reason:     v.Reason,
+			Timestamp:  v.Timestamp,
+		}); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+type AuditLogFilterInput struct {
+	ActorID    *graphql.ID
+	Action     *string
+	TargetType *string
+	TargetID   *graphql.ID
+	From       *int32
+	To         *int32
+}
+
+func (f *AuditLogFilterInput) Entity() entity.AuditLogFilter {
+	var filter entity.AuditLogFilter
+	if f == nil {
+		return filter
+	}
+	if f.ActorID != nil {
+		filter.ActorID = string(*f.ActorID)
+	}
+	if f.Action != nil {
+		filter.Action = *f.Action
+	}
+	if f.TargetType != nil {
+		filter.TargetType = *f.TargetType
+	}
+	if f.TargetID != nil {
+		filter.TargetID = string(*f.TargetID)
+	}
+	if f.From != nil {
+		filter.From = int64(*f.From)
+	}
+	if f.To != nil {
+		filter.To = int64(*f.To)
+	}
+	return filter
+}
+
+type Attachment struct {
+	ID          graphql.ID
+	URL         string
+	ContentType string
+	Size        int32
+	Width       int32
+	Height      int32
+	BlurHash    string
+	Variants    []ImageVariant
+}
+
+type ImageVariant struct {
+	Name        string
+	URL         string
+	ContentType string
+	Width       int32
+	Height      int32
+}
+
+func ResolveAttachment(attachment entity.Attachment) Attachment {
+	variants := make([]ImageVariant, len(attachment.Variants))
+	for i, v := range attachment.Variants {
+		variants[i] = ImageVariant{
+			Name:        v.Name,
+			URL:         v.URL,
+			ContentType: v.ContentType,
+			Width:       int32(v.Width),
+			Height:      int32(v.Height),
+		}
+	}
+	return Attachment{
+		ID:          graphql.ID(attachment.ID),
+		URL:         attachment.URL,
+		ContentType: attachment.ContentType,
+		Size:        int32(attachment.Size),
+		Width:       int32(attachment.Width),
+		Height:      int32(attachment.Height),
+		BlurHash:    attachment.BlurHash,
+		Variants:    variants,
+	}
+}
+
+func ResolveAttachments(attachments []entity.Attachment) []Attachment {
+	result := make([]Attachment, len(attachments))
+	for i, v := range attachments {
+		result[i] = ResolveAttachment(v)
+	}
+	return result
+}
+
+type LinkPreview struct {
+	URL         string
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+}
+
+func ResolveLinkPreviews(previews []entity.LinkPreview) []LinkPreview {
+	result := make([]LinkPreview, len(previews))
+	for i, v := range previews {
+		result[i] = LinkPreview{
+			URL:         v.URL,
+			Title:       v.Title,
+			Description: v.Description,
+			ImageURL:    v.ImageURL,
+			SiteName:    v.SiteName,
+		}
+	}
+	return result
+}
+
+type Poll struct {
+	Options        []PollOption
+	MultipleChoice bool
+	ExpiredAt      *int32
+	IsClosed       bool
+	MyVotes        []graphql.ID
+	Results        *PollResult
+}
+
+type PollOption struct {
+	ID   graphql.ID
+	Text string
+}
+
+type PollResult struct {
+	VotersCount int32
+	Options     []PollOptionResult
+}
+
+type PollOptionResult struct {
+	ID         graphql.ID
+	VotesCount int32
+	Percentage float64
+}
+
+func ResolvePoll(poll *entity.Poll) *Poll {
+	if poll == nil {
+		return nil
+	}
+	now := time.Now().Unix()
+	result := &Poll{
+		MultipleChoice: poll.MultipleChoice,
+		IsClosed:       poll.IsClosed(now),
+		MyVotes:        []graphql.ID{},
+	}
+	if poll.ExpiredAt != 0 {
+		expiredAt := int32(poll.ExpiredAt)
+		result.ExpiredAt = &expiredAt
+	}
+	for _, v := range poll.MyVotes {
+		result.MyVotes = append(result.MyVotes, graphql.ID(v))
+	}
+	for _, v := range poll.Options {
+		result.Options = append(result.Options, PollOption{
+			ID:   graphql.ID(v.ID),
+			Text: v.Text,
+		})
+	}
+	if !poll.IsResultVisible(now) {
+		return result
+	}
+
+	result.Results = &PollResult{VotersCount: int32(poll.VotersCount)}
+	for _, v := range poll.Options {
+		percentage := 0.0
+		if poll.VotersCount > 0 {
+			percentage = float64(v.VotesCount) * 100 / float64(poll.VotersCount)
+		}
+		result.Results.Options = append(result.Results.Options, PollOptionResult{
+			ID:         graphql.ID(v.ID),
+			VotesCount: int32(v.VotesCount),
+			Percentage: percentage,
+		})
+	}
+	return result
+}
+
 type PageInfo struct {
 	EndCursor   graphql.ID
 	HasNextPage bool
+}
+
+type Topic struct {
+	Name       string
+	PostsCount int32
+}
+
+func ResolveTopics(topics []entity.Topic) []Topic {
+	result := make([]Topic, len(topics))
+	for i, v := range topics {
+		result[i] = Topic{
+			Name:       v.Name,
+			PostsCount: int32(v.PostsCount),
+		}
+	}
+	return result
 }
\ No newline at end of file
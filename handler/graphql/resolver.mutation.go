@@ -51,6 +51,7 @@ func (r *Resolver) Register(ctx context.Context, input struct{
 }) AuthResponse {
 	res, err := r.svc.Register(ctx, api.RegisterReq{
 		PushToken: input.PushToken,
+		ClientIP:  ClientIP(ctx),
 	})
 	if err != nil {
 		return AuthResponse{Error: Error(err)}
@@ -87,10 +88,125 @@ func (r *Resolver) UpdateProfile(ctx context.Context, input struct{
 	}
 }
 
+func (r *Resolver) SetHandle(ctx context.Context, input struct{
+	Handle string
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	res, err := r.svc.SetHandle(ctx, api.SetHandleReq{
+		UserID: token.UserID,
+		Handle: input.Handle,
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) UpdateSettings(ctx context.Context, input struct{
+	SensitiveContent string
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	res, err := r.svc.UpdateSettings(ctx, api.UpdateSettingsReq{
+		UserID:           token.UserID,
+		SensitiveContent: input.SensitiveContent,
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) UploadAvatar(ctx context.Context, input struct{
+	File Upload
+}) UploadAvatarResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return UploadAvatarResponse{Error: Error(err)}
+	}
+	res, err := r.svc.UploadAvatar(ctx, api.UploadAvatarReq{
+		UserID: token.UserID,
+		Data:   input.File.Data,
+	})
+	if err != nil {
+		return UploadAvatarResponse{Error: Error(err)}
+	}
+	return UploadAvatarResponse{
+		Payload: res.URL,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) AddAvatar(ctx context.Context, input struct{
+	URL string
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	res, err := r.svc.AddAvatar(ctx, api.AddAvatarReq{
+		UserID: token.UserID,
+		URL:    input.URL,
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) RemoveAvatar(ctx context.Context, input struct{
+	URL string
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	res, err := r.svc.RemoveAvatar(ctx, api.RemoveAvatarReq{
+		UserID: token.UserID,
+		URL:    input.URL,
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
 func (r *Resolver) CreatePost(ctx context.Context, input struct{
 	Body string
 	AuthorID *graphql.ID
 	ParentID *graphql.ID
+	QuotedPostID *graphql.ID
+	Poll *struct{
+		Options []string
+		MultipleChoice *bool
+		ExpiresIn *int32
+	}
+	PublishAt *int32
+	ExpiresIn *int32
+	AttachmentIDs *[]graphql.ID
+	ContentWarnings *[]string
+	ConfirmPersonalData *bool
 }) BasicMutationResponse {
 	token, err := DecodeToken(ctx)
 	if err != nil {
@@ -102,6 +218,7 @@ func (r *Resolver) CreatePost(ctx context.Context, input struct{
 	req := api.CreatePostReq{
 		Body:     input.Body,
 		UserID:   token.UserID,
+		ClientIP: ClientIP(ctx),
 	}
 	if input.AuthorID ==  nil {
 		req.AuthorID = token.UserID
@@ -111,6 +228,35 @@ func (r *Resolver) CreatePost(ctx context.Context, input struct{
 	if input.ParentID != nil {
 		req.ParentID = string(*input.ParentID)
 	}
+	if input.QuotedPostID != nil {
+		req.QuotedPostID = string(*input.QuotedPostID)
+	}
+	if input.Poll != nil {
+		req.Poll = &api.PollReq{Options: input.Poll.Options}
+		if input.Poll.MultipleChoice != nil {
+			req.Poll.MultipleChoice = *input.Poll.MultipleChoice
+		}
+		if input.Poll.ExpiresIn != nil {
+			req.Poll.ExpiresIn = int64(*input.Poll.ExpiresIn)
+		}
+	}
+	if input.PublishAt != nil {
+		req.PublishAt = int64(*input.PublishAt)
+	}
+	if input.ExpiresIn != nil {
+		req.ExpiresIn = int64(*input.ExpiresIn)
+	}
+	if input.AttachmentIDs != nil {
+		for _, v := range *input.AttachmentIDs {
+			req.AttachmentIDs = append(req.AttachmentIDs, string(v))
+		}
+	}
+	if input.ContentWarnings != nil {
+		req.ContentWarnings = *input.ContentWarnings
+	}
+	if input.ConfirmPersonalData != nil {
+		req.ConfirmPersonalData = *input.ConfirmPersonalData
+	}
 	res, err := r.svc.CreatePost(ctx, req)
 	if err != nil {
 		return BasicMutationResponse{Error: Error(err)}
@@ -121,6 +267,48 @@ func (r *Resolver) CreatePost(ctx context.Context, input struct{
 	}
 }
 
+func (r *Resolver) UploadImage(ctx context.Context, input struct{
+	File Upload
+}) UploadImageResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return UploadImageResponse{Error: Error(err)}
+	}
+	res, err := r.svc.UploadImage(ctx, api.UploadImageReq{
+		UserID: token.UserID,
+		Data:   input.File.Data,
+	})
+	if err != nil {
+		return UploadImageResponse{Error: Error(err)}
+	}
+	return UploadImageResponse{
+		Payload: ResolveAttachment(res.Attachment),
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) CancelScheduledPost(ctx context.Context, input struct{
+	ID graphql.ID
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	res, err := r.svc.CancelScheduledPost(ctx, api.CancelScheduledPostReq{
+		PostID: string(input.ID),
+		UserID: token.UserID,
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
 func (r *Resolver) LikePost(ctx context.Context, input struct{
 	ID graphql.ID
 }) BasicMutationResponse {
@@ -131,7 +319,104 @@ func (r *Resolver) LikePost(ctx context.Context, input struct{
 		}
 	}
 	res, err := r.svc.LikePost(ctx, api.LikePostReq{
-		PostID: string(input.ID),
+		PostID:   string(input.ID),
+		UserID:   token.UserID,
+		ClientIP: ClientIP(ctx),
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) Vote(ctx context.Context, input struct{
+	PostID graphql.ID
+	OptionIDs []graphql.ID
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	optionIDs := make([]string, len(input.OptionIDs))
+	for i, v := range input.OptionIDs {
+		optionIDs[i] = string(v)
+	}
+	res, err := r.svc.VotePoll(ctx, api.VotePollReq{
+		PostID:    string(input.PostID),
+		UserID:    token.UserID,
+		OptionIDs: optionIDs,
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) SetContentWarnings(ctx context.Context, input struct{
+	PostID graphql.ID
+	ContentWarnings []string
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	res, err := r.svc.SetContentWarnings(ctx, api.SetContentWarningsReq{
+		PostID:          string(input.PostID),
+		UserID:          token.UserID,
+		ContentWarnings: input.ContentWarnings,
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) Repost(ctx context.Context, input struct{
+	PostID graphql.ID
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	res, err := r.svc.RepostPost(ctx, api.RepostPostReq{
+		PostID: string(input.PostID),
+		UserID: token.UserID,
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) Bookmark(ctx context.Context, input struct{
+	PostID graphql.ID
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	res, err := r.svc.BookmarkPost(ctx, api.BookmarkPostReq{
+		PostID: string(input.PostID),
 		UserID: token.UserID,
 	})
 	if err != nil {
@@ -165,3 +450,278 @@ func (r *Resolver) Follow(ctx context.Context, input struct{
 	}
 }
 
+func (r *Resolver) FollowUser(ctx context.Context, input struct{
+	UserID graphql.ID
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	res, err := r.svc.Follow(ctx, api.FollowReq{
+		UserID:     token.UserID,
+		FollowedID: string(input.UserID),
+		ClientIP:   ClientIP(ctx),
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) UnfollowUser(ctx context.Context, input struct{
+	UserID graphql.ID
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	res, err := r.svc.Unfollow(ctx, api.UnfollowReq{
+		UserID:     token.UserID,
+		FollowedID: string(input.UserID),
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) Block(ctx context.Context, input struct{
+	UserID graphql.ID
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	res, err := r.svc.Block(ctx, api.BlockReq{
+		UserID:    token.UserID,
+		BlockedID: string(input.UserID),
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) Unblock(ctx context.Context, input struct{
+	UserID graphql.ID
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	res, err := r.svc.Unblock(ctx, api.UnblockReq{
+		UserID:    token.UserID,
+		BlockedID: string(input.UserID),
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) Mute(ctx context.Context, input struct{
+	UserID graphql.ID
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	res, err := r.svc.Mute(ctx, api.MuteReq{
+		UserID:  token.UserID,
+		MutedID: string(input.UserID),
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) Unmute(ctx context.Context, input struct{
+	UserID graphql.ID
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	res, err := r.svc.Unmute(ctx, api.UnmuteReq{
+		UserID:  token.UserID,
+		MutedID: string(input.UserID),
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) MuteWord(ctx context.Context, input struct{
+	Word      string
+	ExpiresIn *int32
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	req := api.MuteWordReq{
+		UserID: token.UserID,
+		Word:   input.Word,
+	}
+	if input.ExpiresIn != nil {
+		req.ExpiresIn = int64(*input.ExpiresIn)
+	}
+	res, err := r.svc.MuteWord(ctx, req)
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) UnmuteWord(ctx context.Context, input struct{
+	Word string
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	res, err := r.svc.UnmuteWord(ctx, api.UnmuteWordReq{
+		UserID: token.UserID,
+		Word:   input.Word,
+	})
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+
+func (r *Resolver) ModerateUser(ctx context.Context, input struct{
+	UserID   graphql.ID
+	Action   string
+	Reason   string
+	Duration *int32
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	req := api.ModerateUserReq{
+		UserID:   token.UserID,
+		TargetID: string(input.UserID),
+		Action:   input.Action,
+		Reason:   input.Reason,
+	}
+	if input.Duration != nil {
+		req.Duration = int64(*input.Duration)
+	}
+	res, err := r.svc.ModerateUser(ctx, req)
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) ReportPost(ctx context.Context, input struct{
+	PostID  graphql.ID
+	Reason  string
+	Details *string
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	req := api.ReportPostReq{
+		UserID: token.UserID,
+		PostID: string(input.PostID),
+		Reason: input.Reason,
+	}
+	if input.Details != nil {
+		req.Details = *input.Details
+	}
+	res, err := r.svc.ReportPost(ctx, req)
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) ResolveReport(ctx context.Context, input struct{
+	ID              graphql.ID
+	Action          string
+	SuspendDuration *int32
+	Reason          *string
+}) BasicMutationResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return BasicMutationResponse{
+			Error:   Error(err),
+		}
+	}
+	req := api.ResolveReportReq{
+		UserID:   token.UserID,
+		ReportID: string(input.ID),
+		Action:   input.Action,
+	}
+	if input.SuspendDuration != nil {
+		req.SuspendDuration = int64(*input.SuspendDuration)
+	}
+	if input.Reason != nil {
+		req.Reason = *input.Reason
+	}
+	res, err := r.svc.ResolveReport(ctx, req)
+	if err != nil {
+		return BasicMutationResponse{Error: Error(err)}
+	}
+	return BasicMutationResponse{
+		Message: res.Message,
+		Error:   NoError,
+	}
+}
@@ -71,6 +71,58 @@ func (r *Resolver) Feed(ctx context.Context, input struct {
 	}
 }
 
+func (r *Resolver) Trending(ctx context.Context, input struct {
+	Window *int32
+	First  *int32
+}) FeedResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return FeedResponse{
+			Error: Error(err),
+		}
+	}
+	req := api.TrendingReq{
+		UserID: token.UserID,
+	}
+	if input.Window != nil {
+		req.Window = int(*input.Window)
+	}
+	if input.First != nil {
+		req.First = int(*input.First)
+	}
+	res, err := r.svc.Trending(ctx, req)
+	if err != nil {
+		return FeedResponse{Error: Error(err)}
+	}
+	return FeedResponse{
+		Payload: PostConnection{
+			Edges: ResolvePostEdges(r, res.PostList),
+		},
+		Error: NoError,
+	}
+}
+
+func (r *Resolver) TrendingTopics(ctx context.Context, input struct {
+	Window *int32
+	First  *int32
+}) TrendingTopicsResponse {
+	req := api.TrendingTopicsReq{}
+	if input.Window != nil {
+		req.Window = int(*input.Window)
+	}
+	if input.First != nil {
+		req.First = int(*input.First)
+	}
+	res, err := r.svc.TrendingTopics(ctx, req)
+	if err != nil {
+		return TrendingTopicsResponse{Error: Error(err)}
+	}
+	return TrendingTopicsResponse{
+		Payload: ResolveTopics(res.Topics),
+		Error:   NoError,
+	}
+}
+
 func (r *Resolver) Me(ctx context.Context) MeResponse {
 	token, err := DecodeToken(ctx)
 	if err != nil {
@@ -85,11 +137,14 @@ func (r *Resolver) Me(ctx context.Context) MeResponse {
 		return MeResponse{Error: Error(err)}
 	}
 	return MeResponse{
-		Payload: User{
-			ID:     graphql.ID(res.User.ID),
-			Name:   res.User.Profile.Name,
-			Avatar: res.User.Profile.Avatar,
-			Bio:    res.User.Profile.Bio,
+		Payload: Me{
+			Resolver: r,
+			ID:       graphql.ID(res.User.ID),
+			Name:     res.User.Profile.Name,
+			Handle:   res.User.Profile.Handle,
+			Avatar:   res.User.Profile.Avatar,
+			Bio:      res.User.Profile.Bio,
+			SensitiveContent: sensitiveContent(res.User.Settings),
 		},
 		Error: NoError,
 	}
@@ -113,19 +168,172 @@ func (r *Resolver) Menfess(ctx context.Context) MenfessResponse {
 }
 
 func (r *Resolver) Avatars(ctx context.Context) AvatarsResponse {
+	res, err := r.svc.GetAvatarList(ctx, api.GetAvatarListReq{})
+	if err != nil {
+		return AvatarsResponse{Error: Error(err)}
+	}
+	payload := make([]string, len(res.AvatarList))
+	for i, v := range res.AvatarList {
+		payload[i] = v.URL
+	}
 	return AvatarsResponse{
-		Payload: []string{
-			"https://i.ibb.co/R2xRyg3/upin.jpg",
-			"https://i.ibb.co/4jRdmh5/spiderman.jpg",
-			"https://i.ibb.co/3pDTY1f/saitama.jpg",
-			"https://i.ibb.co/WsK9bLP/ronald.jpg",
-			"https://i.ibb.co/xMXNWBj/mrbean.jpg",
-			"https://i.ibb.co/72TNcHd/monalisa.jpg",
-			"https://i.ibb.co/t2M7zY5/kaonashi.jpg",
-			"https://i.ibb.co/Gv5RSgs/ipin.jpg",
-			"https://i.ibb.co/Vmm19Q4/einstein.jpg",
-			"https://i.ibb.co/84ypfNc/batman.jpg",
+		Payload: payload,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) User(ctx context.Context, input struct {
+	ID graphql.ID
+}) UserProfileResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return UserProfileResponse{Error: Error(err)}
+	}
+	res, err := r.svc.GetUserProfile(ctx, api.GetUserProfileReq{
+		ID:     string(input.ID),
+		UserID: token.UserID,
+	})
+	if err != nil {
+		return UserProfileResponse{Error: Error(err)}
+	}
+	return UserProfileResponse{
+		Payload: ResolveUserProfile(r, *res),
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) UserByHandle(ctx context.Context, input struct {
+	Handle string
+}) UserResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return UserResponse{
+			Error: Error(err),
+		}
+	}
+	res, err := r.svc.GetUserByHandle(ctx, api.GetUserByHandleReq{
+		Handle: input.Handle,
+		UserID: token.UserID,
+	})
+	if err != nil {
+		return UserResponse{Error: Error(err)}
+	}
+	return UserResponse{
+		Payload: ResolveUser(res.User, res.IsFollowed),
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) HandleAvailable(ctx context.Context, input struct {
+	Handle string
+}) HandleAvailableResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return HandleAvailableResponse{
+			Error: Error(err),
+		}
+	}
+	res, err := r.svc.CheckHandle(ctx, api.CheckHandleReq{
+		UserID: token.UserID,
+		Handle: input.Handle,
+	})
+	if err != nil {
+		return HandleAvailableResponse{Error: Error(err)}
+	}
+	return HandleAvailableResponse{
+		Payload: res.Available,
+		Error:   NoError,
+	}
+}
+
+func (r *Resolver) ModerationQueue(ctx context.Context, input struct {
+	First int32
+	After *graphql.ID
+}) ModerationQueueResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return ModerationQueueResponse{Error: Error(err)}
+	}
+	req := api.GetModerationQueueReq{
+		UserID: token.UserID,
+		Pagination: api.PaginationReq{
+			First: int(input.First),
+		},
+	}
+	if input.After != nil {
+		req.Pagination.After = string(*input.After)
+	}
+	res, err := r.svc.GetModerationQueue(ctx, req)
+	if err != nil {
+		return ModerationQueueResponse{Error: Error(err)}
+	}
+	return ModerationQueueResponse{
+		Payload: ReportConnection{
+			Edges: ResolveReportEdges(r, *res),
+			PageInfo: PageInfo{
+				EndCursor:   graphql.ID(res.Pagination.EndCursor),
+				HasNextPage: res.Pagination.HasNextPage,
+			},
 		},
 		Error: NoError,
 	}
 }
+
+func (r *Resolver) AuditLog(ctx context.Context, input struct {
+	First  int32
+	After  *graphql.ID
+	Filter *AuditLogFilterInput
+}) AuditLogResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return AuditLogResponse{Error: Error(err)}
+	}
+	req := api.GetAuditLogReq{
+		UserID: token.UserID,
+		Filter: input.Filter.Entity(),
+		Pagination: api.PaginationReq{
+			First: int(input.First),
+		},
+	}
+	if input.After != nil {
+		req.Pagination.After = string(*input.After)
+	}
+	res, err := r.svc.GetAuditLog(ctx, req)
+	if err != nil {
+		return AuditLogResponse{Error: Error(err)}
+	}
+	return AuditLogResponse{
+		Payload: AuditLogConnection{
+			Edges: ResolveAuditLogEdges(res.AuditLogList),
+			PageInfo: PageInfo{
+				EndCursor:   graphql.ID(res.Pagination.EndCursor),
+				HasNextPage: res.Pagination.HasNextPage,
+			},
+		},
+		Error: NoError,
+	}
+}
+
+func (r *Resolver) ExportAuditLog(ctx context.Context, input struct {
+	Filter *AuditLogFilterInput
+}) ExportResponse {
+	token, err := DecodeToken(ctx)
+	if err != nil {
+		return ExportResponse{Error: Error(err)}
+	}
+	res, err := r.svc.ExportAuditLog(ctx, api.ExportAuditLogReq{
+		UserID: token.UserID,
+		Filter: input.Filter.Entity(),
+	})
+	if err != nil {
+		return ExportResponse{Error: Error(err)}
+	}
+	payload, err := AuditLogJSONLines(res.AuditLogList)
+	if err != nil {
+		return ExportResponse{Error: Error(constants.ErrInternalServerError)}
+	}
+	return ExportResponse{
+		Payload: payload,
+		Error:   NoError,
+	}
+}
@@ -0,0 +1,26 @@
+package graphql
+
+import "fmt"
+
+// Upload is file sent with graphql multipart request, the server put it into the variables
+type Upload struct {
+	FileName string
+	Data     []byte
+}
+
+func (Upload) ImplementsGraphQLType(name string) bool {
+	return name == "Upload"
+}
+
+func (u *Upload) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case Upload:
+		*u = input
+		return nil
+	case *Upload:
+		*u = *input
+		return nil
+	default:
+		return fmt.Errorf("wrong type for Upload: %T", input)
+	}
+}
@@ -0,0 +1,88 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/aeramu/menfess-backend/constants"
+	handler "github.com/aeramu/menfess-backend/handler/graphql"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// multipartMaxSize is the max request size, enough for the max attachments and the operations
+const multipartMaxSize = constants.ImageMaxSize*constants.PostMaxAttachments + 1<<20
+
+// decodeMultipart decode request following graphql multipart request spec,
+// https://github.com/jaydenseric/graphql-multipart-request-spec
+func decodeMultipart(w http.ResponseWriter, r *http.Request) (*request, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, multipartMaxSize)
+	if err := r.ParseMultipartForm(multipartMaxSize); err != nil {
+		return nil, err
+	}
+	var params request
+	if err := json.Unmarshal([]byte(r.FormValue("operations")), &params); err != nil {
+		return nil, err
+	}
+	var fileMap map[string][]string
+	if err := json.Unmarshal([]byte(r.FormValue("map")), &fileMap); err != nil {
+		return nil, err
+	}
+
+	// file path is relative to the operations object, e.g. "variables.file"
+	operations := map[string]interface{}{"variables": params.Variables}
+	for key, paths := range fileMap {
+		files := r.MultipartForm.File[key]
+		if len(files) < 1 {
+			return nil, errors.New("missing file " + key)
+		}
+		f, err := files[0].Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := ioutil.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		upload := handler.Upload{
+			FileName: files[0].Filename,
+			Data:     data,
+		}
+		for _, path := range paths {
+			if err := setPath(operations, strings.Split(path, "."), upload); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &params, nil
+}
+
+// setPath replace the null placeholder on the object path with the value
+func setPath(obj interface{}, path []string, value interface{}) error {
+	if len(path) == 0 {
+		return errors.New("invalid file path")
+	}
+	key := path[0]
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			o[key] = value
+			return nil
+		}
+		return setPath(o[key], path[1:], value)
+	case []interface{}:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(o) {
+			return errors.New("invalid file path")
+		}
+		if len(path) == 1 {
+			o[i] = value
+			return nil
+		}
+		return setPath(o[i], path[1:], value)
+	default:
+		return errors.New("invalid file path")
+	}
+}
@@ -8,11 +8,15 @@ import (
 	"github.com/aeramu/menfess-backend/service"
 	"github.com/graph-gophers/graphql-go"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"os"
+	"strings"
 )
 
-func NewServer(svc service.Service) (*server, error) {
+// NewServer take the client ip from X-Forwarded-For only when trustedProxyHops is set,
+// it's the number of proxy in front of the server that append to the header
+func NewServer(svc service.Service, trustedProxyHops int) (*server, error) {
 	f, err := os.Open("schema.graphql")
 	if err != nil {
 		return nil, err
@@ -29,12 +33,22 @@ func NewServer(svc service.Service) (*server, error) {
 	}
 
 	return &server{
-		Schema: schema,
+		Schema:           schema,
+		svc:              svc,
+		trustedProxyHops: trustedProxyHops,
 	}, nil
 }
 
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
 type server struct {
 	*graphql.Schema
+	svc              service.Service
+	trustedProxyHops int
 }
 
 func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -46,17 +60,22 @@ func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var params struct {
-		Query         string                 `json:"query"`
-		OperationName string                 `json:"operationName"`
-		Variables     map[string]interface{} `json:"variables"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+	var params request
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		p, err := decodeMultipart(w, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		params = *p
+	} else if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	ctx := context.WithValue(r.Context(), constants.AuthorizationKey, r.Header.Get("Authorization"))
+	ctx = context.WithValue(ctx, constants.ClientIPKey, clientIP(r, s.trustedProxyHops))
+	ctx = handler.WithAccountStatus(ctx, s.svc)
 
 	response := s.Schema.Exec(ctx, params.Query, params.OperationName, params.Variables)
 	responseJSON, err := json.Marshal(response)
@@ -67,3 +86,27 @@ func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	w.Write(responseJSON)
 }
+
+// clientIP is the address connected to the server, or the address the first trusted proxy received from.
+// Each proxy append the address it received from to X-Forwarded-For, so only the last trustedProxyHops
+// entries are trusted, the entries before them are whatever the client sent.
+func clientIP(r *http.Request, trustedProxyHops int) string {
+	if trustedProxyHops > 0 {
+		var forwarded []string
+		for _, v := range r.Header.Values("X-Forwarded-For") {
+			for _, ip := range strings.Split(v, ",") {
+				forwarded = append(forwarded, strings.TrimSpace(ip))
+			}
+		}
+		if i := len(forwarded) - trustedProxyHops; i >= 0 {
+			if ip := net.ParseIP(forwarded[i]); ip != nil {
+				return ip.String()
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/service"
+	"github.com/aeramu/menfess-backend/service/api"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+func NewWorker(svc service.Service) *worker {
+	return &worker{svc: svc}
+}
+
+type worker struct {
+	svc service.Service
+}
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+func (w *worker) jobs() []job {
+	return []job{
+		{
+			name:     "RefreshTrending",
+			interval: 10 * time.Minute,
+			run: func(ctx context.Context) error {
+				_, err := w.svc.RefreshTrending(ctx, api.RefreshTrendingReq{Now: time.Now().Unix()})
+				return err
+			},
+		},
+		{
+			name:     "PublishScheduledPost",
+			interval: time.Minute,
+			run: func(ctx context.Context) error {
+				_, err := w.svc.PublishScheduledPost(ctx, api.PublishScheduledPostReq{Now: time.Now().Unix()})
+				return err
+			},
+		},
+		{
+			name:     "DeleteExpiredPost",
+			interval: time.Minute,
+			run: func(ctx context.Context) error {
+				_, err := w.svc.DeleteExpiredPost(ctx, api.DeleteExpiredPostReq{Now: time.Now().Unix()})
+				return err
+			},
+		},
+		{
+			name:     "FetchLinkPreviews",
+			interval: 10 * time.Second,
+			run: func(ctx context.Context) error {
+				_, err := w.svc.FetchLinkPreviews(ctx, api.FetchLinkPreviewsReq{})
+				return err
+			},
+		},
+	}
+}
+
+// Start run every job once, then periodically on its interval until ctx is done
+func (w *worker) Start(ctx context.Context) {
+	for _, v := range w.jobs() {
+		go w.loop(ctx, v)
+	}
+}
+
+func (w *worker) loop(ctx context.Context, j job) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		if err := j.run(ctx); err != nil {
+			logrus.Errorln("[Worker]", j.name, "failed:", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
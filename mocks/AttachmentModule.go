@@ -0,0 +1,82 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/aeramu/menfess-backend/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AttachmentModule is an autogenerated mock type for the AttachmentModule type
+type AttachmentModule struct {
+	mock.Mock
+}
+
+// FindAttachmentByURL provides a mock function with given fields: ctx, url
+func (_m *AttachmentModule) FindAttachmentByURL(ctx context.Context, url string) (*entity.Attachment, error) {
+	ret := _m.Called(ctx, url)
+
+	var r0 *entity.Attachment
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Attachment); ok {
+		r0 = rf(ctx, url)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Attachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindAttachmentListByIDs provides a mock function with given fields: ctx, ids
+func (_m *AttachmentModule) FindAttachmentListByIDs(ctx context.Context, ids []string) ([]entity.Attachment, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []entity.Attachment
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []entity.Attachment); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Attachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertAttachment provides a mock function with given fields: ctx, attachment
+func (_m *AttachmentModule) InsertAttachment(ctx context.Context, attachment entity.Attachment) (string, error) {
+	ret := _m.Called(ctx, attachment)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Attachment) string); ok {
+		r0 = rf(ctx, attachment)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Attachment) error); ok {
+		r1 = rf(ctx, attachment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,64 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	api "github.com/aeramu/menfess-backend/service/api"
+
+	entity "github.com/aeramu/menfess-backend/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditModule is an autogenerated mock type for the AuditModule type
+type AuditModule struct {
+	mock.Mock
+}
+
+// FindAuditLogList provides a mock function with given fields: ctx, filter, pagination
+func (_m *AuditModule) FindAuditLogList(ctx context.Context, filter entity.AuditLogFilter, pagination api.PaginationReq) ([]entity.AuditLog, *api.PaginationRes, error) {
+	ret := _m.Called(ctx, filter, pagination)
+
+	var r0 []entity.AuditLog
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuditLogFilter, api.PaginationReq) []entity.AuditLog); ok {
+		r0 = rf(ctx, filter, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.AuditLog)
+		}
+	}
+
+	var r1 *api.PaginationRes
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AuditLogFilter, api.PaginationReq) *api.PaginationRes); ok {
+		r1 = rf(ctx, filter, pagination)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*api.PaginationRes)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, entity.AuditLogFilter, api.PaginationReq) error); ok {
+		r2 = rf(ctx, filter, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// InsertAuditLog provides a mock function with given fields: ctx, log
+func (_m *AuditModule) InsertAuditLog(ctx context.Context, log entity.AuditLog) error {
+	ret := _m.Called(ctx, log)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuditLog) error); ok {
+		r0 = rf(ctx, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
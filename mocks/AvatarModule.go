@@ -0,0 +1,96 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/aeramu/menfess-backend/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AvatarModule is an autogenerated mock type for the AvatarModule type
+type AvatarModule struct {
+	mock.Mock
+}
+
+// DeleteAvatar provides a mock function with given fields: ctx, url
+func (_m *AvatarModule) DeleteAvatar(ctx context.Context, url string) error {
+	ret := _m.Called(ctx, url)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, url)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAvatarByURL provides a mock function with given fields: ctx, url
+func (_m *AvatarModule) FindAvatarByURL(ctx context.Context, url string) (*entity.Avatar, error) {
+	ret := _m.Called(ctx, url)
+
+	var r0 *entity.Avatar
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Avatar); ok {
+		r0 = rf(ctx, url)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Avatar)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindAvatarList provides a mock function with given fields: ctx
+func (_m *AvatarModule) FindAvatarList(ctx context.Context) ([]entity.Avatar, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entity.Avatar
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.Avatar); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Avatar)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertAvatar provides a mock function with given fields: ctx, avatar
+func (_m *AvatarModule) InsertAvatar(ctx context.Context, avatar entity.Avatar) (string, error) {
+	ret := _m.Called(ctx, avatar)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Avatar) string); ok {
+		r0 = rf(ctx, avatar)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Avatar) error); ok {
+		r1 = rf(ctx, avatar)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
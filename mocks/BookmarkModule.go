@@ -0,0 +1,76 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	api "github.com/aeramu/menfess-backend/service/api"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BookmarkModule is an autogenerated mock type for the BookmarkModule type
+type BookmarkModule struct {
+	mock.Mock
+}
+
+// DeleteBookmark provides a mock function with given fields: ctx, userID, postID
+func (_m *BookmarkModule) DeleteBookmark(ctx context.Context, userID string, postID string) error {
+	ret := _m.Called(ctx, userID, postID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindBookmarkedPostIDs provides a mock function with given fields: ctx, userID, pagination
+func (_m *BookmarkModule) FindBookmarkedPostIDs(ctx context.Context, userID string, pagination api.PaginationReq) ([]string, *api.PaginationRes, error) {
+	ret := _m.Called(ctx, userID, pagination)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.PaginationReq) []string); ok {
+		r0 = rf(ctx, userID, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 *api.PaginationRes
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.PaginationReq) *api.PaginationRes); ok {
+		r1 = rf(ctx, userID, pagination)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*api.PaginationRes)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, api.PaginationReq) error); ok {
+		r2 = rf(ctx, userID, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// InsertBookmark provides a mock function with given fields: ctx, userID, postID
+func (_m *BookmarkModule) InsertBookmark(ctx context.Context, userID string, postID string) error {
+	ret := _m.Called(ctx, userID, postID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
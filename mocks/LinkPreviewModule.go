@@ -0,0 +1,38 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/aeramu/menfess-backend/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LinkPreviewModule is an autogenerated mock type for the LinkPreviewModule type
+type LinkPreviewModule struct {
+	mock.Mock
+}
+
+// FetchLinkPreview provides a mock function with given fields: ctx, url
+func (_m *LinkPreviewModule) FetchLinkPreview(ctx context.Context, url string) (*entity.LinkPreview, error) {
+	ret := _m.Called(ctx, url)
+
+	var r0 *entity.LinkPreview
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.LinkPreview); ok {
+		r0 = rf(ctx, url)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.LinkPreview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
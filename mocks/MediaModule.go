@@ -0,0 +1,38 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/aeramu/menfess-backend/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MediaModule is an autogenerated mock type for the MediaModule type
+type MediaModule struct {
+	mock.Mock
+}
+
+// ProcessImage provides a mock function with given fields: ctx, data, contentType
+func (_m *MediaModule) ProcessImage(ctx context.Context, data []byte, contentType string) (*entity.ProcessedImage, error) {
+	ret := _m.Called(ctx, data, contentType)
+
+	var r0 *entity.ProcessedImage
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, string) *entity.ProcessedImage); ok {
+		r0 = rf(ctx, data, contentType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ProcessedImage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, string) error); ok {
+		r1 = rf(ctx, data, contentType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
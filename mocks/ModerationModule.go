@@ -0,0 +1,38 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/aeramu/menfess-backend/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ModerationModule is an autogenerated mock type for the ModerationModule type
+type ModerationModule struct {
+	mock.Mock
+}
+
+// ReviewPost provides a mock function with given fields: ctx, post
+func (_m *ModerationModule) ReviewPost(ctx context.Context, post entity.Post) (*entity.ModerationResult, error) {
+	ret := _m.Called(ctx, post)
+
+	var r0 *entity.ModerationResult
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Post) *entity.ModerationResult); ok {
+		r0 = rf(ctx, post)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ModerationResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Post) error); ok {
+		r1 = rf(ctx, post)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
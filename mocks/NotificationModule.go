@@ -42,6 +42,29 @@ func (_m *NotificationModule) BroadcastNewPostNotification(ctx context.Context,
 	return r0
 }
 
+// FindPushTokenList provides a mock function with given fields: ctx, userID
+func (_m *NotificationModule) FindPushTokenList(ctx context.Context, userID string) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemovePushToken provides a mock function with given fields: ctx, userID, pushToken
 func (_m *NotificationModule) RemovePushToken(ctx context.Context, userID string, pushToken string) error {
 	ret := _m.Called(ctx, userID, pushToken)
@@ -70,6 +93,20 @@ func (_m *NotificationModule) SendCommentNotification(ctx context.Context, comme
 	return r0
 }
 
+// SendFollowNotification provides a mock function with given fields: ctx, follower, followedID
+func (_m *NotificationModule) SendFollowNotification(ctx context.Context, follower entity.User, followedID string) error {
+	ret := _m.Called(ctx, follower, followedID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User, string) error); ok {
+		r0 = rf(ctx, follower, followedID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendLikeNotification provides a mock function with given fields: ctx, user, post
 func (_m *NotificationModule) SendLikeNotification(ctx context.Context, user entity.User, post entity.Post) error {
 	ret := _m.Called(ctx, user, post)
@@ -83,3 +120,31 @@ func (_m *NotificationModule) SendLikeNotification(ctx context.Context, user ent
 
 	return r0
 }
+
+// SendMentionNotification provides a mock function with given fields: ctx, post, userIDs
+func (_m *NotificationModule) SendMentionNotification(ctx context.Context, post entity.Post, userIDs []string) error {
+	ret := _m.Called(ctx, post, userIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Post, []string) error); ok {
+		r0 = rf(ctx, post, userIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendWarningNotification provides a mock function with given fields: ctx, userID, reason
+func (_m *NotificationModule) SendWarningNotification(ctx context.Context, userID string, reason string) error {
+	ret := _m.Called(ctx, userID, reason)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -17,6 +17,143 @@ type PostModule struct {
 	mock.Mock
 }
 
+// CountPostByAuthorID provides a mock function with given fields: ctx, authorID, userID
+func (_m *PostModule) CountPostByAuthorID(ctx context.Context, authorID string, userID string) (int, error) {
+	ret := _m.Called(ctx, authorID, userID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int); ok {
+		r0 = rf(ctx, authorID, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, authorID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelayLinkPreview provides a mock function with given fields: ctx, id, nextAttemptAt
+func (_m *PostModule) DelayLinkPreview(ctx context.Context, id string, nextAttemptAt int64) error {
+	ret := _m.Called(ctx, id, nextAttemptAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, id, nextAttemptAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteExpiredPost provides a mock function with given fields: ctx, timestamp
+func (_m *PostModule) DeleteExpiredPost(ctx context.Context, timestamp int64) error {
+	ret := _m.Called(ctx, timestamp)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, timestamp)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePost provides a mock function with given fields: ctx, id
+func (_m *PostModule) DeletePost(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePostWithReplies provides a mock function with given fields: ctx, id
+func (_m *PostModule) DeletePostWithReplies(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteScheduledPost provides a mock function with given fields: ctx, id
+func (_m *PostModule) DeleteScheduledPost(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindDueScheduledPostList provides a mock function with given fields: ctx, timestamp
+func (_m *PostModule) FindDueScheduledPostList(ctx context.Context, timestamp int64) ([]entity.Post, error) {
+	ret := _m.Called(ctx, timestamp)
+
+	var r0 []entity.Post
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []entity.Post); ok {
+		r0 = rf(ctx, timestamp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, timestamp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPendingLinkPreviewPostList provides a mock function with given fields: ctx, now, limit
+func (_m *PostModule) FindPendingLinkPreviewPostList(ctx context.Context, now int64, limit int) ([]entity.Post, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	var r0 []entity.Post
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []entity.Post); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindPostByID provides a mock function with given fields: ctx, id, userID
 func (_m *PostModule) FindPostByID(ctx context.Context, id string, userID string) (*entity.Post, error) {
 	ret := _m.Called(ctx, id, userID)
@@ -40,13 +177,59 @@ func (_m *PostModule) FindPostByID(ctx context.Context, id string, userID string
 	return r0, r1
 }
 
-// FindPostListByParentIDAndAuthorIDs provides a mock function with given fields: ctx, parentID, authorIDs, userID, pagination
-func (_m *PostModule) FindPostListByParentIDAndAuthorIDs(ctx context.Context, parentID string, authorIDs []string, userID string, pagination api.PaginationReq) ([]entity.Post, *api.PaginationRes, error) {
-	ret := _m.Called(ctx, parentID, authorIDs, userID, pagination)
+// FindPostListByIDs provides a mock function with given fields: ctx, ids, userID
+func (_m *PostModule) FindPostListByIDs(ctx context.Context, ids []string, userID string) ([]entity.Post, error) {
+	ret := _m.Called(ctx, ids, userID)
+
+	var r0 []entity.Post
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) []entity.Post); ok {
+		r0 = rf(ctx, ids, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string) error); ok {
+		r1 = rf(ctx, ids, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPostListByIDsIncludeHidden provides a mock function with given fields: ctx, ids
+func (_m *PostModule) FindPostListByIDsIncludeHidden(ctx context.Context, ids []string) ([]entity.Post, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []entity.Post
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []entity.Post); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPostListByParentIDAndAuthorIDs provides a mock function with given fields: ctx, parentID, authorIDs, excludedAuthorIDs, userID, pagination
+func (_m *PostModule) FindPostListByParentIDAndAuthorIDs(ctx context.Context, parentID string, authorIDs []string, excludedAuthorIDs []string, userID string, pagination api.PaginationReq) ([]entity.Post, *api.PaginationRes, error) {
+	ret := _m.Called(ctx, parentID, authorIDs, excludedAuthorIDs, userID, pagination)
 
 	var r0 []entity.Post
-	if rf, ok := ret.Get(0).(func(context.Context, string, []string, string, api.PaginationReq) []entity.Post); ok {
-		r0 = rf(ctx, parentID, authorIDs, userID, pagination)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, []string, string, api.PaginationReq) []entity.Post); ok {
+		r0 = rf(ctx, parentID, authorIDs, excludedAuthorIDs, userID, pagination)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Post)
@@ -54,8 +237,8 @@ func (_m *PostModule) FindPostListByParentIDAndAuthorIDs(ctx context.Context, pa
 	}
 
 	var r1 *api.PaginationRes
-	if rf, ok := ret.Get(1).(func(context.Context, string, []string, string, api.PaginationReq) *api.PaginationRes); ok {
-		r1 = rf(ctx, parentID, authorIDs, userID, pagination)
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, []string, string, api.PaginationReq) *api.PaginationRes); ok {
+		r1 = rf(ctx, parentID, authorIDs, excludedAuthorIDs, userID, pagination)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*api.PaginationRes)
@@ -63,8 +246,132 @@ func (_m *PostModule) FindPostListByParentIDAndAuthorIDs(ctx context.Context, pa
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, string, []string, string, api.PaginationReq) error); ok {
-		r2 = rf(ctx, parentID, authorIDs, userID, pagination)
+	if rf, ok := ret.Get(2).(func(context.Context, string, []string, []string, string, api.PaginationReq) error); ok {
+		r2 = rf(ctx, parentID, authorIDs, excludedAuthorIDs, userID, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindPostListCreatedAfter provides a mock function with given fields: ctx, timestamp
+func (_m *PostModule) FindPostListCreatedAfter(ctx context.Context, timestamp int64) ([]entity.Post, error) {
+	ret := _m.Called(ctx, timestamp)
+
+	var r0 []entity.Post
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []entity.Post); ok {
+		r0 = rf(ctx, timestamp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, timestamp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindRecentPostListByUserID provides a mock function with given fields: ctx, userID, timestamp, limit
+func (_m *PostModule) FindRecentPostListByUserID(ctx context.Context, userID string, timestamp int64, limit int) ([]entity.Post, error) {
+	ret := _m.Called(ctx, userID, timestamp, limit)
+
+	var r0 []entity.Post
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int) []entity.Post); ok {
+		r0 = rf(ctx, userID, timestamp, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int) error); ok {
+		r1 = rf(ctx, userID, timestamp, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindRepost provides a mock function with given fields: ctx, postID, userID
+func (_m *PostModule) FindRepost(ctx context.Context, postID string, userID string) (*entity.Post, error) {
+	ret := _m.Called(ctx, postID, userID)
+
+	var r0 *entity.Post
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Post); ok {
+		r0 = rf(ctx, postID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, postID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindScheduledPostByID provides a mock function with given fields: ctx, id
+func (_m *PostModule) FindScheduledPostByID(ctx context.Context, id string) (*entity.Post, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.Post
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Post); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindScheduledPostListByUserID provides a mock function with given fields: ctx, userID, pagination
+func (_m *PostModule) FindScheduledPostListByUserID(ctx context.Context, userID string, pagination api.PaginationReq) ([]entity.Post, *api.PaginationRes, error) {
+	ret := _m.Called(ctx, userID, pagination)
+
+	var r0 []entity.Post
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.PaginationReq) []entity.Post); ok {
+		r0 = rf(ctx, userID, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Post)
+		}
+	}
+
+	var r1 *api.PaginationRes
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.PaginationReq) *api.PaginationRes); ok {
+		r1 = rf(ctx, userID, pagination)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*api.PaginationRes)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, api.PaginationReq) error); ok {
+		r2 = rf(ctx, userID, pagination)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -93,13 +400,48 @@ func (_m *PostModule) InsertPost(ctx context.Context, post entity.Post) (string,
 	return r0, r1
 }
 
-// LikePost provides a mock function with given fields: ctx, postID, userID
-func (_m *PostModule) LikePost(ctx context.Context, postID string, userID string) error {
-	ret := _m.Called(ctx, postID, userID)
+// LikePost provides a mock function with given fields: ctx, postID, userID, shadowbanned
+func (_m *PostModule) LikePost(ctx context.Context, postID string, userID string, shadowbanned bool) error {
+	ret := _m.Called(ctx, postID, userID, shadowbanned)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, postID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) error); ok {
+		r0 = rf(ctx, postID, userID, shadowbanned)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublishPost provides a mock function with given fields: ctx, id
+func (_m *PostModule) PublishPost(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveLinkPreviews provides a mock function with given fields: ctx, id, previews
+func (_m *PostModule) SaveLinkPreviews(ctx context.Context, id string, previews []entity.LinkPreview) error {
+	ret := _m.Called(ctx, id, previews)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []entity.LinkPreview) error); ok {
+		r0 = rf(ctx, id, previews)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -120,3 +462,59 @@ func (_m *PostModule) UnlikePost(ctx context.Context, postID string, userID stri
 
 	return r0
 }
+
+// UpdateContentWarnings provides a mock function with given fields: ctx, id, warnings
+func (_m *PostModule) UpdateContentWarnings(ctx context.Context, id string, warnings []string) error {
+	ret := _m.Called(ctx, id, warnings)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, id, warnings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateHiddenStatus provides a mock function with given fields: ctx, id, hidden
+func (_m *PostModule) UpdateHiddenStatus(ctx context.Context, id string, hidden bool) error {
+	ret := _m.Called(ctx, id, hidden)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, id, hidden)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateShadowbanStatus provides a mock function with given fields: ctx, userID, shadowbanned
+func (_m *PostModule) UpdateShadowbanStatus(ctx context.Context, userID string, shadowbanned bool) error {
+	ret := _m.Called(ctx, userID, shadowbanned)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, userID, shadowbanned)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VotePoll provides a mock function with given fields: ctx, postID, userID, optionIDs
+func (_m *PostModule) VotePoll(ctx context.Context, postID string, userID string, optionIDs []string) error {
+	ret := _m.Called(ctx, postID, userID, optionIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, postID, userID, optionIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,42 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RateLimitModule is an autogenerated mock type for the RateLimitModule type
+type RateLimitModule struct {
+	mock.Mock
+}
+
+// Hit provides a mock function with given fields: ctx, key, window
+func (_m *RateLimitModule) Hit(ctx context.Context, key string, window int64) (int, int64, error) {
+	ret := _m.Called(ctx, key, window)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) int); ok {
+		r0 = rf(ctx, key, window)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) int64); ok {
+		r1 = rf(ctx, key, window)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, int64) error); ok {
+		r2 = rf(ctx, key, window)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
@@ -0,0 +1,38 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/aeramu/menfess-backend/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RedactionModule is an autogenerated mock type for the RedactionModule type
+type RedactionModule struct {
+	mock.Mock
+}
+
+// Redact provides a mock function with given fields: ctx, text
+func (_m *RedactionModule) Redact(ctx context.Context, text string) (*entity.Redaction, error) {
+	ret := _m.Called(ctx, text)
+
+	var r0 *entity.Redaction
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Redaction); ok {
+		r0 = rf(ctx, text)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Redaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, text)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,173 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	api "github.com/aeramu/menfess-backend/service/api"
+
+	entity "github.com/aeramu/menfess-backend/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReportModule is an autogenerated mock type for the ReportModule type
+type ReportModule struct {
+	mock.Mock
+}
+
+// CountPendingReportByPostID provides a mock function with given fields: ctx, postID
+func (_m *ReportModule) CountPendingReportByPostID(ctx context.Context, postID string) (int, error) {
+	ret := _m.Called(ctx, postID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, postID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, postID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountPendingReportByPostIDs provides a mock function with given fields: ctx, postIDs
+func (_m *ReportModule) CountPendingReportByPostIDs(ctx context.Context, postIDs []string) (map[string]int, error) {
+	ret := _m.Called(ctx, postIDs)
+
+	var r0 map[string]int
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]int); ok {
+		r0 = rf(ctx, postIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, postIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPendingReportList provides a mock function with given fields: ctx, pagination
+func (_m *ReportModule) FindPendingReportList(ctx context.Context, pagination api.PaginationReq) ([]entity.Report, *api.PaginationRes, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []entity.Report
+	if rf, ok := ret.Get(0).(func(context.Context, api.PaginationReq) []entity.Report); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Report)
+		}
+	}
+
+	var r1 *api.PaginationRes
+	if rf, ok := ret.Get(1).(func(context.Context, api.PaginationReq) *api.PaginationRes); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*api.PaginationRes)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, api.PaginationReq) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindReportByID provides a mock function with given fields: ctx, id
+func (_m *ReportModule) FindReportByID(ctx context.Context, id string) (*entity.Report, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.Report
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Report); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Report)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertReport provides a mock function with given fields: ctx, report
+func (_m *ReportModule) InsertReport(ctx context.Context, report entity.Report) (string, error) {
+	ret := _m.Called(ctx, report)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Report) string); ok {
+		r0 = rf(ctx, report)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Report) error); ok {
+		r1 = rf(ctx, report)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReopenReportsByPostID provides a mock function with given fields: ctx, postID, action, moderatorID
+func (_m *ReportModule) ReopenReportsByPostID(ctx context.Context, postID string, action string, moderatorID string) error {
+	ret := _m.Called(ctx, postID, action, moderatorID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, postID, action, moderatorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResolveReportsByPostID provides a mock function with given fields: ctx, postID, action, moderatorID
+func (_m *ReportModule) ResolveReportsByPostID(ctx context.Context, postID string, action string, moderatorID string) (bool, error) {
+	ret := _m.Called(ctx, postID, action, moderatorID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
+		r0 = rf(ctx, postID, action, moderatorID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, postID, action, moderatorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -15,21 +15,757 @@ type Service struct {
 	mock.Mock
 }
 
+// AddAvatar provides a mock function with given fields: ctx, req
+func (_m *Service) AddAvatar(ctx context.Context, req api.AddAvatarReq) (*api.AddAvatarRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.AddAvatarRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.AddAvatarReq) *api.AddAvatarRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.AddAvatarRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.AddAvatarReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Block provides a mock function with given fields: ctx, req
+func (_m *Service) Block(ctx context.Context, req api.BlockReq) (*api.BlockRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.BlockRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.BlockReq) *api.BlockRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.BlockRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.BlockReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BookmarkPost provides a mock function with given fields: ctx, req
+func (_m *Service) BookmarkPost(ctx context.Context, req api.BookmarkPostReq) (*api.BookmarkPostRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.BookmarkPostRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.BookmarkPostReq) *api.BookmarkPostRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.BookmarkPostRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.BookmarkPostReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelScheduledPost provides a mock function with given fields: ctx, req
+func (_m *Service) CancelScheduledPost(ctx context.Context, req api.CancelScheduledPostReq) (*api.CancelScheduledPostRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.CancelScheduledPostRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.CancelScheduledPostReq) *api.CancelScheduledPostRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.CancelScheduledPostRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.CancelScheduledPostReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckAccountStatus provides a mock function with given fields: ctx, req
+func (_m *Service) CheckAccountStatus(ctx context.Context, req api.CheckAccountStatusReq) (*api.CheckAccountStatusRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.CheckAccountStatusRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.CheckAccountStatusReq) *api.CheckAccountStatusRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.CheckAccountStatusRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.CheckAccountStatusReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckHandle provides a mock function with given fields: ctx, req
+func (_m *Service) CheckHandle(ctx context.Context, req api.CheckHandleReq) (*api.CheckHandleRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.CheckHandleRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.CheckHandleReq) *api.CheckHandleRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.CheckHandleRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.CheckHandleReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePost provides a mock function with given fields: ctx, req
 func (_m *Service) CreatePost(ctx context.Context, req api.CreatePostReq) (*api.CreatePostRes, error) {
 	ret := _m.Called(ctx, req)
 
-	var r0 *api.CreatePostRes
-	if rf, ok := ret.Get(0).(func(context.Context, api.CreatePostReq) *api.CreatePostRes); ok {
+	var r0 *api.CreatePostRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.CreatePostReq) *api.CreatePostRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.CreatePostRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.CreatePostReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteExpiredPost provides a mock function with given fields: ctx, req
+func (_m *Service) DeleteExpiredPost(ctx context.Context, req api.DeleteExpiredPostReq) (*api.DeleteExpiredPostRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.DeleteExpiredPostRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.DeleteExpiredPostReq) *api.DeleteExpiredPostRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.DeleteExpiredPostRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.DeleteExpiredPostReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportAuditLog provides a mock function with given fields: ctx, req
+func (_m *Service) ExportAuditLog(ctx context.Context, req api.ExportAuditLogReq) (*api.ExportAuditLogRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.ExportAuditLogRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.ExportAuditLogReq) *api.ExportAuditLogRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.ExportAuditLogRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.ExportAuditLogReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Feed provides a mock function with given fields: ctx, req
+func (_m *Service) Feed(ctx context.Context, req api.FeedReq) (*api.FeedRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.FeedRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.FeedReq) *api.FeedRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.FeedRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.FeedReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchLinkPreviews provides a mock function with given fields: ctx, req
+func (_m *Service) FetchLinkPreviews(ctx context.Context, req api.FetchLinkPreviewsReq) (*api.FetchLinkPreviewsRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.FetchLinkPreviewsRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.FetchLinkPreviewsReq) *api.FetchLinkPreviewsRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.FetchLinkPreviewsRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.FetchLinkPreviewsReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Follow provides a mock function with given fields: ctx, req
+func (_m *Service) Follow(ctx context.Context, req api.FollowReq) (*api.FollowRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.FollowRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.FollowReq) *api.FollowRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.FollowRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.FollowReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FollowUser provides a mock function with given fields: ctx, req
+func (_m *Service) FollowUser(ctx context.Context, req api.FollowUserReq) (*api.FollowUserRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.FollowUserRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.FollowUserReq) *api.FollowUserRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.FollowUserRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.FollowUserReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuditLog provides a mock function with given fields: ctx, req
+func (_m *Service) GetAuditLog(ctx context.Context, req api.GetAuditLogReq) (*api.GetAuditLogRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.GetAuditLogRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.GetAuditLogReq) *api.GetAuditLogRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetAuditLogRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.GetAuditLogReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAvatarList provides a mock function with given fields: ctx, req
+func (_m *Service) GetAvatarList(ctx context.Context, req api.GetAvatarListReq) (*api.GetAvatarListRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.GetAvatarListRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.GetAvatarListReq) *api.GetAvatarListRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetAvatarListRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.GetAvatarListReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBookmarkList provides a mock function with given fields: ctx, req
+func (_m *Service) GetBookmarkList(ctx context.Context, req api.GetBookmarkListReq) (*api.GetBookmarkListRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.GetBookmarkListRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.GetBookmarkListReq) *api.GetBookmarkListRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetBookmarkListRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.GetBookmarkListReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFollowerList provides a mock function with given fields: ctx, req
+func (_m *Service) GetFollowerList(ctx context.Context, req api.GetFollowerListReq) (*api.GetFollowerListRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.GetFollowerListRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.GetFollowerListReq) *api.GetFollowerListRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetFollowerListRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.GetFollowerListReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFollowingList provides a mock function with given fields: ctx, req
+func (_m *Service) GetFollowingList(ctx context.Context, req api.GetFollowingListReq) (*api.GetFollowingListRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.GetFollowingListRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.GetFollowingListReq) *api.GetFollowingListRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetFollowingListRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.GetFollowingListReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMenfessList provides a mock function with given fields: ctx, req
+func (_m *Service) GetMenfessList(ctx context.Context, req api.GetMenfessListReq) (*api.GetMenfessListRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.GetMenfessListRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.GetMenfessListReq) *api.GetMenfessListRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetMenfessListRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.GetMenfessListReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetModerationQueue provides a mock function with given fields: ctx, req
+func (_m *Service) GetModerationQueue(ctx context.Context, req api.GetModerationQueueReq) (*api.GetModerationQueueRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.GetModerationQueueRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.GetModerationQueueReq) *api.GetModerationQueueRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetModerationQueueRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.GetModerationQueueReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMutedWordList provides a mock function with given fields: ctx, req
+func (_m *Service) GetMutedWordList(ctx context.Context, req api.GetMutedWordListReq) (*api.GetMutedWordListRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.GetMutedWordListRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.GetMutedWordListReq) *api.GetMutedWordListRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetMutedWordListRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.GetMutedWordListReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPost provides a mock function with given fields: ctx, req
+func (_m *Service) GetPost(ctx context.Context, req api.GetPostReq) (*api.GetPostRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.GetPostRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.GetPostReq) *api.GetPostRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetPostRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.GetPostReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPostList provides a mock function with given fields: ctx, req
+func (_m *Service) GetPostList(ctx context.Context, req api.GetPostListReq) (*api.GetPostListRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.GetPostListRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.GetPostListReq) *api.GetPostListRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetPostListRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.GetPostListReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetScheduledPostList provides a mock function with given fields: ctx, req
+func (_m *Service) GetScheduledPostList(ctx context.Context, req api.GetScheduledPostListReq) (*api.GetScheduledPostListRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.GetScheduledPostListRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.GetScheduledPostListReq) *api.GetScheduledPostListRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetScheduledPostListRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.GetScheduledPostListReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUser provides a mock function with given fields: ctx, req
+func (_m *Service) GetUser(ctx context.Context, req api.GetUserReq) (*api.GetUserRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.GetUserRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.GetUserReq) *api.GetUserRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetUserRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.GetUserReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByHandle provides a mock function with given fields: ctx, req
+func (_m *Service) GetUserByHandle(ctx context.Context, req api.GetUserByHandleReq) (*api.GetUserByHandleRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.GetUserByHandleRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.GetUserByHandleReq) *api.GetUserByHandleRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetUserByHandleRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.GetUserByHandleReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserProfile provides a mock function with given fields: ctx, req
+func (_m *Service) GetUserProfile(ctx context.Context, req api.GetUserProfileReq) (*api.GetUserProfileRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.GetUserProfileRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.GetUserProfileReq) *api.GetUserProfileRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetUserProfileRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.GetUserProfileReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LikePost provides a mock function with given fields: ctx, req
+func (_m *Service) LikePost(ctx context.Context, req api.LikePostReq) (*api.LikePostRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.LikePostRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.LikePostReq) *api.LikePostRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.LikePostRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.LikePostReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, req
+func (_m *Service) Login(ctx context.Context, req api.LoginReq) (*api.LoginRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.LoginRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.LoginReq) *api.LoginRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.LoginRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.LoginReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx, req
+func (_m *Service) Logout(ctx context.Context, req api.LogoutReq) (*api.LogoutRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.LogoutRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.LogoutReq) *api.LogoutRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.LogoutRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.LogoutReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ModerateUser provides a mock function with given fields: ctx, req
+func (_m *Service) ModerateUser(ctx context.Context, req api.ModerateUserReq) (*api.ModerateUserRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.ModerateUserRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.ModerateUserReq) *api.ModerateUserRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.ModerateUserRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.ModerateUserReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mute provides a mock function with given fields: ctx, req
+func (_m *Service) Mute(ctx context.Context, req api.MuteReq) (*api.MuteRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.MuteRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.MuteReq) *api.MuteRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.MuteRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.MuteReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MuteWord provides a mock function with given fields: ctx, req
+func (_m *Service) MuteWord(ctx context.Context, req api.MuteWordReq) (*api.MuteWordRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.MuteWordRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.MuteWordReq) *api.MuteWordRes); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.CreatePostRes)
+			r0 = ret.Get(0).(*api.MuteWordRes)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, api.CreatePostReq) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, api.MuteWordReq) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -38,21 +774,21 @@ func (_m *Service) CreatePost(ctx context.Context, req api.CreatePostReq) (*api.
 	return r0, r1
 }
 
-// GetMenfessList provides a mock function with given fields: ctx, req
-func (_m *Service) GetMenfessList(ctx context.Context, req api.GetMenfessListReq) (*api.GetMenfessListRes, error) {
+// PublishScheduledPost provides a mock function with given fields: ctx, req
+func (_m *Service) PublishScheduledPost(ctx context.Context, req api.PublishScheduledPostReq) (*api.PublishScheduledPostRes, error) {
 	ret := _m.Called(ctx, req)
 
-	var r0 *api.GetMenfessListRes
-	if rf, ok := ret.Get(0).(func(context.Context, api.GetMenfessListReq) *api.GetMenfessListRes); ok {
+	var r0 *api.PublishScheduledPostRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.PublishScheduledPostReq) *api.PublishScheduledPostRes); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.GetMenfessListRes)
+			r0 = ret.Get(0).(*api.PublishScheduledPostRes)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, api.GetMenfessListReq) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, api.PublishScheduledPostReq) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -61,21 +797,21 @@ func (_m *Service) GetMenfessList(ctx context.Context, req api.GetMenfessListReq
 	return r0, r1
 }
 
-// GetPost provides a mock function with given fields: ctx, req
-func (_m *Service) GetPost(ctx context.Context, req api.GetPostReq) (*api.GetPostRes, error) {
+// RefreshTrending provides a mock function with given fields: ctx, req
+func (_m *Service) RefreshTrending(ctx context.Context, req api.RefreshTrendingReq) (*api.RefreshTrendingRes, error) {
 	ret := _m.Called(ctx, req)
 
-	var r0 *api.GetPostRes
-	if rf, ok := ret.Get(0).(func(context.Context, api.GetPostReq) *api.GetPostRes); ok {
+	var r0 *api.RefreshTrendingRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.RefreshTrendingReq) *api.RefreshTrendingRes); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.GetPostRes)
+			r0 = ret.Get(0).(*api.RefreshTrendingRes)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, api.GetPostReq) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, api.RefreshTrendingReq) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -84,21 +820,21 @@ func (_m *Service) GetPost(ctx context.Context, req api.GetPostReq) (*api.GetPos
 	return r0, r1
 }
 
-// GetPostList provides a mock function with given fields: ctx, req
-func (_m *Service) GetPostList(ctx context.Context, req api.GetPostListReq) (*api.GetPostListRes, error) {
+// Register provides a mock function with given fields: ctx, req
+func (_m *Service) Register(ctx context.Context, req api.RegisterReq) (*api.RegisterRes, error) {
 	ret := _m.Called(ctx, req)
 
-	var r0 *api.GetPostListRes
-	if rf, ok := ret.Get(0).(func(context.Context, api.GetPostListReq) *api.GetPostListRes); ok {
+	var r0 *api.RegisterRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.RegisterReq) *api.RegisterRes); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.GetPostListRes)
+			r0 = ret.Get(0).(*api.RegisterRes)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, api.GetPostListReq) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, api.RegisterReq) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -107,21 +843,21 @@ func (_m *Service) GetPostList(ctx context.Context, req api.GetPostListReq) (*ap
 	return r0, r1
 }
 
-// GetUser provides a mock function with given fields: ctx, req
-func (_m *Service) GetUser(ctx context.Context, req api.GetUserReq) (*api.GetUserRes, error) {
+// RemoveAvatar provides a mock function with given fields: ctx, req
+func (_m *Service) RemoveAvatar(ctx context.Context, req api.RemoveAvatarReq) (*api.RemoveAvatarRes, error) {
 	ret := _m.Called(ctx, req)
 
-	var r0 *api.GetUserRes
-	if rf, ok := ret.Get(0).(func(context.Context, api.GetUserReq) *api.GetUserRes); ok {
+	var r0 *api.RemoveAvatarRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.RemoveAvatarReq) *api.RemoveAvatarRes); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.GetUserRes)
+			r0 = ret.Get(0).(*api.RemoveAvatarRes)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, api.GetUserReq) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, api.RemoveAvatarReq) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -130,21 +866,21 @@ func (_m *Service) GetUser(ctx context.Context, req api.GetUserReq) (*api.GetUse
 	return r0, r1
 }
 
-// LikePost provides a mock function with given fields: ctx, req
-func (_m *Service) LikePost(ctx context.Context, req api.LikePostReq) (*api.LikePostRes, error) {
+// ReportPost provides a mock function with given fields: ctx, req
+func (_m *Service) ReportPost(ctx context.Context, req api.ReportPostReq) (*api.ReportPostRes, error) {
 	ret := _m.Called(ctx, req)
 
-	var r0 *api.LikePostRes
-	if rf, ok := ret.Get(0).(func(context.Context, api.LikePostReq) *api.LikePostRes); ok {
+	var r0 *api.ReportPostRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.ReportPostReq) *api.ReportPostRes); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.LikePostRes)
+			r0 = ret.Get(0).(*api.ReportPostRes)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, api.LikePostReq) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, api.ReportPostReq) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -153,21 +889,21 @@ func (_m *Service) LikePost(ctx context.Context, req api.LikePostReq) (*api.Like
 	return r0, r1
 }
 
-// Login provides a mock function with given fields: ctx, req
-func (_m *Service) Login(ctx context.Context, req api.LoginReq) (*api.LoginRes, error) {
+// RepostPost provides a mock function with given fields: ctx, req
+func (_m *Service) RepostPost(ctx context.Context, req api.RepostPostReq) (*api.RepostPostRes, error) {
 	ret := _m.Called(ctx, req)
 
-	var r0 *api.LoginRes
-	if rf, ok := ret.Get(0).(func(context.Context, api.LoginReq) *api.LoginRes); ok {
+	var r0 *api.RepostPostRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.RepostPostReq) *api.RepostPostRes); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.LoginRes)
+			r0 = ret.Get(0).(*api.RepostPostRes)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, api.LoginReq) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, api.RepostPostReq) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -176,21 +912,21 @@ func (_m *Service) Login(ctx context.Context, req api.LoginReq) (*api.LoginRes,
 	return r0, r1
 }
 
-// Logout provides a mock function with given fields: ctx, req
-func (_m *Service) Logout(ctx context.Context, req api.LogoutReq) (*api.LogoutRes, error) {
+// ResolveReport provides a mock function with given fields: ctx, req
+func (_m *Service) ResolveReport(ctx context.Context, req api.ResolveReportReq) (*api.ResolveReportRes, error) {
 	ret := _m.Called(ctx, req)
 
-	var r0 *api.LogoutRes
-	if rf, ok := ret.Get(0).(func(context.Context, api.LogoutReq) *api.LogoutRes); ok {
+	var r0 *api.ResolveReportRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.ResolveReportReq) *api.ResolveReportRes); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.LogoutRes)
+			r0 = ret.Get(0).(*api.ResolveReportRes)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, api.LogoutReq) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, api.ResolveReportReq) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -199,21 +935,182 @@ func (_m *Service) Logout(ctx context.Context, req api.LogoutReq) (*api.LogoutRe
 	return r0, r1
 }
 
-// Register provides a mock function with given fields: ctx, req
-func (_m *Service) Register(ctx context.Context, req api.RegisterReq) (*api.RegisterRes, error) {
+// SetContentWarnings provides a mock function with given fields: ctx, req
+func (_m *Service) SetContentWarnings(ctx context.Context, req api.SetContentWarningsReq) (*api.SetContentWarningsRes, error) {
 	ret := _m.Called(ctx, req)
 
-	var r0 *api.RegisterRes
-	if rf, ok := ret.Get(0).(func(context.Context, api.RegisterReq) *api.RegisterRes); ok {
+	var r0 *api.SetContentWarningsRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.SetContentWarningsReq) *api.SetContentWarningsRes); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.RegisterRes)
+			r0 = ret.Get(0).(*api.SetContentWarningsRes)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, api.RegisterReq) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, api.SetContentWarningsReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetHandle provides a mock function with given fields: ctx, req
+func (_m *Service) SetHandle(ctx context.Context, req api.SetHandleReq) (*api.SetHandleRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.SetHandleRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.SetHandleReq) *api.SetHandleRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.SetHandleRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.SetHandleReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Trending provides a mock function with given fields: ctx, req
+func (_m *Service) Trending(ctx context.Context, req api.TrendingReq) (*api.TrendingRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.TrendingRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.TrendingReq) *api.TrendingRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.TrendingRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.TrendingReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TrendingTopics provides a mock function with given fields: ctx, req
+func (_m *Service) TrendingTopics(ctx context.Context, req api.TrendingTopicsReq) (*api.TrendingTopicsRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.TrendingTopicsRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.TrendingTopicsReq) *api.TrendingTopicsRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.TrendingTopicsRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.TrendingTopicsReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unblock provides a mock function with given fields: ctx, req
+func (_m *Service) Unblock(ctx context.Context, req api.UnblockReq) (*api.UnblockRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.UnblockRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.UnblockReq) *api.UnblockRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.UnblockRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.UnblockReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unfollow provides a mock function with given fields: ctx, req
+func (_m *Service) Unfollow(ctx context.Context, req api.UnfollowReq) (*api.UnfollowRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.UnfollowRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.UnfollowReq) *api.UnfollowRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.UnfollowRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.UnfollowReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unmute provides a mock function with given fields: ctx, req
+func (_m *Service) Unmute(ctx context.Context, req api.UnmuteReq) (*api.UnmuteRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.UnmuteRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.UnmuteReq) *api.UnmuteRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.UnmuteRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.UnmuteReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnmuteWord provides a mock function with given fields: ctx, req
+func (_m *Service) UnmuteWord(ctx context.Context, req api.UnmuteWordReq) (*api.UnmuteWordRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.UnmuteWordRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.UnmuteWordReq) *api.UnmuteWordRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.UnmuteWordRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.UnmuteWordReq) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -244,3 +1141,95 @@ func (_m *Service) UpdateProfile(ctx context.Context, req api.UpdateProfileReq)
 
 	return r0, r1
 }
+
+// UpdateSettings provides a mock function with given fields: ctx, req
+func (_m *Service) UpdateSettings(ctx context.Context, req api.UpdateSettingsReq) (*api.UpdateSettingsRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.UpdateSettingsRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.UpdateSettingsReq) *api.UpdateSettingsRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.UpdateSettingsRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.UpdateSettingsReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadAvatar provides a mock function with given fields: ctx, req
+func (_m *Service) UploadAvatar(ctx context.Context, req api.UploadAvatarReq) (*api.UploadAvatarRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.UploadAvatarRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.UploadAvatarReq) *api.UploadAvatarRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.UploadAvatarRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.UploadAvatarReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadImage provides a mock function with given fields: ctx, req
+func (_m *Service) UploadImage(ctx context.Context, req api.UploadImageReq) (*api.UploadImageRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.UploadImageRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.UploadImageReq) *api.UploadImageRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.UploadImageRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.UploadImageReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VotePoll provides a mock function with given fields: ctx, req
+func (_m *Service) VotePoll(ctx context.Context, req api.VotePollReq) (*api.VotePollRes, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *api.VotePollRes
+	if rf, ok := ret.Get(0).(func(context.Context, api.VotePollReq) *api.VotePollRes); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.VotePollRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, api.VotePollReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
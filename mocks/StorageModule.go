@@ -0,0 +1,35 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StorageModule is an autogenerated mock type for the StorageModule type
+type StorageModule struct {
+	mock.Mock
+}
+
+// Put provides a mock function with given fields: ctx, key, data, contentType
+func (_m *StorageModule) Put(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	ret := _m.Called(ctx, key, data, contentType)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, string) string); ok {
+		r0 = rf(ctx, key, data, contentType)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte, string) error); ok {
+		r1 = rf(ctx, key, data, contentType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,140 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/aeramu/menfess-backend/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TrendingModule is an autogenerated mock type for the TrendingModule type
+type TrendingModule struct {
+	mock.Mock
+}
+
+// DeleteEngagementSnapshotBefore provides a mock function with given fields: ctx, timestamp
+func (_m *TrendingModule) DeleteEngagementSnapshotBefore(ctx context.Context, timestamp int64) error {
+	ret := _m.Called(ctx, timestamp)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, timestamp)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindEngagementSnapshot provides a mock function with given fields: ctx, timestamp
+func (_m *TrendingModule) FindEngagementSnapshot(ctx context.Context, timestamp int64) (*entity.EngagementSnapshot, error) {
+	ret := _m.Called(ctx, timestamp)
+
+	var r0 *entity.EngagementSnapshot
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.EngagementSnapshot); ok {
+		r0 = rf(ctx, timestamp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.EngagementSnapshot)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, timestamp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTrendingPostList provides a mock function with given fields: ctx, window, limit
+func (_m *TrendingModule) FindTrendingPostList(ctx context.Context, window int, limit int) ([]entity.TrendingPost, error) {
+	ret := _m.Called(ctx, window, limit)
+
+	var r0 []entity.TrendingPost
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.TrendingPost); ok {
+		r0 = rf(ctx, window, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.TrendingPost)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, window, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTrendingTopicList provides a mock function with given fields: ctx, window, limit
+func (_m *TrendingModule) FindTrendingTopicList(ctx context.Context, window int, limit int) ([]entity.Topic, error) {
+	ret := _m.Called(ctx, window, limit)
+
+	var r0 []entity.Topic
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.Topic); ok {
+		r0 = rf(ctx, window, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Topic)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, window, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveEngagementSnapshot provides a mock function with given fields: ctx, snapshot
+func (_m *TrendingModule) SaveEngagementSnapshot(ctx context.Context, snapshot entity.EngagementSnapshot) error {
+	ret := _m.Called(ctx, snapshot)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.EngagementSnapshot) error); ok {
+		r0 = rf(ctx, snapshot)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveTrendingPostList provides a mock function with given fields: ctx, window, posts
+func (_m *TrendingModule) SaveTrendingPostList(ctx context.Context, window int, posts []entity.TrendingPost) error {
+	ret := _m.Called(ctx, window, posts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []entity.TrendingPost) error); ok {
+		r0 = rf(ctx, window, posts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveTrendingTopicList provides a mock function with given fields: ctx, window, topics
+func (_m *TrendingModule) SaveTrendingTopicList(ctx context.Context, window int, topics []entity.Topic) error {
+	ret := _m.Called(ctx, window, topics)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []entity.Topic) error); ok {
+		r0 = rf(ctx, window, topics)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
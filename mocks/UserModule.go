@@ -5,7 +5,10 @@ package mocks
 import (
 	context "context"
 
+	api "github.com/aeramu/menfess-backend/service/api"
+
 	entity "github.com/aeramu/menfess-backend/entity"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,6 +17,232 @@ type UserModule struct {
 	mock.Mock
 }
 
+// BanDevices provides a mock function with given fields: ctx, userID, pushTokens, reason
+func (_m *UserModule) BanDevices(ctx context.Context, userID string, pushTokens []string, reason string) error {
+	ret := _m.Called(ctx, userID, pushTokens, reason)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, string) error); ok {
+		r0 = rf(ctx, userID, pushTokens, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountFollowers provides a mock function with given fields: ctx, userID
+func (_m *UserModule) CountFollowers(ctx context.Context, userID string) (int, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountFollowing provides a mock function with given fields: ctx, userID
+func (_m *UserModule) CountFollowing(ctx context.Context, userID string) (int, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteMutedWord provides a mock function with given fields: ctx, userID, word
+func (_m *UserModule) DeleteMutedWord(ctx context.Context, userID string, word string) error {
+	ret := _m.Called(ctx, userID, word)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, word)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindBannedDevice provides a mock function with given fields: ctx, pushToken
+func (_m *UserModule) FindBannedDevice(ctx context.Context, pushToken string) (*entity.BannedDevice, error) {
+	ret := _m.Called(ctx, pushToken)
+
+	var r0 *entity.BannedDevice
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.BannedDevice); ok {
+		r0 = rf(ctx, pushToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BannedDevice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pushToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindBlockerIDsIn provides a mock function with given fields: ctx, blockedID, ids
+func (_m *UserModule) FindBlockerIDsIn(ctx context.Context, blockedID string, ids []string) ([]string, error) {
+	ret := _m.Called(ctx, blockedID, ids)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
+		r0 = rf(ctx, blockedID, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, blockedID, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindFollowedUserIDsIn provides a mock function with given fields: ctx, userID, ids
+func (_m *UserModule) FindFollowedUserIDsIn(ctx context.Context, userID string, ids []string) ([]string, error) {
+	ret := _m.Called(ctx, userID, ids)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
+		r0 = rf(ctx, userID, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, userID, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindFollowerIDs provides a mock function with given fields: ctx, userID, pagination
+func (_m *UserModule) FindFollowerIDs(ctx context.Context, userID string, pagination api.PaginationReq) ([]string, *api.PaginationRes, error) {
+	ret := _m.Called(ctx, userID, pagination)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.PaginationReq) []string); ok {
+		r0 = rf(ctx, userID, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 *api.PaginationRes
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.PaginationReq) *api.PaginationRes); ok {
+		r1 = rf(ctx, userID, pagination)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*api.PaginationRes)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, api.PaginationReq) error); ok {
+		r2 = rf(ctx, userID, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindFollowingIDs provides a mock function with given fields: ctx, userID, pagination
+func (_m *UserModule) FindFollowingIDs(ctx context.Context, userID string, pagination api.PaginationReq) ([]string, *api.PaginationRes, error) {
+	ret := _m.Called(ctx, userID, pagination)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.PaginationReq) []string); ok {
+		r0 = rf(ctx, userID, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 *api.PaginationRes
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.PaginationReq) *api.PaginationRes); ok {
+		r1 = rf(ctx, userID, pagination)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*api.PaginationRes)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, api.PaginationReq) error); ok {
+		r2 = rf(ctx, userID, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindHiddenUserIDs provides a mock function with given fields: ctx, userID
+func (_m *UserModule) FindHiddenUserIDs(ctx context.Context, userID string) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindMenfessList provides a mock function with given fields: ctx
 func (_m *UserModule) FindMenfessList(ctx context.Context) ([]entity.User, error) {
 	ret := _m.Called(ctx)
@@ -37,6 +266,29 @@ func (_m *UserModule) FindMenfessList(ctx context.Context) ([]entity.User, error
 	return r0, r1
 }
 
+// FindMutedWordList provides a mock function with given fields: ctx, userID
+func (_m *UserModule) FindMutedWordList(ctx context.Context, userID string) ([]entity.MutedWord, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.MutedWord
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.MutedWord); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.MutedWord)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindUserByEmail provides a mock function with given fields: ctx, email
 func (_m *UserModule) FindUserByEmail(ctx context.Context, email string) (*entity.User, error) {
 	ret := _m.Called(ctx, email)
@@ -60,6 +312,29 @@ func (_m *UserModule) FindUserByEmail(ctx context.Context, email string) (*entit
 	return r0, r1
 }
 
+// FindUserByHandle provides a mock function with given fields: ctx, handle
+func (_m *UserModule) FindUserByHandle(ctx context.Context, handle string) (*entity.User, error) {
+	ret := _m.Called(ctx, handle)
+
+	var r0 *entity.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, handle)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, handle)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindUserByID provides a mock function with given fields: ctx, id
 func (_m *UserModule) FindUserByID(ctx context.Context, id string) (*entity.User, error) {
 	ret := _m.Called(ctx, id)
@@ -83,6 +358,52 @@ func (_m *UserModule) FindUserByID(ctx context.Context, id string) (*entity.User
 	return r0, r1
 }
 
+// FindUserListByHandles provides a mock function with given fields: ctx, handles
+func (_m *UserModule) FindUserListByHandles(ctx context.Context, handles []string) ([]entity.User, error) {
+	ret := _m.Called(ctx, handles)
+
+	var r0 []entity.User
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []entity.User); ok {
+		r0 = rf(ctx, handles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, handles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUserListByIDs provides a mock function with given fields: ctx, ids
+func (_m *UserModule) FindUserListByIDs(ctx context.Context, ids []string) ([]entity.User, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []entity.User
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []entity.User); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFollowedUserID provides a mock function with given fields: ctx, userID
 func (_m *UserModule) GetFollowedUserID(ctx context.Context, userID string) ([]string, error) {
 	ret := _m.Called(ctx, userID)
@@ -127,6 +448,90 @@ func (_m *UserModule) InsertUser(ctx context.Context, user entity.User) (string,
 	return r0, r1
 }
 
+// IsBlocked provides a mock function with given fields: ctx, userID, blockedID
+func (_m *UserModule) IsBlocked(ctx context.Context, userID string, blockedID string) (bool, error) {
+	ret := _m.Called(ctx, userID, blockedID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userID, blockedID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, blockedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsFollowing provides a mock function with given fields: ctx, follower, followed
+func (_m *UserModule) IsFollowing(ctx context.Context, follower string, followed string) (bool, error) {
+	ret := _m.Called(ctx, follower, followed)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, follower, followed)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, follower, followed)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveBan provides a mock function with given fields: ctx, user
+func (_m *UserModule) SaveBan(ctx context.Context, user entity.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveHandle provides a mock function with given fields: ctx, user
+func (_m *UserModule) SaveHandle(ctx context.Context, user entity.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveMutedWord provides a mock function with given fields: ctx, userID, word
+func (_m *UserModule) SaveMutedWord(ctx context.Context, userID string, word entity.MutedWord) error {
+	ret := _m.Called(ctx, userID, word)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.MutedWord) error); ok {
+		r0 = rf(ctx, userID, word)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveProfile provides a mock function with given fields: ctx, user
 func (_m *UserModule) SaveProfile(ctx context.Context, user entity.User) error {
 	ret := _m.Called(ctx, user)
@@ -141,13 +546,104 @@ func (_m *UserModule) SaveProfile(ctx context.Context, user entity.User) error {
 	return r0
 }
 
+// SaveSettings provides a mock function with given fields: ctx, user
+func (_m *UserModule) SaveSettings(ctx context.Context, user entity.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveShadowban provides a mock function with given fields: ctx, user
+func (_m *UserModule) SaveShadowban(ctx context.Context, user entity.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveSuspension provides a mock function with given fields: ctx, user
+func (_m *UserModule) SaveSuspension(ctx context.Context, user entity.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnbanDevices provides a mock function with given fields: ctx, userID
+func (_m *UserModule) UnbanDevices(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateBlockStatus provides a mock function with given fields: ctx, userID, blockedID, status
+func (_m *UserModule) UpdateBlockStatus(ctx context.Context, userID string, blockedID string, status string) error {
+	ret := _m.Called(ctx, userID, blockedID, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, userID, blockedID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateFollowStatus provides a mock function with given fields: ctx, follower, followed, status
-func (_m *UserModule) UpdateFollowStatus(ctx context.Context, follower string, followed string, status string) error {
+func (_m *UserModule) UpdateFollowStatus(ctx context.Context, follower string, followed string, status string) (bool, error) {
 	ret := _m.Called(ctx, follower, followed, status)
 
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
+		r0 = rf(ctx, follower, followed, status)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, follower, followed, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateMuteStatus provides a mock function with given fields: ctx, userID, mutedID, status
+func (_m *UserModule) UpdateMuteStatus(ctx context.Context, userID string, mutedID string, status string) error {
+	ret := _m.Called(ctx, userID, mutedID, status)
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = rf(ctx, follower, followed, status)
+		r0 = rf(ctx, userID, mutedID, status)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,143 @@
+package attachment
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/aeramu/menfess-backend/service"
+	"github.com/aeramu/mongolib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func NewAttachmentModule(db *mongolib.Database) service.AttachmentModule {
+	return &attachmentModule{attachment: db.Coll("attachment")}
+}
+
+type attachmentModule struct {
+	attachment *mongolib.Collection
+}
+
+func (m *attachmentModule) InsertAttachment(ctx context.Context, attachment entity.Attachment) (string, error) {
+	id := mongolib.NewObjectID()
+	model := Attachment{
+		ID:          id,
+		URL:         attachment.URL,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Width:       attachment.Width,
+		Height:      attachment.Height,
+		BlurHash:    attachment.BlurHash,
+		Variants:    NewImageVariants(attachment.Variants),
+		OwnerID:     mongolib.ObjectID(attachment.OwnerID),
+	}
+	if err := m.attachment.Save(ctx, id, model); err != nil {
+		return "", err
+	}
+	return id.Hex(), nil
+}
+
+func (m *attachmentModule) FindAttachmentListByIDs(ctx context.Context, ids []string) ([]entity.Attachment, error) {
+	objectIDs := make([]primitive.ObjectID, len(ids))
+	for i, v := range ids {
+		objectIDs[i] = mongolib.ObjectID(v)
+	}
+	var model []Attachment
+	if err := m.attachment.Query().
+		In("_id", objectIDs).
+		Find(ctx).Consume(&model); err != nil {
+		return nil, err
+	}
+
+	// keep the order of the requested ids, mongo doesn't guarantee it for $in
+	mapAttachment := make(map[string]entity.Attachment)
+	for _, v := range model {
+		mapAttachment[v.ID.Hex()] = v.Entity()
+	}
+	var attachments []entity.Attachment
+	for _, v := range ids {
+		if attachment, ok := mapAttachment[v]; ok {
+			attachments = append(attachments, attachment)
+		}
+	}
+	return attachments, nil
+}
+
+// FindAttachmentByURL find attachment by the url of its full size image or any of its variant
+func (m *attachmentModule) FindAttachmentByURL(ctx context.Context, url string) (*entity.Attachment, error) {
+	var model Attachment
+	if err := m.attachment.FindOne(ctx, bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "url", Value: url}},
+		bson.D{{Key: "variants.url", Value: url}},
+	}}}).Decode(&model); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, constants.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	attachment := model.Entity()
+	return &attachment, nil
+}
+
+type Attachment struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	URL         string             `bson:"url"`
+	ContentType string             `bson:"content_type"`
+	Size        int                `bson:"size"`
+	Width       int                `bson:"width"`
+	Height      int                `bson:"height"`
+	BlurHash    string             `bson:"blur_hash"`
+	Variants    []ImageVariant     `bson:"variants"`
+	OwnerID     primitive.ObjectID `bson:"owner_id"`
+}
+
+type ImageVariant struct {
+	Name        string `bson:"name"`
+	URL         string `bson:"url"`
+	ContentType string `bson:"content_type"`
+	Width       int    `bson:"width"`
+	Height      int    `bson:"height"`
+}
+
+func NewImageVariants(variants []entity.ImageVariant) []ImageVariant {
+	result := make([]ImageVariant, len(variants))
+	for i, v := range variants {
+		result[i] = ImageVariant{
+			Name:        v.Name,
+			URL:         v.URL,
+			ContentType: v.ContentType,
+			Width:       v.Width,
+			Height:      v.Height,
+		}
+	}
+	return result
+}
+
+func (v ImageVariant) Entity() entity.ImageVariant {
+	return entity.ImageVariant{
+		Name:        v.Name,
+		URL:         v.URL,
+		ContentType: v.ContentType,
+		Width:       v.Width,
+		Height:      v.Height,
+	}
+}
+
+func (a Attachment) Entity() entity.Attachment {
+	variants := make([]entity.ImageVariant, len(a.Variants))
+	for i, v := range a.Variants {
+		variants[i] = v.Entity()
+	}
+	return entity.Attachment{
+		ID:          a.ID.Hex(),
+		URL:         a.URL,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Width:       a.Width,
+		Height:      a.Height,
+		BlurHash:    a.BlurHash,
+		Variants:    variants,
+		OwnerID:     a.OwnerID.Hex(),
+	}
+}
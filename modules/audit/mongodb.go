@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/aeramu/menfess-backend/service"
+	"github.com/aeramu/menfess-backend/service/api"
+	"github.com/aeramu/mongolib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+)
+
+func NewAuditModule(db *mongolib.Database) service.AuditModule {
+	return &auditModule{auditLog: db.Coll("audit_log")}
+}
+
+// EnsureIndex create the index to list the log of an actor or a target newest first
+func EnsureIndex(ctx context.Context, db *mongolib.Database) error {
+	if _, err := db.Coll("audit_log").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "actor_id", Value: 1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "target_id", Value: 1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// auditModule only insert, the audit log is never updated or deleted
+type auditModule struct {
+	auditLog *mongolib.Collection
+}
+
+func (m *auditModule) InsertAuditLog(ctx context.Context, log entity.AuditLog) error {
+	id := mongolib.NewObjectID()
+	model := AuditLog{
+		ID:         id,
+		ActorID:    mongolib.ObjectID(log.ActorID),
+		Action:     log.Action,
+		TargetType: log.TargetType,
+		TargetID:   mongolib.ObjectID(log.TargetID),
+		Before:     log.Before,
+		After:      log.After,
+		Reason:     log.Reason,
+		CreatedAt:  time.Now().Unix(),
+	}
+	if _, err := m.auditLog.InsertOne(ctx, model); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *auditModule) FindAuditLogList(ctx context.Context, filter entity.AuditLogFilter, pagination api.PaginationReq) ([]entity.AuditLog, *api.PaginationRes, error) {
+	query := m.auditLog.Query()
+	if filter.ActorID != "" {
+		query = query.Equal("actor_id", mongolib.ObjectID(filter.ActorID))
+	}
+	if filter.Action != "" {
+		query = query.Equal("action", filter.Action)
+	}
+	if filter.TargetType != "" {
+		query = query.Equal("target_type", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		query = query.Equal("target_id", mongolib.ObjectID(filter.TargetID))
+	}
+	if filter.From != 0 {
+		query = query.GreaterThanEqual("created_at", filter.From)
+	}
+	if filter.To != 0 {
+		query = query.LessThanEqual("created_at", filter.To)
+	}
+	if pagination.After != "" {
+		query = query.LessThan("_id", mongolib.ObjectID(pagination.After))
+	}
+
+	var model []AuditLog
+	if err := query.
+		Sort("_id", mongolib.Descending).
+		Limit(pagination.First).
+		Find(ctx).Consume(&model); err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]entity.AuditLog, len(model))
+	for i, v := range model {
+		result[i] = v.Entity()
+	}
+	endCursor := ""
+	if len(model) > 0 {
+		endCursor = model[len(model)-1].ID.Hex()
+	}
+	return result, &api.PaginationRes{
+		EndCursor:   endCursor,
+		HasNextPage: len(model) >= pagination.First,
+	}, nil
+}
+
+type AuditLog struct {
+	ID         primitive.ObjectID     `bson:"_id"`
+	ActorID    primitive.ObjectID     `bson:"actor_id"`
+	Action     string                 `bson:"action"`
+	TargetType string                 `bson:"target_type"`
+	TargetID   primitive.ObjectID     `bson:"target_id"`
+	Before     map[string]interface{} `bson:"before"`
+	After      map[string]interface{} `bson:"after"`
+	Reason     string                 `bson:"reason"`
+	CreatedAt  int64                  `bson:"created_at"`
+}
+
+func (l AuditLog) Entity() entity.AuditLog {
+	return entity.AuditLog{
+		ID:         l.ID.Hex(),
+		ActorID:    l.ActorID.Hex(),
+		Action:     l.Action,
+		TargetType: l.TargetType,
+		TargetID:   l.TargetID.Hex(),
+		Before:     l.Before,
+		After:      l.After,
+		Reason:     l.Reason,
+		Timestamp:  l.CreatedAt,
+	}
+}
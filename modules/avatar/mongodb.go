@@ -0,0 +1,95 @@
+package avatar
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/aeramu/menfess-backend/service"
+	"github.com/aeramu/mongolib"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func NewAvatarModule(db *mongolib.Database) service.AvatarModule {
+	return &avatarModule{avatar: db.Coll("avatar")}
+}
+
+type avatarModule struct {
+	avatar *mongolib.Collection
+}
+
+// Seed insert the default avatar list if the catalog is still empty
+func Seed(ctx context.Context, db *mongolib.Database, urls []string) error {
+	m := &avatarModule{avatar: db.Coll("avatar")}
+	count, err := m.avatar.Query().Count(ctx)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	for _, v := range urls {
+		if _, err := m.InsertAvatar(ctx, entity.Avatar{URL: v}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *avatarModule) FindAvatarList(ctx context.Context) ([]entity.Avatar, error) {
+	var model []Avatar
+	if err := m.avatar.Query().
+		Sort("_id", mongolib.Ascending).
+		Find(ctx).Consume(&model); err != nil {
+		return nil, err
+	}
+	avatars := make([]entity.Avatar, len(model))
+	for i, v := range model {
+		avatars[i] = v.Entity()
+	}
+	return avatars, nil
+}
+
+func (m *avatarModule) FindAvatarByURL(ctx context.Context, url string) (*entity.Avatar, error) {
+	var model Avatar
+	if err := m.avatar.Query().
+		Equal("url", url).
+		FindOne(ctx).Consume(&model); err != nil {
+		if err == mongolib.ErrNotFound {
+			return nil, constants.ErrAvatarNotFound
+		}
+		return nil, err
+	}
+	avatar := model.Entity()
+	return &avatar, nil
+}
+
+func (m *avatarModule) InsertAvatar(ctx context.Context, avatar entity.Avatar) (string, error) {
+	id := mongolib.NewObjectID()
+	model := Avatar{
+		ID:  id,
+		URL: avatar.URL,
+	}
+	if err := m.avatar.Save(ctx, id, model); err != nil {
+		return "", err
+	}
+	return id.Hex(), nil
+}
+
+func (m *avatarModule) DeleteAvatar(ctx context.Context, url string) error {
+	if err := m.avatar.Query().Equal("url", url).Delete(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+type Avatar struct {
+	ID  primitive.ObjectID `bson:"_id"`
+	URL string             `bson:"url"`
+}
+
+func (a Avatar) Entity() entity.Avatar {
+	return entity.Avatar{
+		ID:  a.ID.Hex(),
+		URL: a.URL,
+	}
+}
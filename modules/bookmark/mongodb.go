@@ -0,0 +1,84 @@
+package bookmark
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/service"
+	"github.com/aeramu/menfess-backend/service/api"
+	"github.com/aeramu/mongolib"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func NewBookmarkModule(db *mongolib.Database) service.BookmarkModule {
+	return &bookmarkModule{bookmark: db.Coll("bookmark")}
+}
+
+type bookmarkModule struct {
+	bookmark *mongolib.Collection
+}
+
+func (m *bookmarkModule) InsertBookmark(ctx context.Context, userID string, postID string) error {
+	var model Bookmark
+	err := m.bookmark.Query().
+		Equal("user_id", mongolib.ObjectID(userID)).
+		Equal("post_id", mongolib.ObjectID(postID)).
+		FindOne(ctx).Consume(&model)
+	if err == nil {
+		return nil
+	}
+	if err != mongolib.ErrNotFound {
+		return err
+	}
+
+	model.ID = mongolib.NewObjectID()
+	model.UserID = mongolib.ObjectID(userID)
+	model.PostID = mongolib.ObjectID(postID)
+	if err := m.bookmark.Save(ctx, model.ID, model); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *bookmarkModule) DeleteBookmark(ctx context.Context, userID string, postID string) error {
+	if err := m.bookmark.Query().
+		Equal("user_id", mongolib.ObjectID(userID)).
+		Equal("post_id", mongolib.ObjectID(postID)).
+		Delete(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *bookmarkModule) FindBookmarkedPostIDs(ctx context.Context, userID string, pagination api.PaginationReq) ([]string, *api.PaginationRes, error) {
+	var model []Bookmark
+	if pagination.After == "" {
+		pagination.After = "ffffffffffffffffffffffff"
+	}
+	if err := m.bookmark.Query().
+		Equal("user_id", mongolib.ObjectID(userID)).
+		LessThan("_id", mongolib.ObjectID(pagination.After)).
+		Sort("_id", mongolib.Descending).
+		Limit(pagination.First).
+		Find(ctx).Consume(&model); err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]string, len(model))
+	for i, v := range model {
+		ids[i] = v.PostID.Hex()
+	}
+	endCursor := ""
+	if len(model) > 0 {
+		endCursor = model[len(model)-1].ID.Hex()
+	}
+	return ids, &api.PaginationRes{
+		EndCursor:   endCursor,
+		HasNextPage: len(model) >= pagination.First,
+	}, nil
+}
+
+type Bookmark struct {
+	ID     primitive.ObjectID `bson:"_id"`
+	UserID primitive.ObjectID `bson:"user_id"`
+	PostID primitive.ObjectID `bson:"post_id"`
+}
@@ -0,0 +1,27 @@
+package linkpreview
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/aeramu/menfess-backend/service"
+)
+
+// NewFakeLinkPreviewModule serve the given previews without any network request,
+// url not in the map is unavailable. It's meant for tests and running locally offline.
+func NewFakeLinkPreviewModule(previews map[string]entity.LinkPreview) service.LinkPreviewModule {
+	return &fakeLinkPreviewModule{previews: previews}
+}
+
+type fakeLinkPreviewModule struct {
+	previews map[string]entity.LinkPreview
+}
+
+func (m *fakeLinkPreviewModule) FetchLinkPreview(_ context.Context, url string) (*entity.LinkPreview, error) {
+	preview, ok := m.previews[url]
+	if !ok {
+		return nil, constants.ErrLinkPreviewUnavailable
+	}
+	preview.URL = url
+	return &preview, nil
+}
@@ -0,0 +1,165 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"github.com/aeramu/menfess-backend/entity"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+const (
+	fetchTimeout = 5 * time.Second
+	// maxBodySize is enough for the head of most page, metadata after it is ignored
+	maxBodySize  = 512 << 10
+	maxRedirects = 5
+	userAgent    = "MenfessBot/1.0 (link preview)"
+)
+
+var (
+	errBlockedAddress   = errors.New("address is not allowed")
+	errInvalidURL       = errors.New("url is not valid")
+	errTooManyRedirects = errors.New("too many redirects")
+	errUnexpectedStatus = errors.New("unexpected status code")
+	errNotHTML          = errors.New("content is not html")
+	errNoMetadata       = errors.New("page has no metadata")
+)
+
+// blockedNetworks is non public ranges not covered by the net.IP helpers
+var blockedNetworks = parseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"240.0.0.0/4",
+	"fc00::/7",
+)
+
+// newHTTPClient return client that only connect to public address on the standard port,
+// so posting a link can't make the server request its own network
+func newHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: fetchTimeout,
+		Control: dialControl,
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			// proxy is skipped, the address check would only see the proxy address
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   fetchTimeout,
+			ResponseHeaderTimeout: fetchTimeout,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		Timeout: fetchTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return errTooManyRedirects
+			}
+			return validateURL(req.URL)
+		},
+	}
+}
+
+// dialControl check the resolved address right before connecting,
+// it cover redirect and dns rebinding that checking the hostname upfront would miss
+func dialControl(_, address string, _ syscall.RawConn) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if port != "80" && port != "443" {
+		return errBlockedAddress
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return errBlockedAddress
+	}
+	return nil
+}
+
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	for _, v := range blockedNetworks {
+		if v.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func validateURL(u *url.URL) error {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || u.User != nil {
+		return errInvalidURL
+	}
+	return nil
+}
+
+func (m *linkPreviewModule) fetch(ctx context.Context, rawURL string) (*entity.LinkPreview, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errInvalidURL
+	}
+	if err := validateURL(u); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errUnexpectedStatus
+	}
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || (mediaType != "text/html" && mediaType != "application/xhtml+xml") {
+		return nil, errNotHTML
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, err
+	}
+
+	// relative image is resolved against the final url after redirect
+	preview := parseMetadata(string(body), resp.Request.URL)
+	if preview.Title == "" {
+		return nil, errNoMetadata
+	}
+	preview.URL = rawURL
+	return &preview, nil
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	result := make([]*net.IPNet, len(cidrs))
+	for i, v := range cidrs {
+		_, network, err := net.ParseCIDR(v)
+		if err != nil {
+			panic(err)
+		}
+		result[i] = network
+	}
+	return result
+}
@@ -0,0 +1,124 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "93.184.216.34", want: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", want: true},
+		// private
+		{ip: "10.1.2.3", want: false},
+		{ip: "172.16.0.1", want: false},
+		{ip: "172.31.255.255", want: false},
+		{ip: "192.168.1.1", want: false},
+		{ip: "100.64.0.1", want: false},
+		{ip: "fd00::1", want: false},
+		// loopback and unspecified
+		{ip: "127.0.0.1", want: false},
+		{ip: "127.1.2.3", want: false},
+		{ip: "::1", want: false},
+		{ip: "0.0.0.0", want: false},
+		{ip: "::", want: false},
+		// link local, including the cloud metadata address
+		{ip: "169.254.169.254", want: false},
+		{ip: "fe80::1", want: false},
+		// ipv4 mapped ipv6 is checked as the ipv4 address
+		{ip: "::ffff:127.0.0.1", want: false},
+		{ip: "::ffff:10.0.0.1", want: false},
+		{ip: "::ffff:169.254.169.254", want: false},
+		{ip: "::ffff:192.168.0.1", want: false},
+		{ip: "::ffff:93.184.216.34", want: true},
+		// multicast and reserved
+		{ip: "224.0.0.1", want: false},
+		{ip: "255.255.255.255", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			assert.Equal(t, tt.want, isPublicIP(net.ParseIP(tt.ip)))
+		})
+	}
+}
+
+func TestDialControl(t *testing.T) {
+	tests := []struct {
+		address string
+		wantErr bool
+	}{
+		{address: "93.184.216.34:443", wantErr: false},
+		{address: "93.184.216.34:80", wantErr: false},
+		{address: "[2606:2800:220:1:248:1893:25c8:1946]:443", wantErr: false},
+		{address: "93.184.216.34:22", wantErr: true},
+		{address: "127.0.0.1:80", wantErr: true},
+		{address: "[::ffff:127.0.0.1]:443", wantErr: true},
+		{address: "[::1]:443", wantErr: true},
+		{address: "example.com:443", wantErr: true},
+		{address: "93.184.216.34", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := dialControl("tcp", tt.address, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestFetch_blockLocalServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<title>internal</title>"))
+	}))
+	defer server.Close()
+
+	m := &linkPreviewModule{client: newHTTPClient()}
+	_, err := m.fetch(context.Background(), server.URL)
+	assert.True(t, errors.Is(err, errBlockedAddress), "got %v", err)
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/blog/post", http.StatusMovedPermanently)
+		case "/blog/post":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(`<html><head><meta property="og:title" content="Post"><meta property="og:image" content="cover.png"></head></html>`))
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"title":"json"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// the test server is on loopback, so the client without the address check is used
+	m := &linkPreviewModule{client: server.Client()}
+
+	got, err := m.fetch(context.Background(), server.URL+"/old")
+	assert.Nil(t, err)
+	assert.Equal(t, server.URL+"/old", got.URL)
+	assert.Equal(t, "Post", got.Title)
+	assert.Equal(t, server.URL+"/blog/cover.png", got.ImageURL)
+
+	_, err = m.fetch(context.Background(), server.URL+"/json")
+	assert.Equal(t, errNotHTML, err)
+	_, err = m.fetch(context.Background(), server.URL+"/missing")
+	assert.Equal(t, errUnexpectedStatus, err)
+	_, err = m.fetch(context.Background(), "ftp://example.com/file")
+	assert.Equal(t, errInvalidURL, err)
+}
@@ -0,0 +1,100 @@
+package linkpreview
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/aeramu/menfess-backend/service"
+	"github.com/aeramu/mongolib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"net/http"
+	"time"
+)
+
+const (
+	// cacheTTL is how long fetched preview reused before the page fetched again
+	cacheTTL = 24 * time.Hour
+	// failedCacheTTL is shorter so page that was down temporarily get its preview later
+	failedCacheTTL = time.Hour
+)
+
+func NewLinkPreviewModule(db *mongolib.Database) service.LinkPreviewModule {
+	return &linkPreviewModule{
+		cache:  db.Coll("link_preview"),
+		client: newHTTPClient(),
+	}
+}
+
+type linkPreviewModule struct {
+	cache  *mongolib.Collection
+	client *http.Client
+}
+
+func (m *linkPreviewModule) FetchLinkPreview(ctx context.Context, url string) (*entity.LinkPreview, error) {
+	var cached LinkPreview
+	err := m.cache.Query().Equal("url", url).FindOne(ctx).Consume(&cached)
+	if err != nil && err != mongolib.ErrNotFound {
+		return nil, err
+	}
+	if err == nil && !cached.IsStale(time.Now()) {
+		if cached.Failed {
+			return nil, constants.ErrLinkPreviewUnavailable
+		}
+		return cached.Entity(), nil
+	}
+
+	preview, fetchErr := m.fetch(ctx, url)
+	model := LinkPreview{
+		URL:       url,
+		FetchedAt: time.Now().Unix(),
+		Failed:    fetchErr != nil,
+	}
+	if preview != nil {
+		model.Title = preview.Title
+		model.Description = preview.Description
+		model.ImageURL = preview.ImageURL
+		model.SiteName = preview.SiteName
+	}
+	if _, err := m.cache.UpdateOne(ctx,
+		bson.D{{Key: "url", Value: url}},
+		bson.D{{Key: "$set", Value: model}},
+		options.Update().SetUpsert(true)); err != nil {
+		return nil, err
+	}
+	if fetchErr != nil {
+		return nil, constants.ErrLinkPreviewUnavailable
+	}
+	return model.Entity(), nil
+}
+
+type LinkPreview struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	URL         string             `bson:"url"`
+	Title       string             `bson:"title"`
+	Description string             `bson:"description"`
+	ImageURL    string             `bson:"image_url"`
+	SiteName    string             `bson:"site_name"`
+	FetchedAt   int64              `bson:"fetched_at"`
+	// Failed mark the page couldn't be fetched or has no metadata, cached so it isn't requested on every post
+	Failed bool `bson:"failed"`
+}
+
+func (l LinkPreview) IsStale(now time.Time) bool {
+	ttl := cacheTTL
+	if l.Failed {
+		ttl = failedCacheTTL
+	}
+	return now.Sub(time.Unix(l.FetchedAt, 0)) > ttl
+}
+
+func (l LinkPreview) Entity() *entity.LinkPreview {
+	return &entity.LinkPreview{
+		URL:         l.URL,
+		Title:       l.Title,
+		Description: l.Description,
+		ImageURL:    l.ImageURL,
+		SiteName:    l.SiteName,
+	}
+}
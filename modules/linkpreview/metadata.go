@@ -0,0 +1,97 @@
+package linkpreview
+
+import (
+	"github.com/aeramu/menfess-backend/entity"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	maxTitleLength       = 200
+	maxDescriptionLength = 500
+)
+
+var (
+	headEndRegex = regexp.MustCompile(`(?i)</head\s*>`)
+	metaRegex    = regexp.MustCompile(`(?is)<meta\s([^>]*)>`)
+	attrRegex    = regexp.MustCompile(`(?s)([a-zA-Z_:.-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	titleRegex   = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title\s*>`)
+)
+
+// parseMetadata read OpenGraph tags of the page, falling back to twitter card and plain html tags
+func parseMetadata(doc string, base *url.URL) entity.LinkPreview {
+	if loc := headEndRegex.FindStringIndex(doc); loc != nil {
+		doc = doc[:loc[0]]
+	}
+
+	meta := make(map[string]string)
+	for _, v := range metaRegex.FindAllStringSubmatch(doc, -1) {
+		attrs := parseAttributes(v[1])
+		key := attrs["property"]
+		if key == "" {
+			key = attrs["name"]
+		}
+		key = strings.ToLower(key)
+		// the first occurrence win, page usually put the main image first
+		if _, ok := meta[key]; key == "" || ok {
+			continue
+		}
+		meta[key] = cleanText(attrs["content"])
+	}
+	title := ""
+	if v := titleRegex.FindStringSubmatch(doc); v != nil {
+		title = cleanText(v[1])
+	}
+
+	return entity.LinkPreview{
+		Title:       truncate(firstNonEmpty(meta["og:title"], meta["twitter:title"], title), maxTitleLength),
+		Description: truncate(firstNonEmpty(meta["og:description"], meta["twitter:description"], meta["description"]), maxDescriptionLength),
+		ImageURL:    resolveURL(base, firstNonEmpty(meta["og:image:secure_url"], meta["og:image"], meta["og:image:url"], meta["twitter:image"])),
+		SiteName:    firstNonEmpty(meta["og:site_name"], base.Hostname()),
+	}
+}
+
+func parseAttributes(s string) map[string]string {
+	attrs := make(map[string]string)
+	for _, v := range attrRegex.FindAllStringSubmatch(s, -1) {
+		attrs[strings.ToLower(v[1])] = v[2] + v[3] + v[4]
+	}
+	return attrs
+}
+
+// cleanText unescape html entity and collapse whitespace
+func cleanText(s string) string {
+	s = strings.ToValidUTF8(html.UnescapeString(s), "")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// resolveURL make relative url absolute, non http(s) url like data: is dropped
+func resolveURL(base *url.URL, s string) string {
+	if s == "" {
+		return ""
+	}
+	u, err := base.Parse(s)
+	if err != nil || validateURL(u) != nil {
+		return ""
+	}
+	return u.String()
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:max-1])) + "…"
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package linkpreview
+
+import (
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestParseMetadata(t *testing.T) {
+	base, _ := url.Parse("https://news.example.com/2021/01/article")
+	tests := []struct {
+		name string
+		doc  string
+		want entity.LinkPreview
+	}{
+		{
+			name: "open graph",
+			doc: `<html><head>
+				<title>Plain title</title>
+				<meta property="og:title" content="OG title">
+				<meta property="og:description" content="OG description">
+				<meta property="og:image" content="https://cdn.example.com/a.jpg">
+				<meta property="og:site_name" content="Example News">
+			</head><body></body></html>`,
+			want: entity.LinkPreview{
+				Title:       "OG title",
+				Description: "OG description",
+				ImageURL:    "https://cdn.example.com/a.jpg",
+				SiteName:    "Example News",
+			},
+		},
+		{
+			name: "fallback to twitter card then plain html",
+			doc: `<head>
+				<TITLE>Plain title</TITLE>
+				<meta name="description" content="Meta description">
+				<meta name="twitter:image" content="/img/b.png">
+			</head>`,
+			want: entity.LinkPreview{
+				Title:       "Plain title",
+				Description: "Meta description",
+				ImageURL:    "https://news.example.com/img/b.png",
+				SiteName:    "news.example.com",
+			},
+		},
+		{
+			name: "attribute in any order and quote, first occurrence win",
+			doc: `<head>
+				<meta content='First' property='og:title'>
+				<meta content="Second" property="og:title">
+				<meta property=og:description content=unquoted>
+			</head>`,
+			want: entity.LinkPreview{
+				Title:       "First",
+				Description: "unquoted",
+				SiteName:    "news.example.com",
+			},
+		},
+		{
+			name: "entity unescaped and whitespace collapsed",
+			doc:  "<head><meta property=\"og:title\" content=\"Tom &amp; Jerry\n\t  &quot;reunion&quot;\"></head>",
+			want: entity.LinkPreview{
+				Title:    `Tom & Jerry "reunion"`,
+				SiteName: "news.example.com",
+			},
+		},
+		{
+			name: "non http image dropped",
+			doc:  `<head><meta property="og:title" content="T"><meta property="og:image" content="data:image/png;base64,AAAA"></head>`,
+			want: entity.LinkPreview{
+				Title:    "T",
+				SiteName: "news.example.com",
+			},
+		},
+		{
+			name: "meta in body ignored",
+			doc:  `<head><title>Head</title></head><body><meta property="og:title" content="Body"></body>`,
+			want: entity.LinkPreview{
+				Title:    "Head",
+				SiteName: "news.example.com",
+			},
+		},
+		{
+			name: "no metadata",
+			doc:  `<p>hello</p>`,
+			want: entity.LinkPreview{SiteName: "news.example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseMetadata(tt.doc, base))
+		})
+	}
+}
+
+func TestParseMetadata_truncate(t *testing.T) {
+	base, _ := url.Parse("https://example.com")
+	doc := `<head><meta property="og:title" content="` + strings.Repeat("é", maxTitleLength+10) + `"></head>`
+
+	got := parseMetadata(doc, base)
+	assert.Equal(t, maxTitleLength, len([]rune(got.Title)))
+	assert.True(t, strings.HasSuffix(got.Title, "…"))
+}
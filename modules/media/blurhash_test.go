@@ -0,0 +1,53 @@
+package media
+
+import (
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"testing"
+)
+
+// halves return 8x6 image, black on one half and white on the other
+func halves(vertical bool) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 6))
+	for y := 0; y < 6; y++ {
+		for x := 0; x < 8; x++ {
+			c := color.Black
+			if (vertical && x >= 4) || (!vertical && y >= 3) {
+				c = color.White
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestBlurHash(t *testing.T) {
+	tests := []struct {
+		name string
+		img  image.Image
+		want string
+	}{
+		{
+			name: "black left, white right",
+			img:  halves(true),
+			want: "L~Lqe900D%?b%MM{Rjt7fQfQfQfQ",
+		},
+		{
+			name: "black top, white bottom",
+			img:  halves(false),
+			want: "L[Lqe9%MfQ%M00RjfQRj4nRjfQRj",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, blurHash(tt.img, 4, 3))
+		})
+	}
+}
+
+func TestEncode83(t *testing.T) {
+	assert.Equal(t, "L", encode83(21, 1))
+	assert.Equal(t, "TI:j", encode83(0xFF0000, 4))
+	assert.Equal(t, "fQ", encode83(3429, 2))
+}
@@ -0,0 +1,77 @@
+package media
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var errInvalidGIF = errors.New("media: invalid gif")
+
+// gifFrames count the frames of the gif and the sum of their pixels by walking its blocks,
+// the pixel data is skipped without decoding
+func gifFrames(data []byte) (int, int, error) {
+	// header and logical screen descriptor
+	if len(data) < 13 {
+		return 0, 0, errInvalidGIF
+	}
+	i := 13
+	if data[10]&0x80 != 0 {
+		i += colorTableSize(data[10])
+	}
+
+	var frames, pixels int
+	for i < len(data) {
+		switch data[i] {
+		case 0x21:
+			// extension, label then sub-blocks
+			n, err := skipSubBlocks(data, i+2)
+			if err != nil {
+				return 0, 0, err
+			}
+			i = n
+		case 0x2C:
+			// image descriptor, then optional local color table, lzw code size and the sub-blocks
+			if i+10 > len(data) {
+				return 0, 0, errInvalidGIF
+			}
+			w := int(binary.LittleEndian.Uint16(data[i+5:]))
+			h := int(binary.LittleEndian.Uint16(data[i+7:]))
+			frames++
+			pixels += w * h
+			j := i + 10
+			if data[i+9]&0x80 != 0 {
+				j += colorTableSize(data[i+9])
+			}
+			n, err := skipSubBlocks(data, j+1)
+			if err != nil {
+				return 0, 0, err
+			}
+			i = n
+		case 0x3B:
+			return frames, pixels, nil
+		default:
+			return 0, 0, errInvalidGIF
+		}
+	}
+	return 0, 0, errInvalidGIF
+}
+
+// colorTableSize return the byte length of the color table declared in the packed field
+func colorTableSize(packed byte) int {
+	return 3 << (uint(packed&0x07) + 1)
+}
+
+// skipSubBlocks return the index after the sub-blocks starting at i
+func skipSubBlocks(data []byte, i int) (int, error) {
+	for {
+		if i >= len(data) {
+			return 0, errInvalidGIF
+		}
+		n := int(data[i])
+		i++
+		if n == 0 {
+			return i, nil
+		}
+		i += n
+	}
+}
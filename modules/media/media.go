@@ -0,0 +1,156 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/aeramu/menfess-backend/service"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+const (
+	jpegQuality = 85
+	// blurhash is computed from small image, the result is blurry anyway
+	blurHashSourceSize = 32
+	blurHashComponentX = 4
+	blurHashComponentY = 3
+)
+
+func NewMediaModule() service.MediaModule {
+	return &mediaModule{}
+}
+
+type mediaModule struct{}
+
+func (m *mediaModule) ProcessImage(_ context.Context, data []byte, contentType string) (*entity.ProcessedImage, error) {
+	var (
+		img      image.Image
+		animated *gif.GIF
+		err      error
+	)
+	if err := checkSize(data, contentType); err != nil {
+		return nil, err
+	}
+
+	switch contentType {
+	case "image/jpeg":
+		img, err = jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		img = applyOrientation(img, jpegOrientation(data))
+	case "image/png":
+		img, err = png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+	case "image/gif":
+		animated, err = gif.DecodeAll(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		img = animated.Image[0]
+	default:
+		return nil, image.ErrFormat
+	}
+
+	// re-encoding drop exif, comment and any other metadata from the upload
+	var original *entity.ImageVariant
+	if animated != nil && len(animated.Image) > 1 {
+		// keep animation as is, only the still variants are resized
+		original, err = encodeGIF(animated)
+	} else {
+		original, err = encode(resize(img, constants.ImageMaxDimension))
+	}
+	if err != nil {
+		return nil, err
+	}
+	original.Name = constants.ImageVariantOriginal
+
+	result := &entity.ProcessedImage{
+		BlurHash: blurHash(resize(img, blurHashSourceSize), blurHashComponentX, blurHashComponentY),
+		Variants: []entity.ImageVariant{*original},
+	}
+	for _, v := range constants.ImageVariantSizes {
+		b := img.Bounds()
+		// variant bigger than the image itself is useless
+		if b.Dx() <= v.Size && b.Dy() <= v.Size {
+			continue
+		}
+		variant, err := encode(resize(img, v.Size))
+		if err != nil {
+			return nil, err
+		}
+		variant.Name = v.Name
+		result.Variants = append(result.Variants, *variant)
+	}
+	return result, nil
+}
+
+// checkSize reject the image too large to decode from its header, before any pixel is decoded
+func checkSize(data []byte, contentType string) error {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if config.Width*config.Height > constants.ImageMaxPixels {
+		return constants.ErrInvalidImage
+	}
+	if contentType != "image/gif" {
+		return nil
+	}
+	frames, pixels, err := gifFrames(data)
+	if err != nil {
+		return err
+	}
+	if frames > constants.ImageMaxFrames || pixels > constants.ImageMaxTotalFramePixels {
+		return constants.ErrInvalidImage
+	}
+	return nil
+}
+
+// encode image as jpeg, or png if it has transparency
+func encode(img image.Image) (*entity.ImageVariant, error) {
+	var buf bytes.Buffer
+	contentType := "image/jpeg"
+	if isOpaque(img) {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+	} else {
+		contentType = "image/png"
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, err
+		}
+	}
+	return &entity.ImageVariant{
+		ContentType: contentType,
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+func encodeGIF(img *gif.GIF) (*entity.ImageVariant, error) {
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, img); err != nil {
+		return nil, err
+	}
+	return &entity.ImageVariant{
+		ContentType: "image/gif",
+		Width:       img.Config.Width,
+		Height:      img.Config.Height,
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
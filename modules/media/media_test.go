@@ -0,0 +1,109 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/stretchr/testify/assert"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/png"
+	"testing"
+)
+
+func testPNG(t *testing.T, w, h int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// pngWithSize rewrite the IHDR dimensions, the pixel data stays tiny
+func pngWithSize(t *testing.T, w, h int) []byte {
+	data := testPNG(t, 1, 1)
+	binary.BigEndian.PutUint32(data[16:], uint32(w))
+	binary.BigEndian.PutUint32(data[20:], uint32(h))
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func testGIF(t *testing.T, w, h, frames int) []byte {
+	g := &gif.GIF{Config: image.Config{Width: w, Height: h, ColorModel: color.Palette(palette.Plan9)}}
+	for i := 0; i < frames; i++ {
+		g.Image = append(g.Image, image.NewPaletted(image.Rect(0, 0, w, h), palette.Plan9))
+		g.Delay = append(g.Delay, 10)
+	}
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestProcessImage_size(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+		wantErr     error
+	}{
+		{
+			name:        "small png",
+			data:        testPNG(t, 40, 30),
+			contentType: "image/png",
+			wantErr:     nil,
+		},
+		{
+			name:        "png header over the pixel limit",
+			data:        pngWithSize(t, 50000, 50000),
+			contentType: "image/png",
+			wantErr:     constants.ErrInvalidImage,
+		},
+		{
+			name:        "animated gif",
+			data:        testGIF(t, 20, 20, 3),
+			contentType: "image/gif",
+			wantErr:     nil,
+		},
+		{
+			name:        "gif with too many frames",
+			data:        testGIF(t, 1, 1, constants.ImageMaxFrames+1),
+			contentType: "image/gif",
+			wantErr:     constants.ErrInvalidImage,
+		},
+		{
+			name:        "gif frames over the total pixel limit",
+			data:        testGIF(t, 2000, 2000, constants.ImageMaxTotalFramePixels/(2000*2000)+1),
+			contentType: "image/gif",
+			wantErr:     constants.ErrInvalidImage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMediaModule().ProcessImage(context.Background(), tt.data, tt.contentType)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.NotEmpty(t, got.Variants)
+			}
+		})
+	}
+}
+
+func TestGIFFrames(t *testing.T) {
+	frames, pixels, err := gifFrames(testGIF(t, 30, 20, 4))
+	assert.Nil(t, err)
+	assert.Equal(t, 4, frames)
+	assert.Equal(t, 4*30*20, pixels)
+
+	_, _, err = gifFrames(testGIF(t, 30, 20, 4)[:40])
+	assert.Error(t, err)
+}
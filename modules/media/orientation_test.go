@@ -0,0 +1,71 @@
+package media
+
+import (
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"testing"
+)
+
+// exifJPEG build the jpeg markers up to start of scan, with the orientation in APP1 exif
+func exifJPEG(order binary.ByteOrder, orientation uint16) []byte {
+	tiff := make([]byte, 26)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], 0x0112)
+	order.PutUint16(tiff[12:], 3)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], orientation)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	data := []byte{0xFF, 0xD8, 0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(data[4:], uint16(len(segment)+2))
+	data = append(data, segment...)
+	return append(data, 0xFF, 0xDA, 0, 2)
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	for orientation := 1; orientation <= 8; orientation++ {
+		assert.Equal(t, orientation, jpegOrientation(exifJPEG(binary.LittleEndian, uint16(orientation))), "little endian %d", orientation)
+		assert.Equal(t, orientation, jpegOrientation(exifJPEG(binary.BigEndian, uint16(orientation))), "big endian %d", orientation)
+	}
+	assert.Equal(t, 1, jpegOrientation([]byte{0xFF, 0xD8, 0xFF, 0xDA, 0, 2}), "no exif")
+	assert.Equal(t, 1, jpegOrientation([]byte("not a jpeg")), "not a jpeg")
+	assert.Equal(t, 1, jpegOrientation(exifJPEG(binary.LittleEndian, 6)[:20]), "truncated exif")
+}
+
+func TestApplyOrientation(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	tests := []struct {
+		orientation int
+		wantWidth   int
+		wantHeight  int
+		// wantRed is where the top left pixel ends up
+		wantRed image.Point
+	}{
+		{orientation: 1, wantWidth: 2, wantHeight: 3, wantRed: image.Pt(0, 0)},
+		{orientation: 2, wantWidth: 2, wantHeight: 3, wantRed: image.Pt(1, 0)},
+		{orientation: 3, wantWidth: 2, wantHeight: 3, wantRed: image.Pt(1, 2)},
+		{orientation: 4, wantWidth: 2, wantHeight: 3, wantRed: image.Pt(0, 2)},
+		{orientation: 5, wantWidth: 3, wantHeight: 2, wantRed: image.Pt(0, 0)},
+		{orientation: 6, wantWidth: 3, wantHeight: 2, wantRed: image.Pt(2, 0)},
+		{orientation: 7, wantWidth: 3, wantHeight: 2, wantRed: image.Pt(2, 1)},
+		{orientation: 8, wantWidth: 3, wantHeight: 2, wantRed: image.Pt(0, 1)},
+	}
+	for _, tt := range tests {
+		img := image.NewRGBA(image.Rect(0, 0, 2, 3))
+		img.Set(0, 0, red)
+
+		got := applyOrientation(img, tt.orientation)
+		assert.Equal(t, tt.wantWidth, got.Bounds().Dx(), "orientation %d", tt.orientation)
+		assert.Equal(t, tt.wantHeight, got.Bounds().Dy(), "orientation %d", tt.orientation)
+		assert.Equal(t, red, got.At(tt.wantRed.X, tt.wantRed.Y), "orientation %d", tt.orientation)
+	}
+}
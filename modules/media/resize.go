@@ -0,0 +1,67 @@
+package media
+
+import (
+	"image"
+	"image/draw"
+)
+
+// resize downscale the image to fit within max x max keeping its aspect ratio,
+// image already fit is returned as is. Each destination pixel is the average of
+// the source pixels it covers, so it doesn't alias like nearest neighbour.
+func resize(img image.Image, max int) image.Image {
+	b := img.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	if sw <= max && sh <= max {
+		return img
+	}
+	dw, dh := max, max
+	if sw > sh {
+		dh = sh * max / sw
+	} else {
+		dw = sw * max / sh
+	}
+	if dw < 1 {
+		dw = 1
+	}
+	if dh < 1 {
+		dh = 1
+	}
+
+	src := image.NewRGBA(image.Rect(0, 0, sw, sh))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for dy := 0; dy < dh; dy++ {
+		y0, y1 := span(dy, sh, dh)
+		for dx := 0; dx < dw; dx++ {
+			x0, x1 := span(dx, sw, dw)
+			var r, g, bl, a, n int
+			for y := y0; y < y1; y++ {
+				i := src.PixOffset(x0, y)
+				for x := x0; x < x1; x++ {
+					r += int(src.Pix[i])
+					g += int(src.Pix[i+1])
+					bl += int(src.Pix[i+2])
+					a += int(src.Pix[i+3])
+					i += 4
+					n++
+				}
+			}
+			j := dst.PixOffset(dx, dy)
+			dst.Pix[j] = uint8(r / n)
+			dst.Pix[j+1] = uint8(g / n)
+			dst.Pix[j+2] = uint8(bl / n)
+			dst.Pix[j+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// span return source range [from, to) covered by destination index i
+func span(i, src, dst int) (int, int) {
+	from := i * src / dst
+	to := (i + 1) * src / dst
+	if to <= from {
+		to = from + 1
+	}
+	return from, to
+}
@@ -0,0 +1,41 @@
+package media
+
+import (
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		name       string
+		w, h       int
+		max        int
+		wantWidth  int
+		wantHeight int
+	}{
+		{name: "landscape", w: 400, h: 300, max: 200, wantWidth: 200, wantHeight: 150},
+		{name: "portrait", w: 300, h: 400, max: 200, wantWidth: 150, wantHeight: 200},
+		{name: "already fit", w: 120, h: 80, max: 200, wantWidth: 120, wantHeight: 80},
+		{name: "thin strip keep at least a pixel", w: 1000, h: 2, max: 100, wantWidth: 100, wantHeight: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resize(image.NewRGBA(image.Rect(0, 0, tt.w, tt.h)), tt.max)
+			assert.Equal(t, tt.wantWidth, got.Bounds().Dx())
+			assert.Equal(t, tt.wantHeight, got.Bounds().Dy())
+		})
+	}
+}
+
+func TestResize_average(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.White)
+	img.Set(1, 1, color.White)
+	img.Set(1, 0, color.Black)
+	img.Set(0, 1, color.Black)
+
+	got := resize(img, 1)
+	assert.Equal(t, color.RGBA{R: 127, G: 127, B: 127, A: 255}, got.At(0, 0))
+}
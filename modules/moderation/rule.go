@@ -0,0 +1,107 @@
+package moderation
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/aeramu/menfess-backend/service"
+	"github.com/aeramu/menfess-backend/utils"
+	"regexp"
+	"strings"
+)
+
+type RuleConfig struct {
+	// BannedWords is matched as whole word after utils.NormalizeText, so the leet and repeated letter variant is caught
+	BannedWords []string `json:"bannedWords"`
+	// BannedPatterns is regular expression matched case insensitive against the text as written
+	BannedPatterns []string `json:"bannedPatterns"`
+	// MaxLinks is how many url a post can have before it's flagged, constants.ModerationMaxLinksDefault if not set
+	MaxLinks int `json:"maxLinks"`
+}
+
+// NewRuleModerationModule return error if any of the banned patterns isn't a valid regular expression
+func NewRuleModerationModule(config RuleConfig) (service.ModerationModule, error) {
+	patterns := make([]*regexp.Regexp, len(config.BannedPatterns))
+	for i, v := range config.BannedPatterns {
+		pattern, err := regexp.Compile("(?i)" + v)
+		if err != nil {
+			return nil, err
+		}
+		patterns[i] = pattern
+	}
+	maxLinks := config.MaxLinks
+	if maxLinks <= 0 {
+		maxLinks = constants.ModerationMaxLinksDefault
+	}
+	return &ruleModerationModule{
+		bannedWords:    utils.NewMatcher(config.BannedWords),
+		bannedPatterns: patterns,
+		maxLinks:       maxLinks,
+	}, nil
+}
+
+type ruleModerationModule struct {
+	bannedWords    *utils.Matcher
+	bannedPatterns []*regexp.Regexp
+	maxLinks       int
+}
+
+// rule is a check on the post text, the strictest decision of the matched rules is taken
+type rule struct {
+	reason   string
+	decision string
+	match    func(text string) bool
+}
+
+func (m *ruleModerationModule) ReviewPost(ctx context.Context, post entity.Post) (*entity.ModerationResult, error) {
+	text := postText(post)
+	rules := []rule{
+		{constants.ModerationReasonBannedWord, constants.ModerationDecisionReject, m.bannedWords.MatchAny},
+		{constants.ModerationReasonBannedPattern, constants.ModerationDecisionReject, m.matchBannedPattern},
+		// national identity number is never needed in a confession, phone number and student id may be the poster own
+		{constants.ModerationReasonNIK, constants.ModerationDecisionReject, contains(utils.NIKDetector{})},
+		{constants.ModerationReasonPhoneNumber, constants.ModerationDecisionFlag, contains(utils.PhoneNumberDetector{})},
+		{constants.ModerationReasonStudentID, constants.ModerationDecisionFlag, contains(utils.StudentIDDetector{})},
+		{constants.ModerationReasonTooManyLinks, constants.ModerationDecisionFlag, func(text string) bool {
+			return len(utils.ExtractURLs(text)) > m.maxLinks
+		}},
+	}
+
+	result := &entity.ModerationResult{Decision: constants.ModerationDecisionAllow}
+	for _, v := range rules {
+		if !v.match(text) {
+			continue
+		}
+		result.Reasons = append(result.Reasons, v.reason)
+		if v.decision == constants.ModerationDecisionReject || result.Decision == constants.ModerationDecisionAllow {
+			result.Decision = v.decision
+		}
+	}
+	return result, nil
+}
+
+func (m *ruleModerationModule) matchBannedPattern(text string) bool {
+	for _, v := range m.bannedPatterns {
+		if v.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(detector utils.PersonalDataDetector) func(text string) bool {
+	return func(text string) bool {
+		return len(detector.Detect(text)) > 0
+	}
+}
+
+// postText join the body and the poll options, each on its own line so a match doesn't span two of them
+func postText(post entity.Post) string {
+	texts := []string{post.Body}
+	if post.Poll != nil {
+		for _, v := range post.Poll.Options {
+			texts = append(texts, v.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,157 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// links return n different url separated by space
+func links(n int) string {
+	var result []string
+	for i := 0; i < n; i++ {
+		result = append(result, fmt.Sprintf("https://site%d.com", i))
+	}
+	return strings.Join(result, " ")
+}
+
+func TestNewRuleModerationModule_invalidPattern(t *testing.T) {
+	m, err := NewRuleModerationModule(RuleConfig{BannedPatterns: []string{`judi\s*online`, `slot(`}})
+	assert.Error(t, err)
+	assert.Nil(t, m)
+}
+
+func TestRuleModerationModule_ReviewPost(t *testing.T) {
+	m, err := NewRuleModerationModule(RuleConfig{
+		BannedWords:    []string{"anjing", "judi"},
+		BannedPatterns: []string{`slot\s*gacor`, `wa\.me/\d+`},
+		MaxLinks:       2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		post entity.Post
+		want *entity.ModerationResult
+	}{
+		{
+			name: "clean post",
+			post: entity.Post{Body: "buat kamu yang tadi pagi di perpus, makasih ya"},
+			want: &entity.ModerationResult{Decision: constants.ModerationDecisionAllow},
+		},
+		{
+			name: "banned word as leet",
+			post: entity.Post{Body: "dasar 4nj1ng"},
+			want: &entity.ModerationResult{
+				Decision: constants.ModerationDecisionReject,
+				Reasons:  []string{constants.ModerationReasonBannedWord},
+			},
+		},
+		{
+			name: "banned word only as whole word",
+			post: entity.Post{Body: "lagi nonton film judian"},
+			want: &entity.ModerationResult{Decision: constants.ModerationDecisionAllow},
+		},
+		{
+			name: "banned pattern case insensitive",
+			post: entity.Post{Body: "info SLOT   Gacor hari ini"},
+			want: &entity.ModerationResult{
+				Decision: constants.ModerationDecisionReject,
+				Reasons:  []string{constants.ModerationReasonBannedPattern},
+			},
+		},
+		{
+			name: "nik rejected",
+			post: entity.Post{Body: "nik dia 3201234508900001"},
+			want: &entity.ModerationResult{
+				Decision: constants.ModerationDecisionReject,
+				Reasons:  []string{constants.ModerationReasonNIK},
+			},
+		},
+		{
+			name: "invalid nik date not rejected",
+			post: entity.Post{Body: "nomor 3201234513900001 bulan 13"},
+			want: &entity.ModerationResult{Decision: constants.ModerationDecisionAllow},
+		},
+		{
+			name: "phone number flagged",
+			post: entity.Post{Body: "yang punya nomor 081234567890 tolong balas"},
+			want: &entity.ModerationResult{
+				Decision: constants.ModerationDecisionFlag,
+				Reasons:  []string{constants.ModerationReasonPhoneNumber},
+			},
+		},
+		{
+			name: "student id in poll option flagged",
+			post: entity.Post{
+				Body: "siapa yang lebih cakep",
+				Poll: &entity.Poll{Options: []entity.PollOption{{Text: "NIM: 13519001"}, {Text: "yang satunya"}}},
+			},
+			want: &entity.ModerationResult{
+				Decision: constants.ModerationDecisionFlag,
+				Reasons:  []string{constants.ModerationReasonStudentID},
+			},
+		},
+		{
+			name: "links up to the limit allowed",
+			post: entity.Post{Body: "cek https://a.com dan https://b.com"},
+			want: &entity.ModerationResult{Decision: constants.ModerationDecisionAllow},
+		},
+		{
+			name: "links over the limit flagged",
+			post: entity.Post{Body: "cek https://a.com https://b.com https://c.com"},
+			want: &entity.ModerationResult{
+				Decision: constants.ModerationDecisionFlag,
+				Reasons:  []string{constants.ModerationReasonTooManyLinks},
+			},
+		},
+		{
+			name: "the same link counted once",
+			post: entity.Post{Body: strings.Repeat("https://a.com ", 3)},
+			want: &entity.ModerationResult{Decision: constants.ModerationDecisionAllow},
+		},
+		{
+			name: "reject beat flag, every reason kept",
+			post: entity.Post{Body: "wa 081234567890 buat judi " + links(3)},
+			want: &entity.ModerationResult{
+				Decision: constants.ModerationDecisionReject,
+				Reasons: []string{
+					constants.ModerationReasonBannedWord,
+					constants.ModerationReasonPhoneNumber,
+					constants.ModerationReasonTooManyLinks,
+				},
+			},
+		},
+		{
+			name: "reject after flag still reject",
+			post: entity.Post{Body: "081234567890 nik 3201234508900001"},
+			want: &entity.ModerationResult{
+				Decision: constants.ModerationDecisionReject,
+				Reasons:  []string{constants.ModerationReasonNIK, constants.ModerationReasonPhoneNumber},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.ReviewPost(context.Background(), tt.post)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRuleModerationModule_defaultMaxLinks(t *testing.T) {
+	m, err := NewRuleModerationModule(RuleConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := m.ReviewPost(context.Background(), entity.Post{Body: links(constants.ModerationMaxLinksDefault)})
+	assert.Equal(t, constants.ModerationDecisionAllow, got.Decision)
+	got, _ = m.ReviewPost(context.Background(), entity.Post{Body: links(constants.ModerationMaxLinksDefault + 1)})
+	assert.Equal(t, constants.ModerationDecisionFlag, got.Decision)
+}
@@ -21,6 +21,24 @@ func (m *notificationModule) findPushToken(ctx context.Context, userID string) (
 	return result, nil
 }
 
+func (m *notificationModule) findPushTokenByUserIDs(ctx context.Context, userIDs []string) ([]string, error) {
+	ids := make([]primitive.ObjectID, len(userIDs))
+	for i, v := range userIDs {
+		ids[i] = mongolib.ObjectID(v)
+	}
+	var models []PushToken
+	if err := m.pushToken.Query().In("user_id", ids).Find(ctx).Consume(&models); err != nil {
+		return nil, err
+	}
+
+	result := make([]string, len(models))
+	for i, v := range models {
+		result[i] = v.Token
+	}
+
+	return result, nil
+}
+
 func (m *notificationModule) findAllPushToken(ctx context.Context) ([]PushToken, error) {
 	var model []PushToken
 	err := m.pushToken.Query().Find(ctx).Consume(&model)
@@ -8,6 +8,7 @@ import (
 	"github.com/aeramu/menfess-backend/service"
 	"github.com/aeramu/menfess-backend/utils"
 	"github.com/aeramu/mongolib"
+	"strings"
 )
 
 func NewNotificationModule(db *mongolib.Database) service.NotificationModule {
@@ -23,7 +24,12 @@ type notificationModule struct {
 const(
 	likeNotificationTitle = "%s like your post"
 	commentNotificationTitle = "%s comment on your post"
+	// mentionNotificationTitle doesn't name the poster, the post may be an anonymous menfess
+	mentionNotificationTitle = "Someone mentioned you in a post"
 	newPostNotificationTitle = "Someone post a menfess just now"
+	followNotificationTitle = "%s started following you"
+	warningNotificationTitle = "Your post was reported for %s"
+	warningNotificationBody = "A moderator reviewed your post and found it breaking the rules, repeated violation can get your account suspended"
 )
 
 func (m *notificationModule) AddPushToken(ctx context.Context, userID string, pushToken string) error {
@@ -43,7 +49,14 @@ func (m *notificationModule) RemovePushToken(ctx context.Context, userID string,
 	return nil
 }
 
+func (m *notificationModule) FindPushTokenList(ctx context.Context, userID string) ([]string, error) {
+	return m.findPushToken(ctx, userID)
+}
+
 func (m *notificationModule) SendLikeNotification(ctx context.Context, user entity.User, post entity.Post) error {
+	if user.Account.ShadowbannedAt != 0 {
+		return nil
+	}
 	tokens, err := m.findPushToken(ctx, post.User.ID)
 	if err != nil {
 		return err
@@ -63,6 +76,9 @@ func (m *notificationModule) SendLikeNotification(ctx context.Context, user enti
 }
 
 func (m *notificationModule) SendCommentNotification(ctx context.Context, comment entity.Post, parent entity.Post) error {
+	if comment.IsShadowbanned {
+		return nil
+	}
 	tokens, err := m.findPushToken(ctx, parent.User.ID)
 	if err != nil {
 		return err
@@ -81,7 +97,40 @@ func (m *notificationModule) SendCommentNotification(ctx context.Context, commen
 	return nil
 }
 
+func (m *notificationModule) SendMentionNotification(ctx context.Context, post entity.Post, userIDs []string) error {
+	if post.IsShadowbanned {
+		return nil
+	}
+	tokens, err := m.findPushTokenByUserIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	// reply is opened in its thread, like comment notification
+	postID := post.ID
+	if post.Parent != nil && post.Parent.ID != "" {
+		postID = post.Parent.ID
+	}
+	if err := m.sendNotification(
+		ctx,
+		tokens,
+		mentionNotificationTitle,
+		post.Body,
+		Data{PostID: postID},
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *notificationModule) BroadcastNewPostNotification(ctx context.Context, post entity.Post) error {
+	if post.IsShadowbanned {
+		return nil
+	}
 	pushTokens, err := m.findAllPushToken(ctx)
 	if err != nil {
 		return err
@@ -111,6 +160,55 @@ func (m *notificationModule) BroadcastNewPostNotification(ctx context.Context, p
 	return nil
 }
 
+func (m *notificationModule) SendFollowNotification(ctx context.Context, follower entity.User, followedID string) error {
+	if follower.Account.ShadowbannedAt != 0 {
+		return nil
+	}
+	tokens, err := m.findPushToken(ctx, followedID)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	if err := m.sendNotification(
+		ctx,
+		tokens,
+		fmt.Sprintf(followNotificationTitle, follower.Profile.Name),
+		follower.Profile.Bio,
+		Data{UserID: follower.ID},
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *notificationModule) SendWarningNotification(ctx context.Context, userID string, reason string) error {
+	tokens, err := m.findPushToken(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	if err := m.sendNotification(
+		ctx,
+		tokens,
+		fmt.Sprintf(warningNotificationTitle, strings.ReplaceAll(reason, "_", " ")),
+		warningNotificationBody,
+		Data{},
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 type Data struct {
 	PostID string `json:"postID"`
+	// UserID is set when the notification open a user profile
+	UserID string `json:"userID,omitempty"`
 }
\ No newline at end of file
@@ -2,6 +2,7 @@ package post
 
 import (
 	"context"
+	"encoding/binary"
 	"github.com/aeramu/menfess-backend/constants"
 	"github.com/aeramu/menfess-backend/entity"
 	"github.com/aeramu/menfess-backend/service"
@@ -9,6 +10,8 @@ import (
 	"github.com/aeramu/mongolib"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
 )
 
 func NewPostModule(db *mongolib.Database) service.PostModule {
@@ -21,14 +24,10 @@ type postModule struct {
 
 func (m *postModule) FindPostByID(ctx context.Context, id string, userID string) (*entity.Post, error) {
 	var model AggregatePostList
-	if err := m.post.Aggregate().
-		Match(mongolib.Filter().Equal("_id", mongolib.ObjectID(id))).
-		Lookup("user", "author_id", "_id", "author").
-		Unwind("$author").
-		Lookup("user", "user_id", "_id", "user").
-		Unwind("$user").
-		Lookup("post", "_id", "parent_id", "replies").
-		AddField("replies_count", bson.D{{"$size", "$replies"}}).
+	if err := m.aggregate(m.post.Aggregate().
+		Match(append(mongolib.Filter().
+			Equal("_id", mongolib.ObjectID(id)),
+			visibleTo(userID)...)), userID).
 		Exec(ctx).Consume(&model);
 	err != nil {
 		return nil, err
@@ -39,31 +38,215 @@ func (m *postModule) FindPostByID(ctx context.Context, id string, userID string)
 	return model[0].Entity(userID), nil
 }
 
-func (m *postModule) FindPostListByParentIDAndAuthorIDs(ctx context.Context, parentID string, authorIDs []string, userID string, pagination api.PaginationReq) ([]entity.Post, *api.PaginationRes, error) {
-	var model AggregatePostList
+func (m *postModule) FindPostListByParentIDAndAuthorIDs(ctx context.Context, parentID string, authorIDs []string, excludedAuthorIDs []string, userID string, pagination api.PaginationReq) ([]entity.Post, *api.PaginationRes, error) {
 	if pagination.After == "" {
 		pagination.After = "ffffffffffffffffffffffff"
 	}
-	filter := mongolib.Filter().
-		Equal("parent_id", mongolib.ObjectID(parentID)).
-		LessThan("_id", mongolib.ObjectID(pagination.After))
+	filter := append(mongolib.Filter().
+		Equal("parent_id", mongolib.ObjectID(parentID)),
+		visibleTo(userID)...)
 	if len(authorIDs) > 0 {
-		ids := make([]primitive.ObjectID, len(authorIDs))
-		for i, v := range authorIDs {
-			ids[i] = mongolib.ObjectID(v)
+		filter = filter.In("author_id", objectIDs(authorIDs))
+	}
+	if len(excludedAuthorIDs) > 0 {
+		filter = filter.NotIn("author_id", objectIDs(excludedAuthorIDs))
+	}
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.D{{Key: "$and", Value: filter}}}}}
+	if len(excludedAuthorIDs) > 0 {
+		// the repost or quote of the excluded user post is excluded too, before the limit so the page is still full
+		pipeline = append(pipeline,
+			bson.D{{Key: "$lookup", Value: bson.D{
+				{Key: "from", Value: "post"},
+				{Key: "localField", Value: "quoted_post_id"},
+				{Key: "foreignField", Value: "_id"},
+				{Key: "as", Value: "quoted_post"},
+			}}},
+			bson.D{{Key: "$match", Value: bson.D{
+				{Key: "quoted_post.author_id", Value: bson.D{{Key: "$nin", Value: objectIDs(excludedAuthorIDs)}}},
+			}}},
+		)
+	}
+	// the same content shared by the original post and its reposts is only shown at its newest occurrence,
+	// it's grouped before the cursor so the older occurrence doesn't come back on the next page
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "$cond", Value: bson.A{
+				bson.D{{Key: "$eq", Value: bson.A{"$is_repost", true}}}, "$quoted_post_id", "$_id",
+			}}}},
+			{Key: "post_id", Value: bson.D{{Key: "$max", Value: "$_id"}}},
+		}}},
+		bson.D{{Key: "$match", Value: bson.D{{Key: "post_id", Value: bson.D{{Key: "$lt", Value: mongolib.ObjectID(pagination.After)}}}}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "post_id", Value: mongolib.Descending}}}},
+		bson.D{{Key: "$limit", Value: pagination.First}},
+	)
+	cur, err := m.post.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, nil, err
+	}
+	var page []struct {
+		PostID primitive.ObjectID `bson:"post_id"`
+	}
+	if err := cur.All(ctx, &page); err != nil {
+		return nil, nil, err
+	}
+	if len(page) == 0 {
+		return nil, &api.PaginationRes{}, nil
+	}
+	ids := make([]primitive.ObjectID, len(page))
+	for i, v := range page {
+		ids[i] = v.PostID
+	}
+
+	var model AggregatePostList
+	if err := m.aggregate(m.post.Aggregate().
+		Match(mongolib.Filter().In("_id", ids)).
+		Sort("_id", mongolib.Descending), userID).
+		Exec(ctx).Consume(&model);
+		err != nil {
+		return nil, nil, err
+	}
+
+	return model.Entity(userID), &api.PaginationRes{
+		EndCursor: ids[len(ids)-1].Hex(),
+		HasNextPage: len(ids) >= pagination.First,
+	}, nil
+}
+
+func (m *postModule) FindPostListByIDs(ctx context.Context, ids []string, userID string) ([]entity.Post, error) {
+	objectIDs := make([]primitive.ObjectID, len(ids))
+	for i, v := range ids {
+		objectIDs[i] = mongolib.ObjectID(v)
+	}
+	var model AggregatePostList
+	if err := m.aggregate(m.post.Aggregate().
+		Match(append(mongolib.Filter().
+			In("_id", objectIDs),
+			visibleTo(userID)...)), userID).
+		Exec(ctx).Consume(&model);
+		err != nil {
+		return nil, err
+	}
+
+	// keep the order of the requested ids, mongo doesn't guarantee it for $in
+	mapPost := make(map[string]entity.Post)
+	for _, v := range model.Entity(userID) {
+		mapPost[v.ID] = v
+	}
+	var posts []entity.Post
+	for _, v := range ids {
+		if post, ok := mapPost[v]; ok {
+			posts = append(posts, post)
 		}
-		filter = filter.In("author_id", ids)
 	}
-	if err := m.post.Aggregate().
-		Match(filter).
+	return posts, nil
+}
+
+func (m *postModule) FindPostListByIDsIncludeHidden(ctx context.Context, ids []string) ([]entity.Post, error) {
+	var model AggregatePostList
+	if err := m.aggregate(m.post.Aggregate().
+		Match(mongolib.Filter().
+			In("_id", objectIDs(ids))), "").
+		Exec(ctx).Consume(&model);
+		err != nil {
+		return nil, err
+	}
+	return model.Entity(""), nil
+}
+
+func (m *postModule) FindPostListCreatedAfter(ctx context.Context, timestamp int64) ([]entity.Post, error) {
+	var model AggregatePostList
+	if err := m.aggregate(m.post.Aggregate().
+		Match(append(mongolib.Filter().
+			Equal("parent_id", primitive.NilObjectID).
+			NotEqual("is_repost", true).
+			GreaterThanEqual("_id", primitive.NewObjectIDFromTimestamp(time.Unix(timestamp, 0))),
+			visibleTo("")...)), "").
+		Exec(ctx).Consume(&model);
+		err != nil {
+		return nil, err
+	}
+	return model.Entity(""), nil
+}
+
+func (m *postModule) FindRepost(ctx context.Context, postID string, userID string) (*entity.Post, error) {
+	var model Post
+	if err := m.post.Query().
+		Equal("quoted_post_id", mongolib.ObjectID(postID)).
+		Equal("user_id", mongolib.ObjectID(userID)).
+		Equal("is_repost", true).
+		FindOne(ctx).Consume(&model);
+		err != nil {
+		if err == mongolib.ErrNotFound {
+			return nil, constants.ErrPostNotFound
+		}
+		return nil, err
+	}
+	return &entity.Post{
+		ID:         model.ID.Hex(),
+		IsRepost:   model.IsRepost,
+		QuotedPost: &entity.Post{ID: model.QuotedPostID.Hex()},
+		Author:     entity.User{ID: model.AuthorID.Hex()},
+		User:       entity.User{ID: model.User.Hex()},
+	}, nil
+}
+
+func (m *postModule) FindRecentPostListByUserID(ctx context.Context, userID string, timestamp int64, limit int) ([]entity.Post, error) {
+	var model []Post
+	if err := m.post.Query().
+		Equal("user_id", mongolib.ObjectID(userID)).
+		NotEqual("is_repost", true).
+		GreaterThanEqual("_id", primitive.NewObjectIDFromTimestamp(time.Unix(timestamp, 0))).
 		Sort("_id", mongolib.Descending).
-		Limit(pagination.First).
-		Lookup("user", "author_id", "_id", "author").
-		Unwind("$author").
-		Lookup("user", "user_id", "_id", "user").
-		Unwind("$user").
-		Lookup("post", "_id", "parent_id", "replies").
-		AddField("replies_count", bson.D{{"$size", "$replies"}}).
+		Limit(limit).
+		Find(ctx).Consume(&model); err != nil {
+		return nil, err
+	}
+	posts := make([]entity.Post, len(model))
+	for i, v := range model {
+		posts[i] = entity.Post{
+			ID:   v.ID.Hex(),
+			Body: v.Body,
+		}
+	}
+	return posts, nil
+}
+
+func (m *postModule) CountPostByAuthorID(ctx context.Context, authorID string, userID string) (int, error) {
+	filter := append(bson.A{
+		bson.D{{Key: "author_id", Value: mongolib.ObjectID(authorID)}},
+	}, visibleTo(userID)...)
+	count, err := m.post.CountDocuments(ctx, bson.D{{Key: "$and", Value: filter}})
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (m *postModule) FindScheduledPostByID(ctx context.Context, id string) (*entity.Post, error) {
+	var model AggregatePostList
+	if err := m.aggregate(m.post.Aggregate().
+		Match(mongolib.Filter().
+			Equal("_id", mongolib.ObjectID(id)).
+			Equal("scheduled", true)), "").
+		Exec(ctx).Consume(&model);
+		err != nil {
+		return nil, err
+	}
+	if len(model) < 1 {
+		return nil, constants.ErrPostNotFound
+	}
+	return model[0].Entity(""), nil
+}
+
+func (m *postModule) FindScheduledPostListByUserID(ctx context.Context, userID string, pagination api.PaginationReq) ([]entity.Post, *api.PaginationRes, error) {
+	var model AggregatePostList
+	if err := m.aggregate(m.post.Aggregate().
+		Match(mongolib.Filter().
+			Equal("user_id", mongolib.ObjectID(userID)).
+			Equal("scheduled", true).
+			GreaterThan("_id", mongolib.ObjectID(pagination.After))).
+		Sort("_id", mongolib.Ascending).
+		Limit(pagination.First), userID).
 		Exec(ctx).Consume(&model);
 		err != nil {
 		return nil, nil, err
@@ -74,70 +257,536 @@ func (m *postModule) FindPostListByParentIDAndAuthorIDs(ctx context.Context, par
 		endCursor = model[len(model)-1].ID.Hex()
 	}
 	return model.Entity(userID), &api.PaginationRes{
-		EndCursor: endCursor,
+		EndCursor:   endCursor,
 		HasNextPage: len(model) >= pagination.First,
 	}, nil
 }
 
+func (m *postModule) FindDueScheduledPostList(ctx context.Context, timestamp int64) ([]entity.Post, error) {
+	var model AggregatePostList
+	if err := m.aggregate(m.post.Aggregate().
+		Match(mongolib.Filter().
+			Equal("scheduled", true).
+			LessThanEqual("_id", newObjectIDAt(timestamp))), "").
+		Exec(ctx).Consume(&model);
+		err != nil {
+		return nil, err
+	}
+	return model.Entity(""), nil
+}
+
+func (m *postModule) PublishPost(ctx context.Context, id string) (bool, error) {
+	// only the scheduled post match, so the post already published by other worker or cancelled isn't published twice
+	res, err := m.post.UpdateOne(ctx,
+		bson.D{
+			{Key: "_id", Value: mongolib.ObjectID(id)},
+			{Key: "scheduled", Value: true},
+		},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "scheduled", Value: false}}}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
 func (m *postModule) InsertPost(ctx context.Context, post entity.Post) (string, error) {
 	id := mongolib.NewObjectID()
-	if err := m.post.Save(ctx, id, Post{
+	// scheduled post take the publish time as its id timestamp, so it is ordered in the feed by publish time
+	if post.IsScheduled {
+		id = newObjectIDAt(post.Timestamp)
+	}
+	model := Post{
 		ID:           id,
 		Body:         post.Body,
 		Likes:        map[string]bool{},
 		ParentID:     mongolib.ObjectID(post.Parent.ID),
 		AuthorID:     mongolib.ObjectID(post.Author.ID),
 		User:         mongolib.ObjectID(post.User.ID),
-	}); err != nil {
+		IsRepost:     post.IsRepost,
+		Attachments:  NewAttachments(post.Attachments),
+		Scheduled:    post.IsScheduled,
+		ExpiredAt:    post.ExpiredAt,
+		ContentWarnings: post.ContentWarnings,
+		MentionIDs:   objectIDs(post.MentionIDs),
+		Links:        post.Links,
+		LinkPreviewPending: len(post.Links) > 0,
+		Shadowbanned: post.IsShadowbanned,
+		Hidden:       post.IsHidden,
+	}
+	if post.QuotedPost != nil {
+		model.QuotedPostID = mongolib.ObjectID(post.QuotedPost.ID)
+	}
+	if post.Poll != nil {
+		model.Poll = NewPoll(*post.Poll)
+	}
+	if err := m.post.Save(ctx, id, model); err != nil {
 		return "", err
 	}
 
 	return id.Hex(), nil
 }
 
-func (m *postModule) LikePost(ctx context.Context, postID string, userID string) error {
-	var model Post
+func (m *postModule) UpdateContentWarnings(ctx context.Context, id string, warnings []string) error {
+	if warnings == nil {
+		warnings = []string{}
+	}
 	if err := m.post.Query().
-		Equal("_id", mongolib.ObjectID(postID)).
-		FindOne(ctx).Consume(&model);
-	err != nil {
+		Equal("_id", mongolib.ObjectID(id)).
+		Set("content_warnings", warnings).
+		Update(ctx); err != nil {
 		return err
 	}
+	return nil
+}
 
-	model.Likes[userID] = true
+func (m *postModule) FindPendingLinkPreviewPostList(ctx context.Context, now int64, limit int) ([]entity.Post, error) {
+	var model []Post
+	if err := m.post.Query().
+		Equal("link_preview_pending", true).
+		LessThanEqual("link_preview_next_attempt_at", now).
+		Sort("link_preview_next_attempt_at", mongolib.Ascending).
+		Sort("_id", mongolib.Ascending).
+		Limit(limit).
+		Find(ctx).Consume(&model); err != nil {
+		return nil, err
+	}
+	posts := make([]entity.Post, len(model))
+	for i, v := range model {
+		posts[i] = entity.Post{
+			ID:                  v.ID.Hex(),
+			Links:               v.Links,
+			LinkPreviewAttempts: v.LinkPreviewAttempts,
+		}
+	}
+	return posts, nil
+}
 
-	if err := m.post.Save(ctx, mongolib.ObjectID(postID), model); err != nil {
+func (m *postModule) DelayLinkPreview(ctx context.Context, id string, nextAttemptAt int64) error {
+	if err := m.post.Query().
+		Equal("_id", mongolib.ObjectID(id)).
+		Inc("link_preview_attempts", 1).
+		Set("link_preview_next_attempt_at", nextAttemptAt).
+		Update(ctx); err != nil {
 		return err
 	}
+	return nil
+}
 
+func (m *postModule) SaveLinkPreviews(ctx context.Context, id string, previews []entity.LinkPreview) error {
+	if err := m.post.Query().
+		Equal("_id", mongolib.ObjectID(id)).
+		Set("link_previews", NewLinkPreviews(previews)).
+		Set("link_preview_pending", false).
+		Update(ctx); err != nil {
+		return err
+	}
 	return nil
 }
 
-func (m *postModule) UnlikePost(ctx context.Context, postID string, userID string) error {
-	var model Post
+func (m *postModule) DeletePost(ctx context.Context, id string) error {
+	if err := m.post.Query().Equal("_id", mongolib.ObjectID(id)).Delete(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *postModule) DeleteScheduledPost(ctx context.Context, id string) error {
+	// only the scheduled post match, the post published meanwhile by the worker is kept
+	res, err := m.post.DeleteOne(ctx, bson.D{
+		{Key: "_id", Value: mongolib.ObjectID(id)},
+		{Key: "scheduled", Value: true},
+	})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return constants.ErrPostNotFound
+	}
+	return nil
+}
+
+func (m *postModule) UpdateHiddenStatus(ctx context.Context, id string, hidden bool) error {
+	if err := m.post.Query().
+		Equal("_id", mongolib.ObjectID(id)).
+		Set("hidden", hidden).
+		Update(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *postModule) DeletePostWithReplies(ctx context.Context, id string) error {
+	return m.deletePostList(ctx, []primitive.ObjectID{mongolib.ObjectID(id)})
+}
+
+func (m *postModule) DeleteExpiredPost(ctx context.Context, timestamp int64) error {
+	var model []Post
+	if err := m.post.Query().
+		GreaterThan("expired_at", 0).
+		LessThanEqual("expired_at", timestamp).
+		Find(ctx).Consume(&model); err != nil {
+		return err
+	}
+	if len(model) == 0 {
+		return nil
+	}
+
+	ids := make([]primitive.ObjectID, len(model))
+	for i, v := range model {
+		ids[i] = v.ID
+	}
+	return m.deletePostList(ctx, ids)
+}
+
+// deletePostList delete the posts, their replies and reposts go together, quote keep its own body
+func (m *postModule) deletePostList(ctx context.Context, ids []primitive.ObjectID) error {
+	if err := m.post.Query().In("parent_id", ids).Delete(ctx); err != nil {
+		return err
+	}
+	if err := m.post.Query().
+		In("quoted_post_id", ids).
+		Equal("is_repost", true).
+		Delete(ctx); err != nil {
+		return err
+	}
+	if err := m.post.Query().In("_id", ids).Delete(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *postModule) LikePost(ctx context.Context, postID string, userID string, shadowbanned bool) error {
+	field := "likes."
+	if shadowbanned {
+		field = "shadow_likes."
+	}
 	if err := m.post.Query().
 		Equal("_id", mongolib.ObjectID(postID)).
-		FindOne(ctx).Consume(&model);
-		err != nil {
+		Set(field+userID, true).
+		Update(ctx); err != nil {
 		return err
 	}
+	return nil
+}
 
-	delete(model.Likes, userID)
+func (m *postModule) UnlikePost(ctx context.Context, postID string, userID string) error {
+	filter := bson.D{{Key: "_id", Value: mongolib.ObjectID(postID)}}
+	update := bson.D{{Key: "$unset", Value: bson.D{
+		{Key: "likes." + userID, Value: ""},
+		{Key: "shadow_likes." + userID, Value: ""},
+	}}}
+	if _, err := m.post.UpdateOne(ctx, filter, update); err != nil {
+		return err
+	}
+	return nil
+}
 
-	if err := m.post.Save(ctx, mongolib.ObjectID(postID), model); err != nil {
+func (m *postModule) VotePoll(ctx context.Context, postID string, userID string, optionIDs []string) error {
+	// the null check keep the first vote when the same user vote concurrently,
+	// the losing vote match nothing
+	res, err := m.post.UpdateOne(ctx,
+		bson.D{
+			{Key: "_id", Value: mongolib.ObjectID(postID)},
+			{Key: "poll.votes." + userID, Value: nil},
+		},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "poll.votes." + userID, Value: optionIDs}}}},
+	)
+	if err != nil {
 		return err
 	}
+	if res.MatchedCount == 0 {
+		return constants.ErrAlreadyVoted
+	}
+	return nil
+}
 
+func (m *postModule) UpdateShadowbanStatus(ctx context.Context, userID string, shadowbanned bool) error {
+	if err := m.post.Query().
+		Equal("user_id", mongolib.ObjectID(userID)).
+		Set("shadowbanned", shadowbanned).
+		Update(ctx); err != nil {
+		return err
+	}
+
+	// move the user likes between likes and shadow_likes, so the likes count follow the status
+	from, to := "likes."+userID, "shadow_likes."+userID
+	if !shadowbanned {
+		from, to = to, from
+	}
+	if _, err := m.post.UpdateMany(ctx,
+		bson.D{{Key: from, Value: bson.D{{Key: "$exists", Value: true}}}},
+		bson.D{{Key: "$rename", Value: bson.D{{Key: from, Value: to}}}}); err != nil {
+		return err
+	}
 	return nil
 }
 
+// visible filter out post that isn't published yet, hidden by moderation or already expired but not swept yet
+func visible() bson.A {
+	return bson.A{
+		bson.D{{Key: "scheduled", Value: bson.D{{Key: "$ne", Value: true}}}},
+		bson.D{{Key: "hidden", Value: bson.D{{Key: "$ne", Value: true}}}},
+		bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "expired_at", Value: bson.D{{Key: "$exists", Value: false}}}},
+			bson.D{{Key: "expired_at", Value: 0}},
+			bson.D{{Key: "expired_at", Value: bson.D{{Key: "$gt", Value: time.Now().Unix()}}}},
+		}}},
+	}
+}
+
+// visibleTo is visible for the viewer, post of shadowbanned user is only shown to the user
+func visibleTo(userID string) bson.A {
+	return append(visible(), bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "shadowbanned", Value: bson.D{{Key: "$ne", Value: true}}}},
+		bson.D{{Key: "user_id", Value: mongolib.ObjectID(userID)}},
+	}}})
+}
+
+// isVisibleTo is visibleTo shadowban condition as aggregation expression, for the post in the looked up array
+func isVisibleTo(post string, userID string) bson.D {
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "$ne", Value: bson.A{post + ".shadowbanned", true}}},
+		bson.D{{Key: "$eq", Value: bson.A{post + ".user_id", mongolib.ObjectID(userID)}}},
+	}}}
+}
+
+// countVisibleTo count the looked up posts visible to the viewer
+func countVisibleTo(posts string, userID string) bson.D {
+	return bson.D{{Key: "$size", Value: bson.D{{Key: "$filter", Value: bson.D{
+		{Key: "input", Value: posts},
+		{Key: "cond", Value: isVisibleTo("$$this", userID)},
+	}}}}}
+}
+
+func objectIDs(ids []string) []primitive.ObjectID {
+	result := make([]primitive.ObjectID, len(ids))
+	for i, v := range ids {
+		result[i] = mongolib.ObjectID(v)
+	}
+	return result
+}
+
+func hexes(ids []primitive.ObjectID) []string {
+	result := make([]string, len(ids))
+	for i, v := range ids {
+		result[i] = v.Hex()
+	}
+	return result
+}
+
+// newObjectIDAt generate unique object id with the given unix timestamp
+func newObjectIDAt(timestamp int64) primitive.ObjectID {
+	id := primitive.NewObjectID()
+	binary.BigEndian.PutUint32(id[0:4], uint32(timestamp))
+	return id
+}
+
+// aggregate resolve the post relation, the replies count only count the reply visible to the viewer
+func (m *postModule) aggregate(a mongolib.Aggregate, userID string) mongolib.Aggregate {
+	return a.
+		Lookup("user", "author_id", "_id", "author").
+		Unwind("$author").
+		Lookup("user", "user_id", "_id", "user").
+		Unwind("$user").
+		Lookup("post", "_id", "parent_id", "replies").
+		AddField("replies_count", countVisibleTo("$replies", userID)).
+		Lookup("bookmark", "_id", "post_id", "bookmarks").
+		Lookup("post", "quoted_post_id", "_id", "quoted_post").
+		Lookup("user", "quoted_post.author_id", "_id", "quoted_post_author").
+		Lookup("post", "quoted_post_id", "parent_id", "quoted_post_replies").
+		AddField("quoted_post_replies_count", countVisibleTo("$quoted_post_replies", userID))
+}
+
 type Post struct {
 	ID           primitive.ObjectID `bson:"_id"`
 	Body         string             `bson:"body"`
 	Likes        map[string]bool    `bson:"likes"`
+	// ShadowLikes is the likes of shadowbanned user, only counted for the user
+	ShadowLikes  map[string]bool    `bson:"shadow_likes,omitempty"`
 	ParentID     primitive.ObjectID `bson:"parent_id"`
 	AuthorID     primitive.ObjectID `bson:"author_id"`
 	User         primitive.ObjectID `bson:"user_id"`
+	QuotedPostID primitive.ObjectID `bson:"quoted_post_id"`
+	IsRepost     bool               `bson:"is_repost"`
+	Poll         *Poll              `bson:"poll,omitempty"`
+	Attachments  []Attachment       `bson:"attachments"`
+	Scheduled    bool               `bson:"scheduled"`
+	ExpiredAt    int64              `bson:"expired_at"`
+	ContentWarnings []string        `bson:"content_warnings"`
+	MentionIDs   []primitive.ObjectID `bson:"mention_ids"`
+	Links        []string           `bson:"links"`
+	LinkPreviews []LinkPreview      `bson:"link_previews"`
+	// LinkPreviewPending mark the links preview isn't fetched yet
+	LinkPreviewPending bool         `bson:"link_preview_pending"`
+	// LinkPreviewAttempts and LinkPreviewNextAttemptAt back off the post whose lookup failed
+	LinkPreviewAttempts int         `bson:"link_preview_attempts"`
+	LinkPreviewNextAttemptAt int64  `bson:"link_preview_next_attempt_at"`
+	// Hidden is set on insert when the post is flagged by the content filter, then by UpdateHiddenStatus
+	Hidden       bool               `bson:"hidden,omitempty"`
+	// Shadowbanned is set on insert and by UpdateShadowbanStatus
+	Shadowbanned bool               `bson:"shadowbanned,omitempty"`
+}
+
+// Attachment is a copy of the attachment document at the time the post created
+type Attachment struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	URL         string             `bson:"url"`
+	ContentType string             `bson:"content_type"`
+	Size        int                `bson:"size"`
+	Width       int                `bson:"width"`
+	Height      int                `bson:"height"`
+	BlurHash    string             `bson:"blur_hash"`
+	Variants    []ImageVariant     `bson:"variants"`
+}
+
+type ImageVariant struct {
+	Name        string `bson:"name"`
+	URL         string `bson:"url"`
+	ContentType string `bson:"content_type"`
+	Width       int    `bson:"width"`
+	Height      int    `bson:"height"`
+}
+
+func NewAttachments(attachments []entity.Attachment) []Attachment {
+	result := make([]Attachment, len(attachments))
+	for i, v := range attachments {
+		result[i] = Attachment{
+			ID:          mongolib.ObjectID(v.ID),
+			URL:         v.URL,
+			ContentType: v.ContentType,
+			Size:        v.Size,
+			Width:       v.Width,
+			Height:      v.Height,
+			BlurHash:    v.BlurHash,
+			Variants:    make([]ImageVariant, len(v.Variants)),
+		}
+		for j, variant := range v.Variants {
+			result[i].Variants[j] = ImageVariant{
+				Name:        variant.Name,
+				URL:         variant.URL,
+				ContentType: variant.ContentType,
+				Width:       variant.Width,
+				Height:      variant.Height,
+			}
+		}
+	}
+	return result
+}
+
+func attachmentEntities(attachments []Attachment) []entity.Attachment {
+	result := make([]entity.Attachment, len(attachments))
+	for i, v := range attachments {
+		variants := make([]entity.ImageVariant, len(v.Variants))
+		for j, variant := range v.Variants {
+			variants[j] = entity.ImageVariant{
+				Name:        variant.Name,
+				URL:         variant.URL,
+				ContentType: variant.ContentType,
+				Width:       variant.Width,
+				Height:      variant.Height,
+			}
+		}
+		result[i] = entity.Attachment{
+			ID:          v.ID.Hex(),
+			URL:         v.URL,
+			ContentType: v.ContentType,
+			Size:        v.Size,
+			Width:       v.Width,
+			Height:      v.Height,
+			BlurHash:    v.BlurHash,
+			Variants:    variants,
+		}
+	}
+	return result
+}
+
+type LinkPreview struct {
+	URL         string `bson:"url"`
+	Title       string `bson:"title"`
+	Description string `bson:"description"`
+	ImageURL    string `bson:"image_url"`
+	SiteName    string `bson:"site_name"`
+}
+
+func NewLinkPreviews(previews []entity.LinkPreview) []LinkPreview {
+	result := make([]LinkPreview, len(previews))
+	for i, v := range previews {
+		result[i] = LinkPreview{
+			URL:         v.URL,
+			Title:       v.Title,
+			Description: v.Description,
+			ImageURL:    v.ImageURL,
+			SiteName:    v.SiteName,
+		}
+	}
+	return result
+}
+
+func linkPreviewEntities(previews []LinkPreview) []entity.LinkPreview {
+	result := make([]entity.LinkPreview, len(previews))
+	for i, v := range previews {
+		result[i] = entity.LinkPreview{
+			URL:         v.URL,
+			Title:       v.Title,
+			Description: v.Description,
+			ImageURL:    v.ImageURL,
+			SiteName:    v.SiteName,
+		}
+	}
+	return result
+}
+
+type Poll struct {
+	Options        []PollOption        `bson:"options"`
+	MultipleChoice bool                `bson:"multiple_choice"`
+	ExpiredAt      int64               `bson:"expired_at"`
+	Votes          map[string][]string `bson:"votes"`
+}
+
+type PollOption struct {
+	ID   string `bson:"id"`
+	Text string `bson:"text"`
+}
+
+func NewPoll(poll entity.Poll) *Poll {
+	options := make([]PollOption, len(poll.Options))
+	for i, v := range poll.Options {
+		options[i] = PollOption{
+			ID:   v.ID,
+			Text: v.Text,
+		}
+	}
+	return &Poll{
+		Options:        options,
+		MultipleChoice: poll.MultipleChoice,
+		ExpiredAt:      poll.ExpiredAt,
+		Votes:          map[string][]string{},
+	}
+}
+
+func (p Poll) Entity(userID string) *entity.Poll {
+	votesCount := make(map[string]int)
+	for _, optionIDs := range p.Votes {
+		for _, v := range optionIDs {
+			votesCount[v]++
+		}
+	}
+	options := make([]entity.PollOption, len(p.Options))
+	for i, v := range p.Options {
+		options[i] = entity.PollOption{
+			ID:         v.ID,
+			Text:       v.Text,
+			VotesCount: votesCount[v.ID],
+		}
+	}
+	return &entity.Poll{
+		Options:        options,
+		MultipleChoice: p.MultipleChoice,
+		ExpiredAt:      p.ExpiredAt,
+		VotersCount:    len(p.Votes),
+		MyVotes:        p.Votes[userID],
+	}
 }
 
 type AggregatePost struct {
@@ -145,34 +794,78 @@ type AggregatePost struct {
 	Body         string             `bson:"body"`
 	RepliesCount int                `bson:"replies_count"`
 	Likes        map[string]bool    `bson:"likes"`
+	ShadowLikes  map[string]bool    `bson:"shadow_likes"`
+	Bookmarks    []Bookmark         `bson:"bookmarks"`
 	ParentID     primitive.ObjectID `bson:"parent_id"`
 	Author       User               `bson:"author"`
 	User         User               `bson:"user"`
+	IsRepost     bool               `bson:"is_repost"`
+	QuotedPostID primitive.ObjectID `bson:"quoted_post_id"`
+	Poll         *Poll              `bson:"poll"`
+	Attachments  []Attachment       `bson:"attachments"`
+	Scheduled    bool               `bson:"scheduled"`
+	ExpiredAt    int64              `bson:"expired_at"`
+	ContentWarnings []string        `bson:"content_warnings"`
+	MentionIDs   []primitive.ObjectID `bson:"mention_ids"`
+	LinkPreviews []LinkPreview      `bson:"link_previews"`
+	Hidden       bool               `bson:"hidden"`
+	Shadowbanned bool               `bson:"shadowbanned"`
+
+	// quoted post resolved by lookup, empty if the post doesn't quote anything or the quoted post deleted
+	QuotedPost             []Post `bson:"quoted_post"`
+	QuotedPostAuthor       []User `bson:"quoted_post_author"`
+	QuotedPostRepliesCount int    `bson:"quoted_post_replies_count"`
+}
+
+type Bookmark struct {
+	UserID primitive.ObjectID `bson:"user_id"`
 }
 
 type User struct {
 	ID     primitive.ObjectID `bson:"_id"`
 	Name   string             `bson:"name"`
+	Handle string             `bson:"handle"`
 	Avatar string             `bson:"avatar"`
 }
 
 func (p AggregatePost) Entity(userID string) *entity.Post {
-	isLiked := false
-	if _, ok := p.Likes[userID]; ok {
-		isLiked = true
+	likesCount, isLiked := countLikes(p.Likes, p.ShadowLikes, userID)
+	isBookmarked := false
+	for _, v := range p.Bookmarks {
+		if v.UserID.Hex() == userID {
+			isBookmarked = true
+			break
+		}
+	}
+	var poll *entity.Poll
+	if p.Poll != nil {
+		poll = p.Poll.Entity(userID)
 	}
 	return &entity.Post{
 		ID:           p.ID.Hex(),
 		Body:         p.Body,
+		IsRepost:     p.IsRepost,
+		QuotedPost:   p.quotedPost(userID),
+		Poll:         poll,
+		Attachments:  attachmentEntities(p.Attachments),
+		IsScheduled:  p.Scheduled,
+		IsHidden:     p.Hidden,
+		IsShadowbanned: p.Shadowbanned,
+		ExpiredAt:    p.ExpiredAt,
+		ContentWarnings: p.ContentWarnings,
+		MentionIDs:   hexes(p.MentionIDs),
+		LinkPreviews: linkPreviewEntities(p.LinkPreviews),
 		Timestamp:    p.ID.Timestamp().Unix(),
 		RepliesCount: p.RepliesCount,
-		LikesCount:   len(p.Likes),
+		LikesCount:   likesCount,
 		IsLiked:      isLiked,
+		IsBookmarked: isBookmarked,
 		Parent:       &entity.Post{ID: p.ParentID.Hex()},
 		Author:       entity.User{
 			ID:      p.Author.ID.Hex(),
 			Profile: entity.Profile{
 				Name:   p.Author.Name,
+				Handle: p.Author.Handle,
 				Avatar: p.Author.Avatar,
 			},
 		},
@@ -180,12 +873,66 @@ func (p AggregatePost) Entity(userID string) *entity.Post {
 			ID:      p.User.ID.Hex(),
 			Profile: entity.Profile{
 				Name:   p.User.Name,
+				Handle: p.User.Handle,
 				Avatar: p.User.Avatar,
 			},
 		},
 	}
 }
 
+func (p AggregatePost) quotedPost(userID string) *entity.Post {
+	if len(p.QuotedPost) < 1 {
+		return nil
+	}
+	quoted := p.QuotedPost[0]
+	if quoted.ExpiredAt != 0 && quoted.ExpiredAt <= time.Now().Unix() {
+		return nil
+	}
+	if quoted.Hidden {
+		return nil
+	}
+	if quoted.Shadowbanned && quoted.User.Hex() != userID {
+		return nil
+	}
+	likesCount, isLiked := countLikes(quoted.Likes, quoted.ShadowLikes, userID)
+	post := &entity.Post{
+		ID:           quoted.ID.Hex(),
+		Body:         quoted.Body,
+		Timestamp:    quoted.ID.Timestamp().Unix(),
+		RepliesCount: p.QuotedPostRepliesCount,
+		LikesCount:   likesCount,
+		IsLiked:      isLiked,
+		Attachments:  attachmentEntities(quoted.Attachments),
+		ContentWarnings: quoted.ContentWarnings,
+		LinkPreviews: linkPreviewEntities(quoted.LinkPreviews),
+		Parent:       &entity.Post{ID: quoted.ParentID.Hex()},
+		Author:       entity.User{ID: quoted.AuthorID.Hex()},
+		User:         entity.User{ID: quoted.User.Hex()},
+	}
+	if quoted.Poll != nil {
+		post.Poll = quoted.Poll.Entity(userID)
+	}
+	if len(p.QuotedPostAuthor) > 0 {
+		post.Author.Profile = entity.Profile{
+			Name:   p.QuotedPostAuthor[0].Name,
+			Handle: p.QuotedPostAuthor[0].Handle,
+			Avatar: p.QuotedPostAuthor[0].Avatar,
+		}
+	}
+	return post
+}
+
+// countLikes count the likes shown to the viewer, the viewer own shadow like is counted so the shadowban isn't noticed
+func countLikes(likes map[string]bool, shadowLikes map[string]bool, userID string) (int, bool) {
+	_, isLiked := likes[userID]
+	count := len(likes)
+	if _, ok := shadowLikes[userID]; ok {
+		isLiked = true
+		count++
+	}
+	return count, isLiked
+}
+
 type AggregatePostList []AggregatePost
 
 func (p AggregatePostList) Entity(userID string) []entity.Post {
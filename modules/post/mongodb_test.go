@@ -0,0 +1,196 @@
+package post
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/menfess-backend/service/api"
+	"github.com/aeramu/mongolib"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"strings"
+	"testing"
+)
+
+const testPostID = "5f8d0d55b54764421b7156c9"
+
+// newTestModule return the module on a mock deployment answering every command with the responses
+func newTestModule(mt *mtest.T, responses ...bson.D) *postModule {
+	mt.AddMockResponses(responses...)
+	return &postModule{post: &mongolib.Collection{Collection: mt.Coll}}
+}
+
+// sentUpdate return the update document of the first update statement sent
+func sentUpdate(mt *mtest.T) bson.Raw {
+	updates := mt.GetStartedEvent().Command.Lookup("updates").Array()
+	return updates.Index(0).Value().Document().Lookup("u").Document()
+}
+
+func Test_postModule_LikePost(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	tests := []struct {
+		name         string
+		shadowbanned bool
+		want         string
+	}{
+		{
+			name:         "like",
+			shadowbanned: false,
+			want:         `{"$set": {"likes.user-id": true}}`,
+		},
+		{
+			name:         "shadowbanned like",
+			shadowbanned: true,
+			want:         `{"$set": {"shadow_likes.user-id": true}}`,
+		},
+	}
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			m := newTestModule(mt, mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}))
+			err := m.LikePost(context.Background(), testPostID, "user-id", tt.shadowbanned)
+			assert.Nil(mt, err)
+			// only the like field is written, so poll votes and moderation fields set concurrently are kept
+			update := sentUpdate(mt)
+			assert.Equal(mt, tt.want, update.String())
+			_, err = update.LookupErr("$set", "poll")
+			assert.Error(mt, err)
+		})
+	}
+}
+
+func Test_postModule_UnlikePost(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("unset both like fields", func(mt *mtest.T) {
+		m := newTestModule(mt, mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}))
+		err := m.UnlikePost(context.Background(), testPostID, "user-id")
+		assert.Nil(mt, err)
+		// the empty shadow_likes map would be omitted by a whole document $set, so the like has to be unset
+		assert.Equal(mt, `{"$unset": {"likes.user-id": "","shadow_likes.user-id": ""}}`, sentUpdate(mt).String())
+	})
+}
+
+func Test_postModule_DeleteScheduledPost(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	tests := []struct {
+		name    string
+		deleted int
+		wantErr error
+	}{
+		{
+			name:    "still scheduled",
+			deleted: 1,
+			wantErr: nil,
+		},
+		{
+			name:    "already published",
+			deleted: 0,
+			wantErr: constants.ErrPostNotFound,
+		},
+	}
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			m := newTestModule(mt, mtest.CreateSuccessResponse(bson.E{Key: "n", Value: tt.deleted}))
+			err := m.DeleteScheduledPost(context.Background(), testPostID)
+			assert.Equal(mt, tt.wantErr, err)
+			deletes := mt.GetStartedEvent().Command.Lookup("deletes").Array()
+			filter := deletes.Index(0).Value().Document().Lookup("q").Document()
+			assert.Equal(mt, true, filter.Lookup("scheduled").Boolean())
+		})
+	}
+}
+
+func Test_postModule_FindPostListByParentIDAndAuthorIDs(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	var (
+		blocked = "5f8d0d55b54764421b7156ca"
+		viewer  = "5f8d0d55b54764421b7156cb"
+	)
+	// stageIndex return the index of the first stage containing the text, -1 if there's none
+	stageIndex := func(stages []bson.RawValue, text string) int {
+		for i, stage := range stages {
+			if strings.Contains(stage.Document().String(), text) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	mt.Run("exclude the quote of excluded author before the limit", func(mt *mtest.T) {
+		m := newTestModule(mt, mtest.CreateCursorResponse(0, "test.post", mtest.FirstBatch))
+		_, _, err := m.FindPostListByParentIDAndAuthorIDs(context.Background(), "", nil, []string{blocked}, viewer, api.PaginationReq{First: 10})
+		assert.Nil(mt, err)
+
+		stages, _ := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Values()
+		quoteExcluded := stageIndex(stages, `"quoted_post.author_id": {"$nin": [{"$oid":"`+blocked+`"}]}`)
+		assert.True(mt, quoteExcluded >= 0, "quoted author isn't filtered")
+		assert.True(mt, quoteExcluded < stageIndex(stages, `"$limit"`), "quoted author is filtered after the limit")
+	})
+
+	mt.Run("group the same content before the cursor", func(mt *mtest.T) {
+		newest := primitive.NewObjectID()
+		oldest := primitive.NewObjectID()
+		m := newTestModule(mt,
+			mtest.CreateCursorResponse(0, "test.post", mtest.FirstBatch,
+				bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "post_id", Value: newest}},
+				bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "post_id", Value: oldest}},
+			),
+			mtest.CreateCursorResponse(0, "test.post", mtest.FirstBatch),
+		)
+		_, pagination, err := m.FindPostListByParentIDAndAuthorIDs(context.Background(), "", nil, nil, viewer, api.PaginationReq{First: 2, After: testPostID})
+		assert.Nil(mt, err)
+		// the cursor is the last raw post id of the page
+		assert.Equal(mt, oldest.Hex(), pagination.EndCursor)
+		assert.True(mt, pagination.HasNextPage)
+
+		stages, _ := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Values()
+		grouped := stageIndex(stages, `"$group"`)
+		cursor := stageIndex(stages, `{"$oid":"`+testPostID+`"}`)
+		assert.True(mt, grouped >= 0, "content isn't grouped")
+		assert.True(mt, grouped < cursor, "content is grouped after the cursor")
+		assert.True(mt, cursor < stageIndex(stages, `"$limit"`), "cursor is applied after the limit")
+	})
+}
+
+func Test_postModule_FindPendingLinkPreviewPostList(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("skip the delayed post", func(mt *mtest.T) {
+		m := newTestModule(mt, mtest.CreateCursorResponse(0, "db.post", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: mongolib.ObjectID(testPostID)},
+			{Key: "links", Value: bson.A{"https://a.com"}},
+			{Key: "link_preview_attempts", Value: 2},
+		}))
+		got, err := m.FindPendingLinkPreviewPostList(context.Background(), 1000000, 10)
+		assert.Nil(mt, err)
+		assert.Equal(mt, 2, got[0].LinkPreviewAttempts)
+		// the post whose lookup keeps failing is pushed back instead of blocking the batch
+		command := mt.GetStartedEvent().Command
+		assert.Equal(mt, `{"$and": [{"link_preview_pending": true},{"link_preview_next_attempt_at": {"$lte": {"$numberLong":"1000000"}}}]}`,
+			command.Lookup("filter").Document().String())
+		assert.Equal(mt, `{"link_preview_next_attempt_at": {"$numberInt":"1"},"_id": {"$numberInt":"1"}}`,
+			command.Lookup("sort").Document().String())
+	})
+}
+
+func Test_postModule_DelayLinkPreview(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("count the attempt", func(mt *mtest.T) {
+		m := newTestModule(mt, mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}))
+		err := m.DelayLinkPreview(context.Background(), testPostID, 1000060)
+		assert.Nil(mt, err)
+		assert.Equal(mt, `{"$inc": {"link_preview_attempts": {"$numberInt":"1"}},"$set": {"link_preview_next_attempt_at": {"$numberLong":"1000060"}}}`,
+			sentUpdate(mt).String())
+	})
+}
@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/service"
+	"github.com/aeramu/mongolib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"strconv"
+	"time"
+)
+
+func NewRateLimitModule(db *mongolib.Database) service.RateLimitModule {
+	return &rateLimitModule{rateLimit: db.Coll("rate_limit")}
+}
+
+// EnsureIndex create the TTL index removing the counter after its window ends
+func EnsureIndex(ctx context.Context, db *mongolib.Database) error {
+	if _, err := db.Coll("rate_limit").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expired_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// rateLimitModule count in fixed window, each window of a key is its own document,
+// so the count is a single atomic increment shared by every server instance
+type rateLimitModule struct {
+	rateLimit *mongolib.Collection
+}
+
+func (m *rateLimitModule) Hit(ctx context.Context, key string, window int64) (int, int64, error) {
+	now := time.Now().Unix()
+	start := now - now%window
+	resetAt := start + window
+
+	var model RateLimit
+	if err := m.rateLimit.FindOneAndUpdate(ctx,
+		bson.D{{Key: "_id", Value: key + ":" + strconv.FormatInt(start, 10)}},
+		bson.D{
+			{Key: "$inc", Value: bson.D{{Key: "count", Value: 1}}},
+			{Key: "$setOnInsert", Value: bson.D{{Key: "expired_at", Value: time.Unix(resetAt, 0)}}},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&model); err != nil {
+		return 0, 0, err
+	}
+	return model.Count, resetAt, nil
+}
+
+type RateLimit struct {
+	ID    string `bson:"_id"`
+	Count int    `bson:"count"`
+	// ExpiredAt is a date, the TTL index doesn't work on unix timestamp
+	ExpiredAt time.Time `bson:"expired_at"`
+}
@@ -0,0 +1,115 @@
+package redaction
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/aeramu/menfess-backend/service"
+	"github.com/aeramu/menfess-backend/utils"
+	"sort"
+	"strings"
+)
+
+// Rule pair the detector with what is done to the personal data it found
+type Rule struct {
+	Detector utils.PersonalDataDetector
+	// Action is constants.RedactionActionMask or constants.RedactionActionConfirm
+	Action string
+}
+
+// DefaultRules mask the data that is certainly personal, the guessed full name is only confirmed.
+// NIK is left to the content filter, which reject the post.
+func DefaultRules() []Rule {
+	return []Rule{
+		{Detector: utils.PhoneNumberDetector{}, Action: constants.RedactionActionMask},
+		{Detector: utils.StudentIDDetector{}, Action: constants.RedactionActionMask},
+		{Detector: utils.InstagramHandleDetector{}, Action: constants.RedactionActionMask},
+		{Detector: utils.FullNameDetector{}, Action: constants.RedactionActionConfirm},
+	}
+}
+
+func NewRedactionModule(rules []Rule) service.RedactionModule {
+	return &redactionModule{rules: rules}
+}
+
+type redactionModule struct {
+	rules []Rule
+}
+
+type found struct {
+	start, end int
+	data       entity.PersonalData
+}
+
+func (m *redactionModule) Redact(ctx context.Context, text string) (*entity.Redaction, error) {
+	var masked, unconfirmed []found
+	for _, rule := range m.rules {
+		for _, v := range rule.Detector.Detect(text) {
+			f := found{start: v[0], end: v[1], data: entity.PersonalData{
+				Type: rule.Detector.Type(),
+				Text: text[v[0]:v[1]],
+			}}
+			if rule.Action == constants.RedactionActionMask {
+				masked = append(masked, f)
+			} else {
+				unconfirmed = append(unconfirmed, f)
+			}
+		}
+	}
+	masked = mergeOverlap(text, masked)
+
+	result := &entity.Redaction{Text: mask(text, masked)}
+	for _, v := range masked {
+		result.Masked = append(result.Masked, v.data)
+	}
+	// the data already masked doesn't need confirmation
+	for _, v := range unconfirmed {
+		if !overlaps(v, masked) {
+			result.Unconfirmed = append(result.Unconfirmed, v.data)
+		}
+	}
+	return result, nil
+}
+
+// mergeOverlap sort the found data by position and join the overlapping one,
+// the joined data keep the type of the first
+func mergeOverlap(text string, list []found) []found {
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].start < list[j].start
+	})
+	var result []found
+	for _, v := range list {
+		last := len(result) - 1
+		if last >= 0 && v.start < result[last].end {
+			if v.end > result[last].end {
+				result[last].end = v.end
+				result[last].data.Text = text[result[last].start:v.end]
+			}
+			continue
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+// mask replace the sorted and non overlapping data with constants.RedactionMask
+func mask(text string, list []found) string {
+	var b strings.Builder
+	last := 0
+	for _, v := range list {
+		b.WriteString(text[last:v.start])
+		b.WriteString(constants.RedactionMask)
+		last = v.end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func overlaps(f found, list []found) bool {
+	for _, v := range list {
+		if f.start < v.end && v.start < f.end {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package redaction
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// substringDetector find every given substring, so the test control exactly where the data is
+type substringDetector struct {
+	dataType   string
+	substrings []string
+}
+
+func (d substringDetector) Type() string {
+	return d.dataType
+}
+
+func (d substringDetector) Detect(text string) [][2]int {
+	var result [][2]int
+	for _, v := range d.substrings {
+		if i := strings.Index(text, v); i >= 0 {
+			result = append(result, [2]int{i, i + len(v)})
+		}
+	}
+	return result
+}
+
+func TestRedactionModule_Redact(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []Rule
+		text  string
+		want  *entity.Redaction
+	}{
+		{
+			name: "nothing found",
+			rules: []Rule{
+				{Detector: substringDetector{constants.PersonalDataPhoneNumber, []string{"0812"}}, Action: constants.RedactionActionMask},
+			},
+			text: "halo semua",
+			want: &entity.Redaction{Text: "halo semua"},
+		},
+		{
+			name: "two spans masked in one text",
+			rules: []Rule{
+				{Detector: substringDetector{constants.PersonalDataPhoneNumber, []string{"081234567890"}}, Action: constants.RedactionActionMask},
+				{Detector: substringDetector{constants.PersonalDataStudentID, []string{"13519001"}}, Action: constants.RedactionActionMask},
+			},
+			text: "nim 13519001 wa 081234567890 ya",
+			want: &entity.Redaction{
+				Text: "nim [redacted] wa [redacted] ya",
+				Masked: []entity.PersonalData{
+					{Type: constants.PersonalDataStudentID, Text: "13519001"},
+					{Type: constants.PersonalDataPhoneNumber, Text: "081234567890"},
+				},
+			},
+		},
+		{
+			name: "overlapping hits merged keeping the first type",
+			rules: []Rule{
+				{Detector: substringDetector{constants.PersonalDataStudentID, []string{"12345678"}}, Action: constants.RedactionActionMask},
+				{Detector: substringDetector{constants.PersonalDataPhoneNumber, []string{"0812345678901"}}, Action: constants.RedactionActionMask},
+			},
+			text: "nomor 0812345678901 itu",
+			want: &entity.Redaction{
+				Text: "nomor [redacted] itu",
+				Masked: []entity.PersonalData{
+					{Type: constants.PersonalDataPhoneNumber, Text: "0812345678901"},
+				},
+			},
+		},
+		{
+			name: "overlapping hits extend the merged span",
+			rules: []Rule{
+				{Detector: substringDetector{constants.PersonalDataInstagramHandle, []string{"budi.99"}}, Action: constants.RedactionActionMask},
+				{Detector: substringDetector{constants.PersonalDataStudentID, []string{"99 1234"}}, Action: constants.RedactionActionMask},
+			},
+			text: "ig budi.99 1234 dong",
+			want: &entity.Redaction{
+				Text: "ig [redacted] dong",
+				Masked: []entity.PersonalData{
+					{Type: constants.PersonalDataInstagramHandle, Text: "budi.99 1234"},
+				},
+			},
+		},
+		{
+			name: "full name inside masked range isn't confirmed",
+			rules: []Rule{
+				{Detector: substringDetector{constants.PersonalDataInstagramHandle, []string{"Budi.Santoso"}}, Action: constants.RedactionActionMask},
+				{Detector: substringDetector{constants.PersonalDataFullName, []string{"Budi", "Siti Aminah"}}, Action: constants.RedactionActionConfirm},
+			},
+			text: "ig Budi.Santoso titip salam ke Siti Aminah",
+			want: &entity.Redaction{
+				Text: "ig [redacted] titip salam ke Siti Aminah",
+				Masked: []entity.PersonalData{
+					{Type: constants.PersonalDataInstagramHandle, Text: "Budi.Santoso"},
+				},
+				Unconfirmed: []entity.PersonalData{
+					{Type: constants.PersonalDataFullName, Text: "Siti Aminah"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRedactionModule(tt.rules).Redact(context.Background(), tt.text)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRedactionModule_Redact_defaultRules(t *testing.T) {
+	got, err := NewRedactionModule(DefaultRules()).Redact(context.Background(),
+		"buat Budi Santoso, wa 081234567890 atau ig @budi.santoso")
+	assert.Nil(t, err)
+	assert.Equal(t, "buat Budi Santoso, wa [redacted] atau ig @[redacted]", got.Text)
+	assert.Equal(t, []entity.PersonalData{
+		{Type: constants.PersonalDataPhoneNumber, Text: "081234567890"},
+		{Type: constants.PersonalDataInstagramHandle, Text: "budi.santoso"},
+	}, got.Masked)
+	assert.Equal(t, []entity.PersonalData{
+		{Type: constants.PersonalDataFullName, Text: "Budi Santoso"},
+	}, got.Unconfirmed)
+}
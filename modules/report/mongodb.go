@@ -0,0 +1,234 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/aeramu/menfess-backend/service"
+	"github.com/aeramu/menfess-backend/service/api"
+	"github.com/aeramu/mongolib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+func NewReportModule(db *mongolib.Database) service.ReportModule {
+	return &reportModule{report: db.Coll("report")}
+}
+
+// EnsureIndex create the index keeping a single pending report of a user on each post,
+// the user can report the post again after the previous report resolved,
+// and the index to list the pending report oldest first
+func EnsureIndex(ctx context.Context, db *mongolib.Database) error {
+	if _, err := db.Coll("report").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "post_id", Value: 1}, {Key: "reporter_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.D{{Key: "status", Value: constants.ReportStatusPending}}),
+		},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "_id", Value: 1}}},
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+type reportModule struct {
+	report *mongolib.Collection
+}
+
+func (m *reportModule) InsertReport(ctx context.Context, report entity.Report) (string, error) {
+	id := mongolib.NewObjectID()
+	model := Report{
+		ID:             id,
+		PostID:         mongolib.ObjectID(report.PostID),
+		ReportedUserID: mongolib.ObjectID(report.ReportedUserID),
+		ReporterID:     mongolib.ObjectID(report.ReporterID),
+		Reason:         report.Reason,
+		Details:        report.Details,
+		Status:         constants.ReportStatusPending,
+		CreatedAt:      time.Now().Unix(),
+	}
+	if err := m.report.Save(ctx, id, model); err != nil {
+		if isDuplicateKeyError(err) {
+			return "", constants.ErrAlreadyReported
+		}
+		return "", err
+	}
+	return id.Hex(), nil
+}
+
+func (m *reportModule) FindReportByID(ctx context.Context, id string) (*entity.Report, error) {
+	var model Report
+	if err := m.report.Query().
+		Equal("_id", mongolib.ObjectID(id)).
+		FindOne(ctx).Consume(&model); err != nil {
+		if err == mongolib.ErrNotFound {
+			return nil, constants.ErrReportNotFound
+		}
+		return nil, err
+	}
+	return model.Entity(), nil
+}
+
+func (m *reportModule) FindPendingReportList(ctx context.Context, pagination api.PaginationReq) ([]entity.Report, *api.PaginationRes, error) {
+	var model []Report
+	if pagination.After == "" {
+		pagination.After = "000000000000000000000000"
+	}
+	if err := m.report.Query().
+		Equal("status", constants.ReportStatusPending).
+		GreaterThan("_id", mongolib.ObjectID(pagination.After)).
+		Sort("_id", mongolib.Ascending).
+		Limit(pagination.First).
+		Find(ctx).Consume(&model); err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]entity.Report, len(model))
+	for i, v := range model {
+		result[i] = *v.Entity()
+	}
+	endCursor := ""
+	if len(model) > 0 {
+		endCursor = model[len(model)-1].ID.Hex()
+	}
+	return result, &api.PaginationRes{
+		EndCursor:   endCursor,
+		HasNextPage: len(model) >= pagination.First,
+	}, nil
+}
+
+func (m *reportModule) CountPendingReportByPostID(ctx context.Context, postID string) (int, error) {
+	return m.report.Query().
+		Equal("post_id", mongolib.ObjectID(postID)).
+		Equal("status", constants.ReportStatusPending).
+		Count(ctx)
+}
+
+func (m *reportModule) CountPendingReportByPostIDs(ctx context.Context, postIDs []string) (map[string]int, error) {
+	ids := make([]primitive.ObjectID, len(postIDs))
+	for i, v := range postIDs {
+		ids[i] = mongolib.ObjectID(v)
+	}
+	cur, err := m.report.Collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "post_id", Value: bson.D{{Key: "$in", Value: ids}}},
+			{Key: "status", Value: constants.ReportStatusPending},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$post_id"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var model []struct {
+		PostID primitive.ObjectID `bson:"_id"`
+		Count  int                `bson:"count"`
+	}
+	if err := cur.All(ctx, &model); err != nil {
+		return nil, err
+	}
+	result := make(map[string]int, len(model))
+	for _, v := range model {
+		result[v.PostID.Hex()] = v.Count
+	}
+	return result, nil
+}
+
+func (m *reportModule) ResolveReportsByPostID(ctx context.Context, postID string, action string, moderatorID string) (bool, error) {
+	// only the pending report match, so the moderator resolving the post concurrently match nothing
+	res, err := m.report.UpdateMany(ctx,
+		bson.D{
+			{Key: "post_id", Value: mongolib.ObjectID(postID)},
+			{Key: "status", Value: constants.ReportStatusPending},
+		},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "status", Value: constants.ReportStatusResolved},
+			{Key: "action", Value: action},
+			{Key: "resolved_by", Value: mongolib.ObjectID(moderatorID)},
+			{Key: "resolved_at", Value: time.Now().Unix()},
+		}}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (m *reportModule) ReopenReportsByPostID(ctx context.Context, postID string, action string, moderatorID string) error {
+	if _, err := m.report.UpdateMany(ctx,
+		bson.D{
+			{Key: "post_id", Value: mongolib.ObjectID(postID)},
+			{Key: "status", Value: constants.ReportStatusResolved},
+			{Key: "action", Value: action},
+			{Key: "resolved_by", Value: mongolib.ObjectID(moderatorID)},
+		},
+		bson.D{
+			{Key: "$set", Value: bson.D{{Key: "status", Value: constants.ReportStatusPending}}},
+			{Key: "$unset", Value: bson.D{
+				{Key: "action", Value: ""},
+				{Key: "resolved_by", Value: ""},
+				{Key: "resolved_at", Value: ""},
+			}},
+		},
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+func isDuplicateKeyError(err error) bool {
+	var writeErr mongo.WriteException
+	if !errors.As(err, &writeErr) {
+		return false
+	}
+	for _, v := range writeErr.WriteErrors {
+		if v.Code == 11000 {
+			return true
+		}
+	}
+	return false
+}
+
+type Report struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	PostID         primitive.ObjectID `bson:"post_id"`
+	ReportedUserID primitive.ObjectID `bson:"reported_user_id"`
+	ReporterID     primitive.ObjectID `bson:"reporter_id"`
+	Reason         string             `bson:"reason"`
+	Details        string             `bson:"details"`
+	Status         string             `bson:"status"`
+	Action         string             `bson:"action,omitempty"`
+	ResolvedBy     primitive.ObjectID `bson:"resolved_by,omitempty"`
+	ResolvedAt     int64              `bson:"resolved_at,omitempty"`
+	CreatedAt      int64              `bson:"created_at"`
+}
+
+func (r Report) Entity() *entity.Report {
+	report := &entity.Report{
+		ID:             r.ID.Hex(),
+		PostID:         r.PostID.Hex(),
+		ReportedUserID: r.ReportedUserID.Hex(),
+		Reason:         r.Reason,
+		Details:        r.Details,
+		Status:         r.Status,
+		Action:         r.Action,
+		ResolvedAt:     r.ResolvedAt,
+		Timestamp:      r.CreatedAt,
+	}
+	// the report filed by the content filter has no reporter
+	if !r.ReporterID.IsZero() {
+		report.ReporterID = r.ReporterID.Hex()
+	}
+	if !r.ResolvedBy.IsZero() {
+		report.ResolvedBy = r.ResolvedBy.Hex()
+	}
+	return report
+}
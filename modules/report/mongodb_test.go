@@ -0,0 +1,66 @@
+package report
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/mongolib"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"testing"
+)
+
+func Test_reportModule_ResolveReportsByPostID(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	tests := []struct {
+		name     string
+		modified int
+		want     bool
+	}{
+		{
+			name:     "pending report resolved",
+			modified: 2,
+			want:     true,
+		},
+		{
+			name:     "resolved by other moderator",
+			modified: 0,
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(
+				bson.E{Key: "n", Value: tt.modified},
+				bson.E{Key: "nModified", Value: tt.modified},
+			))
+			m := &reportModule{report: &mongolib.Collection{Collection: mt.Coll}}
+			got, err := m.ResolveReportsByPostID(context.Background(), "5f8d0d55b54764421b7156c9", constants.ReportActionHidePost, "5f8d0d55b54764421b7156ca")
+			assert.Nil(mt, err)
+			assert.Equal(mt, tt.want, got)
+			updates := mt.GetStartedEvent().Command.Lookup("updates").Array()
+			filter := updates.Index(0).Value().Document().Lookup("q").Document()
+			assert.Equal(mt, constants.ReportStatusPending, filter.Lookup("status").StringValue())
+		})
+	}
+}
+
+func Test_reportModule_CountPendingReportByPostIDs(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("count in a single aggregation", func(mt *mtest.T) {
+		post1 := mongolib.ObjectID("5f8d0d55b54764421b7156c9")
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.report", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: post1}, {Key: "count", Value: 3}},
+		))
+		m := &reportModule{report: &mongolib.Collection{Collection: mt.Coll}}
+		got, err := m.CountPendingReportByPostIDs(context.Background(), []string{post1.Hex(), "5f8d0d55b54764421b7156ca"})
+		assert.Nil(mt, err)
+		assert.Equal(mt, map[string]int{post1.Hex(): 3}, got)
+		assert.Equal(mt, "aggregate", mt.GetStartedEvent().CommandName)
+		assert.Nil(mt, mt.GetStartedEvent())
+	})
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/service"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NewFileSystemStorageModule store object under dir, the object is expected to be served at baseURL
+func NewFileSystemStorageModule(dir string, baseURL string) service.StorageModule {
+	return &fileSystemStorageModule{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+type fileSystemStorageModule struct {
+	dir     string
+	baseURL string
+}
+
+func (m *fileSystemStorageModule) Put(_ context.Context, key string, data []byte, _ string) (string, error) {
+	path := filepath.Join(m.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	return m.baseURL + "/" + key, nil
+}
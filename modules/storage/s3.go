@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"github.com/aeramu/menfess-backend/service"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"strings"
+)
+
+type S3Config struct {
+	// Endpoint is empty for aws, filled for s3-compatible storage (minio, spaces, r2, etc)
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PublicURL is the base url the bucket object is served from
+	PublicURL string
+}
+
+func NewS3StorageModule(cfg S3Config) (service.StorageModule, error) {
+	awsCfg := aws.NewConfig().
+		WithRegion(cfg.Region).
+		WithCredentials(credentials.NewStaticCredentials(cfg.AccessKey, cfg.SecretKey, ""))
+	if cfg.Endpoint != "" {
+		awsCfg = awsCfg.WithEndpoint(cfg.Endpoint).WithS3ForcePathStyle(true)
+	}
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return nil, err
+	}
+	return &s3StorageModule{
+		client:    s3.New(sess),
+		bucket:    cfg.Bucket,
+		publicURL: strings.TrimSuffix(cfg.PublicURL, "/"),
+	}, nil
+}
+
+type s3StorageModule struct {
+	client    *s3.S3
+	bucket    string
+	publicURL string
+}
+
+func (m *s3StorageModule) Put(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	if _, err := m.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(m.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+		ACL:         aws.String(s3.ObjectCannedACLPublicRead),
+	}); err != nil {
+		return "", err
+	}
+	return m.publicURL + "/" + key, nil
+}
@@ -0,0 +1,188 @@
+package trending
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/aeramu/menfess-backend/service"
+	"github.com/aeramu/mongolib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+func NewTrendingModule(db *mongolib.Database) service.TrendingModule {
+	return &trendingModule{
+		trending: db.Coll("trending"),
+		snapshot: db.Coll("engagement_snapshot"),
+	}
+}
+
+// trendingModule keep one document per window, so the query only need a single read
+// and the periodic job replace the ranking atomically
+type trendingModule struct {
+	trending *mongolib.Collection
+	snapshot *mongolib.Collection
+}
+
+func (m *trendingModule) SaveTrendingPostList(ctx context.Context, window int, posts []entity.TrendingPost) error {
+	model := make([]TrendingPost, len(posts))
+	for i, v := range posts {
+		model[i] = TrendingPost{
+			PostID: mongolib.ObjectID(v.PostID),
+			Score:  v.Score,
+		}
+	}
+	return m.save(ctx, window, "posts", model)
+}
+
+func (m *trendingModule) FindTrendingPostList(ctx context.Context, window int, limit int) ([]entity.TrendingPost, error) {
+	model, err := m.find(ctx, window)
+	if err != nil {
+		return nil, err
+	}
+	var result []entity.TrendingPost
+	for i, v := range model.Posts {
+		if i >= limit {
+			break
+		}
+		result = append(result, entity.TrendingPost{
+			PostID: v.PostID.Hex(),
+			Score:  v.Score,
+		})
+	}
+	return result, nil
+}
+
+func (m *trendingModule) SaveTrendingTopicList(ctx context.Context, window int, topics []entity.Topic) error {
+	model := make([]Topic, len(topics))
+	for i, v := range topics {
+		model[i] = Topic{
+			Name:       v.Name,
+			PostsCount: v.PostsCount,
+			Score:      v.Score,
+		}
+	}
+	return m.save(ctx, window, "topics", model)
+}
+
+func (m *trendingModule) FindTrendingTopicList(ctx context.Context, window int, limit int) ([]entity.Topic, error) {
+	model, err := m.find(ctx, window)
+	if err != nil {
+		return nil, err
+	}
+	var result []entity.Topic
+	for i, v := range model.Topics {
+		if i >= limit {
+			break
+		}
+		result = append(result, entity.Topic{
+			Name:       v.Name,
+			PostsCount: v.PostsCount,
+			Score:      v.Score,
+		})
+	}
+	return result, nil
+}
+
+func (m *trendingModule) SaveEngagementSnapshot(ctx context.Context, snapshot entity.EngagementSnapshot) error {
+	model := EngagementSnapshot{TakenAt: snapshot.TakenAt}
+	for id, v := range snapshot.Engagement {
+		model.Posts = append(model.Posts, PostEngagement{
+			PostID:     mongolib.ObjectID(id),
+			Engagement: v,
+		})
+	}
+	if _, err := m.snapshot.InsertOne(ctx, model); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindEngagementSnapshot return the newest snapshot taken at or before the timestamp
+func (m *trendingModule) FindEngagementSnapshot(ctx context.Context, timestamp int64) (*entity.EngagementSnapshot, error) {
+	var model EngagementSnapshot
+	if err := m.snapshot.Query().
+		LessThanEqual("taken_at", timestamp).
+		Sort("taken_at", -1).
+		FindOne(ctx).Consume(&model); err != nil {
+		if err == mongolib.ErrNotFound {
+			return nil, constants.ErrEngagementSnapshotNotFound
+		}
+		return nil, err
+	}
+	return model.Entity(), nil
+}
+
+func (m *trendingModule) DeleteEngagementSnapshotBefore(ctx context.Context, timestamp int64) error {
+	return m.snapshot.Query().LessThan("taken_at", timestamp).Delete(ctx)
+}
+
+func (m *trendingModule) save(ctx context.Context, window int, field string, value interface{}) error {
+	filter := bson.D{{Key: "window", Value: window}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "window", Value: window},
+		{Key: field, Value: value},
+		{Key: "computed_at", Value: time.Now().Unix()},
+	}}}
+	if _, err := m.trending.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *trendingModule) find(ctx context.Context, window int) (*Trending, error) {
+	var model Trending
+	if err := m.trending.Query().Equal("window", window).FindOne(ctx).Consume(&model); err != nil {
+		// job hasn't run yet for this window, treat it as empty ranking
+		if err == mongolib.ErrNotFound {
+			return &Trending{Window: window}, nil
+		}
+		return nil, err
+	}
+	return &model, nil
+}
+
+type Trending struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	Window     int                `bson:"window"`
+	Posts      []TrendingPost     `bson:"posts"`
+	Topics     []Topic            `bson:"topics"`
+	ComputedAt int64              `bson:"computed_at"`
+}
+
+// EngagementSnapshot keep likes + replies of every trending candidate at a time,
+// the growth in a window is the difference from the snapshot taken at the window start
+type EngagementSnapshot struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty"`
+	TakenAt int64              `bson:"taken_at"`
+	Posts   []PostEngagement   `bson:"posts"`
+}
+
+func (s EngagementSnapshot) Entity() *entity.EngagementSnapshot {
+	engagement := make(map[string]int, len(s.Posts))
+	for _, v := range s.Posts {
+		engagement[v.PostID.Hex()] = v.Engagement
+	}
+	return &entity.EngagementSnapshot{
+		TakenAt:    s.TakenAt,
+		Engagement: engagement,
+	}
+}
+
+type PostEngagement struct {
+	PostID     primitive.ObjectID `bson:"post_id"`
+	Engagement int                `bson:"engagement"`
+}
+
+type TrendingPost struct {
+	PostID primitive.ObjectID `bson:"post_id"`
+	Score  float64            `bson:"score"`
+}
+
+type Topic struct {
+	Name       string  `bson:"name"`
+	PostsCount int     `bson:"posts_count"`
+	Score      float64 `bson:"score"`
+}
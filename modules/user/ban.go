@@ -0,0 +1,64 @@
+package user
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/aeramu/mongolib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+func (u *userModule) BanDevices(ctx context.Context, userID string, pushTokens []string, reason string) error {
+	now := time.Now().Unix()
+	for _, v := range pushTokens {
+		// the device used by other banned user before keep its first ban
+		if _, err := u.bannedDevice.UpdateOne(ctx,
+			bson.D{{Key: "push_token", Value: v}},
+			bson.D{{Key: "$setOnInsert", Value: bson.D{
+				{Key: "user_id", Value: mongolib.ObjectID(userID)},
+				{Key: "reason", Value: reason},
+				{Key: "created_at", Value: now},
+			}}},
+			options.Update().SetUpsert(true)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *userModule) UnbanDevices(ctx context.Context, userID string) error {
+	if err := u.bannedDevice.Query().
+		Equal("user_id", mongolib.ObjectID(userID)).
+		Delete(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *userModule) FindBannedDevice(ctx context.Context, pushToken string) (*entity.BannedDevice, error) {
+	var model BannedDevice
+	if err := u.bannedDevice.Query().
+		Equal("push_token", pushToken).
+		FindOne(ctx).Consume(&model); err != nil {
+		if err == mongolib.ErrNotFound {
+			return nil, constants.ErrBannedDeviceNotFound
+		}
+		return nil, err
+	}
+	return &entity.BannedDevice{
+		PushToken: model.PushToken,
+		UserID:    model.UserID.Hex(),
+		Reason:    model.Reason,
+	}, nil
+}
+
+type BannedDevice struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	PushToken string             `bson:"push_token"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	Reason    string             `bson:"reason"`
+	CreatedAt int64              `bson:"created_at"`
+}
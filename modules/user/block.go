@@ -0,0 +1,101 @@
+package user
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/mongolib"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+func (u *userModule) UpdateBlockStatus(ctx context.Context, userID, blockedID, status string) error {
+	return updateRestrictionStatus(ctx, u.block, userID, blockedID, status == constants.BlockStatusActive)
+}
+
+func (u *userModule) UpdateMuteStatus(ctx context.Context, userID, mutedID, status string) error {
+	return updateRestrictionStatus(ctx, u.mute, userID, mutedID, status == constants.MuteStatusActive)
+}
+
+func (u *userModule) IsBlocked(ctx context.Context, userID, blockedID string) (bool, error) {
+	count, err := u.block.Query().
+		Equal("user_id", mongolib.ObjectID(userID)).
+		Equal("target_id", mongolib.ObjectID(blockedID)).
+		Count(ctx)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (u *userModule) FindBlockerIDsIn(ctx context.Context, blockedID string, ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return []string{}, nil
+	}
+	objectIDs := make([]primitive.ObjectID, len(ids))
+	for i, v := range ids {
+		objectIDs[i] = mongolib.ObjectID(v)
+	}
+	var model []Restriction
+	if err := u.block.Query().
+		In("user_id", objectIDs).
+		Equal("target_id", mongolib.ObjectID(blockedID)).
+		Find(ctx).Consume(&model); err != nil {
+		return nil, err
+	}
+	result := make([]string, len(model))
+	for i, v := range model {
+		result[i] = v.UserID.Hex()
+	}
+	return result, nil
+}
+
+func (u *userModule) FindHiddenUserIDs(ctx context.Context, userID string) ([]string, error) {
+	var result []string
+	for _, coll := range []*mongolib.Collection{u.block, u.mute} {
+		var model []Restriction
+		if err := coll.Query().
+			Equal("user_id", mongolib.ObjectID(userID)).
+			Find(ctx).Consume(&model); err != nil {
+			return nil, err
+		}
+		for _, v := range model {
+			result = append(result, v.TargetID.Hex())
+		}
+	}
+	return result, nil
+}
+
+// updateRestrictionStatus add or remove the block or mute, both are stored the same way in their own collection
+func updateRestrictionStatus(ctx context.Context, coll *mongolib.Collection, userID, targetID string, active bool) error {
+	if active {
+		id := mongolib.NewObjectID()
+		model := Restriction{
+			ID:        id,
+			UserID:    mongolib.ObjectID(userID),
+			TargetID:  mongolib.ObjectID(targetID),
+			CreatedAt: time.Now().Unix(),
+		}
+		if err := coll.Save(ctx, id, model); err != nil {
+			// already blocked or muted, the unique index keep a single one
+			if isDuplicateKeyError(err) {
+				return nil
+			}
+			return err
+		}
+	} else {
+		if err := coll.Query().
+			Equal("user_id", mongolib.ObjectID(userID)).
+			Equal("target_id", mongolib.ObjectID(targetID)).
+			Delete(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type Restriction struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	TargetID  primitive.ObjectID `bson:"target_id"`
+	CreatedAt int64              `bson:"created_at"`
+}
@@ -0,0 +1,189 @@
+package user
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/menfess-backend/service/api"
+	"github.com/aeramu/mongolib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+func (u *userModule) GetFollowedUserID(ctx context.Context, userID string) ([]string, error) {
+	var model []Follow
+	if err := u.follow.Query().
+		Equal("follower_id", mongolib.ObjectID(userID)).
+		Find(ctx).Consume(&model); err != nil {
+		return nil, err
+	}
+	result := make([]string, len(model))
+	for i, v := range model {
+		result[i] = v.FollowedID.Hex()
+	}
+	return result, nil
+}
+
+func (u *userModule) FindFollowedUserIDsIn(ctx context.Context, userID string, ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return []string{}, nil
+	}
+	objectIDs := make([]primitive.ObjectID, len(ids))
+	for i, v := range ids {
+		objectIDs[i] = mongolib.ObjectID(v)
+	}
+	var model []Follow
+	if err := u.follow.Query().
+		Equal("follower_id", mongolib.ObjectID(userID)).
+		In("followed_id", objectIDs).
+		Find(ctx).Consume(&model); err != nil {
+		return nil, err
+	}
+	result := make([]string, len(model))
+	for i, v := range model {
+		result[i] = v.FollowedID.Hex()
+	}
+	return result, nil
+}
+
+func (u *userModule) FindFollowerIDs(ctx context.Context, userID string, pagination api.PaginationReq) ([]string, *api.PaginationRes, error) {
+	model, paginationRes, err := u.findFollowList(ctx, "followed_id", userID, pagination)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := make([]string, len(model))
+	for i, v := range model {
+		ids[i] = v.FollowerID.Hex()
+	}
+	return ids, paginationRes, nil
+}
+
+func (u *userModule) FindFollowingIDs(ctx context.Context, userID string, pagination api.PaginationReq) ([]string, *api.PaginationRes, error) {
+	model, paginationRes, err := u.findFollowList(ctx, "follower_id", userID, pagination)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := make([]string, len(model))
+	for i, v := range model {
+		ids[i] = v.FollowedID.Hex()
+	}
+	return ids, paginationRes, nil
+}
+
+// findFollowList return the newest follow first, the cursor is the follow id
+func (u *userModule) findFollowList(ctx context.Context, key string, userID string, pagination api.PaginationReq) ([]Follow, *api.PaginationRes, error) {
+	var model []Follow
+	if pagination.After == "" {
+		pagination.After = "ffffffffffffffffffffffff"
+	}
+	if err := u.follow.Query().
+		Equal(key, mongolib.ObjectID(userID)).
+		LessThan("_id", mongolib.ObjectID(pagination.After)).
+		Sort("_id", mongolib.Descending).
+		Limit(pagination.First).
+		Find(ctx).Consume(&model); err != nil {
+		return nil, nil, err
+	}
+
+	endCursor := ""
+	if len(model) > 0 {
+		endCursor = model[len(model)-1].ID.Hex()
+	}
+	return model, &api.PaginationRes{
+		EndCursor:   endCursor,
+		HasNextPage: len(model) >= pagination.First,
+	}, nil
+}
+
+func (u *userModule) IsFollowing(ctx context.Context, follower, followed string) (bool, error) {
+	count, err := u.follow.Query().
+		Equal("follower_id", mongolib.ObjectID(follower)).
+		Equal("followed_id", mongolib.ObjectID(followed)).
+		Count(ctx)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (u *userModule) CountFollowers(ctx context.Context, userID string) (int, error) {
+	return u.follow.Query().Equal("followed_id", mongolib.ObjectID(userID)).Count(ctx)
+}
+
+func (u *userModule) CountFollowing(ctx context.Context, userID string) (int, error) {
+	return u.follow.Query().Equal("follower_id", mongolib.ObjectID(userID)).Count(ctx)
+}
+
+func (u *userModule) UpdateFollowStatus(ctx context.Context, follower, followed, status string) (bool, error) {
+	if status == constants.FollowStatusActive {
+		id := mongolib.NewObjectID()
+		model := Follow{
+			ID:         id,
+			FollowerID: mongolib.ObjectID(follower),
+			FollowedID: mongolib.ObjectID(followed),
+			CreatedAt:  time.Now().Unix(),
+		}
+		if err := u.follow.Save(ctx, id, model); err != nil {
+			// already followed, the unique index keep a single edge
+			if isDuplicateKeyError(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+
+	res, err := u.follow.DeleteOne(ctx, bson.D{
+		{Key: "follower_id", Value: mongolib.ObjectID(follower)},
+		{Key: "followed_id", Value: mongolib.ObjectID(followed)},
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// MigrateFollow move the follow array embedded on the user document to the follow collection.
+// It's safe to run on every start, user already migrated no longer has the follow array.
+func MigrateFollow(ctx context.Context, db *mongolib.Database) error {
+	user := db.Coll("user")
+	follow := db.Coll("follow")
+
+	cursor, err := user.Find(ctx, bson.D{{Key: "follow", Value: bson.D{{Key: "$exists", Value: true}}}})
+	if err != nil {
+		return err
+	}
+	var model []struct {
+		ID     primitive.ObjectID   `bson:"_id"`
+		Follow []primitive.ObjectID `bson:"follow"`
+	}
+	if err := cursor.All(ctx, &model); err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	for _, v := range model {
+		for _, followed := range v.Follow {
+			if _, err := follow.UpdateOne(ctx,
+				bson.D{{Key: "follower_id", Value: v.ID}, {Key: "followed_id", Value: followed}},
+				bson.D{{Key: "$setOnInsert", Value: bson.D{{Key: "created_at", Value: now}}}},
+				options.Update().SetUpsert(true)); err != nil {
+				return err
+			}
+		}
+		if _, err := user.UpdateOne(ctx,
+			bson.D{{Key: "_id", Value: v.ID}},
+			bson.D{{Key: "$unset", Value: bson.D{{Key: "follow", Value: ""}}}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type Follow struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	FollowerID primitive.ObjectID `bson:"follower_id"`
+	FollowedID primitive.ObjectID `bson:"followed_id"`
+	CreatedAt  int64              `bson:"created_at"`
+}
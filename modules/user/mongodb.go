@@ -2,19 +2,78 @@ package user
 
 import (
 	"context"
+	"errors"
 	"github.com/aeramu/menfess-backend/constants"
 	"github.com/aeramu/menfess-backend/entity"
 	"github.com/aeramu/menfess-backend/service"
 	"github.com/aeramu/mongolib"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func NewUserModule(db *mongolib.Database) service.UserModule {
-	return &userModule{user: db.Coll("user")}
+	return &userModule{
+		user:         db.Coll("user"),
+		follow:       db.Coll("follow"),
+		block:        db.Coll("block"),
+		mute:         db.Coll("mute"),
+		mutedWord:    db.Coll("muted_word"),
+		bannedDevice: db.Coll("banned_device"),
+	}
+}
+
+// EnsureIndex create the unique handle index, user without handle is skipped by the sparse index,
+// the follow indexes to look up the edge from both the follower and the followed user,
+// the unique block and mute indexes, the unique muted word index and the unique banned device index
+func EnsureIndex(ctx context.Context, db *mongolib.Database) error {
+	if _, err := db.Coll("user").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "handle", Value: 1}},
+		Options: options.Index().SetUnique(true).SetSparse(true),
+	}); err != nil {
+		return err
+	}
+	if _, err := db.Coll("follow").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "follower_id", Value: 1}, {Key: "followed_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "follower_id", Value: 1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "followed_id", Value: 1}, {Key: "_id", Value: -1}}},
+	}); err != nil {
+		return err
+	}
+	for _, coll := range []string{"block", "mute"} {
+		if _, err := db.Coll(coll).Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "target_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		}); err != nil {
+			return err
+		}
+	}
+	if _, err := db.Coll("muted_word").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "normalized", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		return err
+	}
+	if _, err := db.Coll("banned_device").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "push_token", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		return err
+	}
+	return nil
 }
 
 type userModule struct {
-	user *mongolib.Collection
+	user         *mongolib.Collection
+	follow       *mongolib.Collection
+	block        *mongolib.Collection
+	mute         *mongolib.Collection
+	mutedWord    *mongolib.Collection
+	bannedDevice *mongolib.Collection
 }
 
 func (u *userModule) FindUserByID(ctx context.Context, id string) (*entity.User, error) {
@@ -39,6 +98,64 @@ func (u *userModule) FindUserByEmail(ctx context.Context, email string) (*entity
 	return model.Entity(), nil
 }
 
+func (u *userModule) FindUserByHandle(ctx context.Context, handle string) (*entity.User, error) {
+	var model User
+	if err := u.user.Query().Equal("handle", handle).FindOne(ctx).Consume(&model); err != nil {
+		if err == mongolib.ErrNotFound {
+			return nil, constants.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return model.Entity(), nil
+}
+
+func (u *userModule) SaveHandle(ctx context.Context, user entity.User) error {
+	if err := u.user.Query().
+		Equal("_id", mongolib.ObjectID(user.ID)).
+		Set("handle", user.Profile.Handle).
+		Set("handle_changed_at", user.Account.HandleChangedAt).
+		Update(ctx); err != nil {
+		// the unique index catch other user taking the same handle at the same time
+		if isDuplicateKeyError(err) {
+			return constants.ErrHandleTaken
+		}
+		return err
+	}
+	return nil
+}
+
+func (u *userModule) FindUserListByIDs(ctx context.Context, ids []string) ([]entity.User, error) {
+	objectIDs := make([]primitive.ObjectID, len(ids))
+	for i, v := range ids {
+		objectIDs[i] = mongolib.ObjectID(v)
+	}
+	var model Users
+	if err := u.user.Query().In("_id", objectIDs).Find(ctx).Consume(&model); err != nil {
+		return nil, err
+	}
+
+	// keep the order of the requested ids, mongo doesn't guarantee it for $in
+	mapUser := make(map[string]entity.User)
+	for _, v := range model.Entity() {
+		mapUser[v.ID] = v
+	}
+	var users []entity.User
+	for _, v := range ids {
+		if user, ok := mapUser[v]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (u *userModule) FindUserListByHandles(ctx context.Context, handles []string) ([]entity.User, error) {
+	var model Users
+	if err := u.user.Query().In("handle", handles).Find(ctx).Consume(&model); err != nil {
+		return nil, err
+	}
+	return model.Entity(), nil
+}
+
 func (u *userModule) InsertUser(ctx context.Context, user entity.User) (string, error) {
 	id := mongolib.NewObjectID()
 	model := User{
@@ -47,7 +164,6 @@ func (u *userModule) InsertUser(ctx context.Context, user entity.User) (string,
 		Avatar:   user.Profile.Avatar,
 		Bio:      user.Profile.Bio,
 		Type:     "user",
-		Follow:   &[]primitive.ObjectID{},
 	}
 	if err := u.user.Save(ctx, id, model); err != nil {
 		return "", err
@@ -69,6 +185,48 @@ func (u *userModule) SaveProfile(ctx context.Context, user entity.User) error {
 	return nil
 }
 
+func (u *userModule) SaveSettings(ctx context.Context, user entity.User) error {
+	if err := u.user.Query().
+		Equal("_id", mongolib.ObjectID(user.ID)).
+		Set("sensitive_content", user.Settings.SensitiveContent).
+		Update(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *userModule) SaveSuspension(ctx context.Context, user entity.User) error {
+	if err := u.user.Query().
+		Equal("_id", mongolib.ObjectID(user.ID)).
+		Set("suspended_until", user.Account.SuspendedUntil).
+		Set("suspension_reason", user.Account.SuspensionReason).
+		Update(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *userModule) SaveBan(ctx context.Context, user entity.User) error {
+	if err := u.user.Query().
+		Equal("_id", mongolib.ObjectID(user.ID)).
+		Set("banned_at", user.Account.BannedAt).
+		Set("ban_reason", user.Account.BanReason).
+		Update(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *userModule) SaveShadowban(ctx context.Context, user entity.User) error {
+	if err := u.user.Query().
+		Equal("_id", mongolib.ObjectID(user.ID)).
+		Set("shadowbanned_at", user.Account.ShadowbannedAt).
+		Update(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (u *userModule) FindMenfessList(ctx context.Context) ([]entity.User, error) {
 	var model Users
 	if err := u.user.Aggregate().
@@ -83,54 +241,58 @@ func (u *userModule) FindMenfessList(ctx context.Context) ([]entity.User, error)
 	return model.Entity(), nil
 }
 
-func (u *userModule) GetFollowedUserID(ctx context.Context, userID string) ([]string, error) {
-	var model User
-	if err := u.user.FindByID(ctx, mongolib.ObjectID(userID)).Consume(&model); err != nil {
-		return nil, err
-	}
-	if model.Follow == nil{
-		model.Follow = &[]primitive.ObjectID{}
-	}
-	result := make([]string, len(*model.Follow))
-	for i, v := range *model.Follow {
-		result[i] = v.Hex()
+func isDuplicateKeyError(err error) bool {
+	var writeErr mongo.WriteException
+	if !errors.As(err, &writeErr) {
+		return false
 	}
-	return result, nil
-}
-
-func (u *userModule) UpdateFollowStatus(ctx context.Context, follower, followed, status string) error {
-	if status == constants.FollowStatusActive {
-		if err := u.user.Query().
-			Equal("_id", mongolib.ObjectID(follower)).
-			Push("follow", mongolib.ObjectID(followed)).
-			Update(ctx); err != nil {
-				return err
-		}
-	} else {
-		if err := u.user.Query().
-			Equal("_id", mongolib.ObjectID(follower)).
-			Pull("follow", mongolib.ObjectID(followed)).
-			Update(ctx); err != nil {
-			return err
+	for _, v := range writeErr.WriteErrors {
+		if v.Code == 11000 {
+			return true
 		}
 	}
-	return nil
+	return false
 }
 
 type User struct {
 	ID     primitive.ObjectID    `bson:"_id"`
 	Name   string                `bson:"name"`
+	// Handle is omitted so saving profile doesn't reset it
+	Handle string                `bson:"handle,omitempty"`
+	HandleChangedAt int64        `bson:"handle_changed_at,omitempty"`
 	Avatar string                `bson:"avatar"`
 	Bio    string                `bson:"bio"`
 	Type   string                `bson:"type"`
-	Follow *[]primitive.ObjectID `bson:"follow,omitempty"`
+	// Role is set directly on the database, omitted so saving profile doesn't reset it
+	Role   string                `bson:"role,omitempty"`
+	// SensitiveContent is only written by SaveSettings, omitted so saving profile doesn't reset it
+	SensitiveContent string      `bson:"sensitive_content,omitempty"`
+	// SuspendedUntil and SuspensionReason are only written by SaveSuspension
+	SuspendedUntil   int64       `bson:"suspended_until,omitempty"`
+	SuspensionReason string      `bson:"suspension_reason,omitempty"`
+	// BannedAt and BanReason are only written by SaveBan
+	BannedAt  int64              `bson:"banned_at,omitempty"`
+	BanReason string             `bson:"ban_reason,omitempty"`
+	// ShadowbannedAt is only written by SaveShadowban
+	ShadowbannedAt int64         `bson:"shadowbanned_at,omitempty"`
 }
 
 func (u User) Entity() *entity.User {
 	return &entity.User{
 		ID:      u.ID.Hex(),
+		Account: entity.Account{
+			Role:             u.Role,
+			HandleChangedAt:  u.HandleChangedAt,
+			SuspendedUntil:   u.SuspendedUntil,
+			SuspensionReason: u.SuspensionReason,
+			BannedAt:         u.BannedAt,
+			BanReason:        u.BanReason,
+			ShadowbannedAt:   u.ShadowbannedAt,
+		},
+		Settings: entity.Settings{SensitiveContent: u.SensitiveContent},
 		Profile: entity.Profile{
 			Name:   u.Name,
+			Handle: u.Handle,
 			Avatar: u.Avatar,
 			Bio:    u.Bio,
 		},
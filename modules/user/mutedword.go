@@ -0,0 +1,74 @@
+package user
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/aeramu/menfess-backend/utils"
+	"github.com/aeramu/mongolib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+func (u *userModule) SaveMutedWord(ctx context.Context, userID string, word entity.MutedWord) error {
+	// the same word written differently is saved once, only its expiry is updated
+	if _, err := u.mutedWord.UpdateOne(ctx,
+		bson.D{
+			{Key: "user_id", Value: mongolib.ObjectID(userID)},
+			{Key: "normalized", Value: utils.NormalizeText(word.Word)},
+		},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "word", Value: word.Word},
+			{Key: "expired_at", Value: word.ExpiredAt},
+		}}},
+		options.Update().SetUpsert(true)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *userModule) DeleteMutedWord(ctx context.Context, userID string, word string) error {
+	if err := u.mutedWord.Query().
+		Equal("user_id", mongolib.ObjectID(userID)).
+		Equal("normalized", utils.NormalizeText(word)).
+		Delete(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *userModule) FindMutedWordList(ctx context.Context, userID string) ([]entity.MutedWord, error) {
+	var model []MutedWord
+	cursor, err := u.mutedWord.Find(ctx, bson.D{
+		{Key: "user_id", Value: mongolib.ObjectID(userID)},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "expired_at", Value: 0}},
+			bson.D{{Key: "expired_at", Value: bson.D{{Key: "$gt", Value: time.Now().Unix()}}}},
+		}},
+	}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(ctx, &model); err != nil {
+		return nil, err
+	}
+
+	result := make([]entity.MutedWord, len(model))
+	for i, v := range model {
+		result[i] = entity.MutedWord{
+			Word:      v.Word,
+			ExpiredAt: v.ExpiredAt,
+		}
+	}
+	return result, nil
+}
+
+type MutedWord struct {
+	ID     primitive.ObjectID `bson:"_id"`
+	UserID primitive.ObjectID `bson:"user_id"`
+	Word   string             `bson:"word"`
+	// Normalized is the word normalized with utils.NormalizeText, unique for each user
+	Normalized string `bson:"normalized"`
+	ExpiredAt  int64  `bson:"expired_at"`
+}
@@ -4,9 +4,15 @@ import (
 	"github.com/aeramu/menfess-backend/constants"
 	"github.com/aeramu/menfess-backend/entity"
 	"github.com/aeramu/menfess-backend/utils"
+	"net/http"
+	"net/url"
+	"regexp"
 	"strings"
+	"time"
 )
 
+var handleRegex = regexp.MustCompile(`^[a-z0-9_]+$`)
+
 type PaginationReq struct {
 	First int
 	After string
@@ -39,7 +45,10 @@ func (req LoginReq) Validate() error {
 }
 
 type RegisterReq struct {
+	// PushToken is the device id for the banned device check and the per-device rate limit
 	PushToken string
+	// ClientIP identify the client for the per-ip rate limit, the limit is skipped if it's empty
+	ClientIP  string
 }
 
 type RegisterRes struct {
@@ -76,6 +85,100 @@ func (req *UpdateProfileReq) Validate() error {
 	return nil
 }
 
+type UpdateSettingsReq struct {
+	UserID           string
+	SensitiveContent string
+}
+
+type UpdateSettingsRes struct {
+	Message string
+}
+
+func (req UpdateSettingsReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	switch req.SensitiveContent {
+	case constants.SensitiveContentHide, constants.SensitiveContentBlur, constants.SensitiveContentShow:
+	default:
+		return constants.ErrInvalidSensitiveContent
+	}
+	return nil
+}
+
+type GetAvatarListReq struct {}
+
+type GetAvatarListRes struct {
+	AvatarList []entity.Avatar
+}
+
+func (req GetAvatarListReq) Validate() error {
+	return nil
+}
+
+type AddAvatarReq struct {
+	UserID string
+	URL    string
+}
+
+type AddAvatarRes struct {
+	Message string
+}
+
+func (req *AddAvatarReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	req.URL = strings.TrimSpace(req.URL)
+	u, err := url.ParseRequestURI(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return constants.ErrInvalidAvatar
+	}
+	return nil
+}
+
+type RemoveAvatarReq struct {
+	UserID string
+	URL    string
+}
+
+type RemoveAvatarRes struct {
+	Message string
+}
+
+func (req RemoveAvatarReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.URL == "" {
+		return constants.ErrInvalidAvatar
+	}
+	return nil
+}
+
+type UploadAvatarReq struct {
+	UserID      string
+	Data        []byte
+	// ContentType is sniffed from Data on Validate, client provided type isn't trusted
+	ContentType string
+}
+
+type UploadAvatarRes struct {
+	URL string
+}
+
+func (req *UploadAvatarReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	contentType, err := detectImageType(req.Data)
+	if err != nil {
+		return err
+	}
+	req.ContentType = contentType
+	return nil
+}
+
 type GetUserReq struct {
 	ID string
 }
@@ -91,6 +194,153 @@ func (req GetUserReq) Validate() error {
 	return nil
 }
 
+type GetUserProfileReq struct {
+	ID     string
+	UserID string
+}
+
+type GetUserProfileRes struct {
+	User           entity.User
+	FollowersCount int
+	FollowingCount int
+	PostsCount     int
+	IsFollowed     bool
+	FollowsYou     bool
+}
+
+func (req GetUserProfileReq) Validate() error {
+	if req.ID == "" {
+		return constants.ErrInvalidID
+	}
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	return nil
+}
+
+type GetUserByHandleReq struct {
+	Handle string
+	UserID string
+}
+
+type GetUserByHandleRes struct {
+	User       entity.User
+	IsFollowed bool
+}
+
+func (req *GetUserByHandleReq) Validate() error {
+	req.Handle = normalizeHandle(req.Handle)
+	if req.Handle == "" {
+		return constants.ErrInvalidHandle
+	}
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	return nil
+}
+
+type SetHandleReq struct {
+	UserID string
+	Handle string
+}
+
+type SetHandleRes struct {
+	Message string
+}
+
+func (req *SetHandleReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	req.Handle = normalizeHandle(req.Handle)
+	return validateHandle(req.Handle)
+}
+
+type CheckHandleReq struct {
+	UserID string
+	Handle string
+}
+
+type CheckHandleRes struct {
+	Available bool
+}
+
+func (req *CheckHandleReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	req.Handle = normalizeHandle(req.Handle)
+	return validateHandle(req.Handle)
+}
+
+// normalizeHandle make handle comparison case-insensitive, leading @ is accepted
+func normalizeHandle(handle string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+}
+
+func validateHandle(handle string) error {
+	if len(handle) < constants.HandleMinLength || len(handle) > constants.HandleMaxLength || !handleRegex.MatchString(handle) {
+		return constants.ErrInvalidHandle
+	}
+	stripped := strings.TrimFunc(handle, func(r rune) bool {
+		return r == '_' || (r >= '0' && r <= '9')
+	})
+	if constants.ReservedHandles[handle] || constants.ReservedHandles[stripped] {
+		return constants.ErrHandleReserved
+	}
+	return nil
+}
+
+type GetFollowerListReq struct {
+	ID         string
+	UserID     string
+	Pagination PaginationReq
+}
+
+type GetFollowerListRes struct {
+	UserList    []entity.User
+	FollowedIDs []string
+	Pagination  PaginationRes
+}
+
+func (req *GetFollowerListReq) Validate() error {
+	if req.ID == "" {
+		return constants.ErrInvalidID
+	}
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.Pagination.First < 1 {
+		req.Pagination.First = 20
+	}
+	return nil
+}
+
+type GetFollowingListReq struct {
+	ID         string
+	UserID     string
+	Pagination PaginationReq
+}
+
+type GetFollowingListRes struct {
+	UserList    []entity.User
+	FollowedIDs []string
+	Pagination  PaginationRes
+}
+
+func (req *GetFollowingListReq) Validate() error {
+	if req.ID == "" {
+		return constants.ErrInvalidID
+	}
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.Pagination.First < 1 {
+		req.Pagination.First = 20
+	}
+	return nil
+}
+
 type GetMenfessListReq struct {
 	UserID string
 }
@@ -166,10 +416,29 @@ func (req FeedReq) Validate() error {
 }
 
 type CreatePostReq struct {
-	Body     string
-	UserID   string
-	AuthorID string
-	ParentID string
+	Body         string
+	UserID       string
+	AuthorID     string
+	ParentID     string
+	QuotedPostID string
+	Poll         *PollReq
+	// PublishAt is unix timestamp to publish the post later, 0 mean publish immediately
+	PublishAt    int64
+	// ExpiresIn is post lifetime in second counted from publish time, 0 mean the post never expire
+	ExpiresIn    int64
+	// AttachmentIDs is the id of images uploaded beforehand by the user
+	AttachmentIDs []string
+	ContentWarnings []string
+	// ConfirmPersonalData post the personal data the redaction can't mask as is
+	ConfirmPersonalData bool
+	ClientIP     string
+}
+
+type PollReq struct {
+	Options        []string
+	MultipleChoice bool
+	// ExpiresIn is poll duration in second, 0 mean the poll never close
+	ExpiresIn      int64
 }
 
 type CreatePostRes struct {
@@ -177,18 +446,123 @@ type CreatePostRes struct {
 }
 
 func (req CreatePostReq) Validate() error {
-	if req.Body == "" {
+	if req.Body == "" && len(req.AttachmentIDs) == 0 {
 		return constants.ErrInvalidBody
 	}
 	if req.UserID == "" {
 		return constants.ErrInvalidUserID
 	}
+	if req.ParentID != "" && req.QuotedPostID != "" {
+		return constants.ErrQuoteReply
+	}
+	if req.Poll != nil {
+		if err := req.Poll.Validate(); err != nil {
+			return err
+		}
+	}
+	if req.PublishAt != 0 {
+		if req.ParentID != "" {
+			return constants.ErrScheduleReply
+		}
+		now := time.Now().Unix()
+		if req.PublishAt <= now || req.PublishAt > now+constants.ScheduleMaxAhead {
+			return constants.ErrInvalidPublishAt
+		}
+	}
+	if req.ExpiresIn != 0 && (req.ExpiresIn < constants.PostMinLifetime || req.ExpiresIn > constants.PostMaxLifetime) {
+		return constants.ErrInvalidExpiresIn
+	}
+	if len(req.AttachmentIDs) > constants.PostMaxAttachments {
+		return constants.ErrTooManyAttachments
+	}
+	if err := validateContentWarnings(req.ContentWarnings); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateContentWarnings check every category is known and not repeated
+func validateContentWarnings(warnings []string) error {
+	found := make(map[string]bool)
+	for _, v := range warnings {
+		if found[v] || !isValidContentWarning(v) {
+			return constants.ErrInvalidContentWarning
+		}
+		found[v] = true
+	}
+	return nil
+}
+
+func isValidContentWarning(warning string) bool {
+	for _, v := range constants.ContentWarnings {
+		if warning == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (req *PollReq) Validate() error {
+	if len(req.Options) < constants.PollMinOptions || len(req.Options) > constants.PollMaxOptions {
+		return constants.ErrInvalidPoll
+	}
+	found := make(map[string]bool)
+	for i, v := range req.Options {
+		v = strings.TrimSpace(v)
+		if v == "" || len(v) > constants.PollMaxOptionLength || found[strings.ToLower(v)] {
+			return constants.ErrInvalidPollOption
+		}
+		found[strings.ToLower(v)] = true
+		req.Options[i] = v
+	}
+	if req.ExpiresIn < 0 || req.ExpiresIn > constants.PollMaxDuration {
+		return constants.ErrInvalidPoll
+	}
+	return nil
+}
+
+type UploadImageReq struct {
+	UserID      string
+	Data        []byte
+	// ContentType is sniffed from Data on Validate, client provided type isn't trusted
+	ContentType string
+}
+
+type UploadImageRes struct {
+	Attachment entity.Attachment
+}
+
+func (req *UploadImageReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	contentType, err := detectImageType(req.Data)
+	if err != nil {
+		return err
+	}
+	req.ContentType = contentType
 	return nil
 }
 
+// detectImageType sniff the content type of uploaded image and check it's allowed
+func detectImageType(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", constants.ErrInvalidImage
+	}
+	if len(data) > constants.ImageMaxSize {
+		return "", constants.ErrImageTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	if _, ok := constants.ImageExtensions[contentType]; !ok {
+		return "", constants.ErrUnsupportedImageType
+	}
+	return contentType, nil
+}
+
 type LikePostReq struct {
 	PostID string
 	UserID string
+	ClientIP string
 }
 
 type LikePostRes struct {
@@ -205,40 +579,713 @@ func (req LikePostReq) Validate() error {
 	return nil
 }
 
-type LogoutReq struct {
-	UserID    string
-	PushToken string
+type GetScheduledPostListReq struct {
+	UserID     string
+	Pagination PaginationReq
 }
 
-type LogoutRes struct {
-	Message string
+type GetScheduledPostListRes struct {
+	PostList   []entity.Post
+	Pagination PaginationRes
 }
 
-func (req LogoutReq) Validate() error {
+func (req *GetScheduledPostListReq) Validate() error {
 	if req.UserID == "" {
 		return constants.ErrInvalidUserID
 	}
-	if req.PushToken == "" {
-		return constants.ErrInvalidPushToken
+	if req.Pagination.First < 1 {
+		req.Pagination.First = 20
 	}
 	return nil
 }
 
-type FollowUserReq struct {
+type CancelScheduledPostReq struct {
+	PostID string
 	UserID string
-	FollowedID string
 }
 
-type FollowUserRes struct {
+type CancelScheduledPostRes struct {
 	Message string
 }
 
-func (req FollowUserReq) Validate() error {
+func (req CancelScheduledPostReq) Validate() error {
+	if req.PostID == "" {
+		return constants.ErrInvalidPostID
+	}
 	if req.UserID == "" {
 		return constants.ErrInvalidUserID
 	}
-	if req.FollowedID == "" {
-		return constants.ErrInvalidFollowedID
+	return nil
+}
+
+type PublishScheduledPostReq struct {
+	Now int64
+}
+
+type PublishScheduledPostRes struct {
+	Message string
+}
+
+func (req *PublishScheduledPostReq) Validate() error {
+	if req.Now == 0 {
+		req.Now = time.Now().Unix()
+	}
+	return nil
+}
+
+type DeleteExpiredPostReq struct {
+	Now int64
+}
+
+type DeleteExpiredPostRes struct {
+	Message string
+}
+
+func (req *DeleteExpiredPostReq) Validate() error {
+	if req.Now == 0 {
+		req.Now = time.Now().Unix()
+	}
+	return nil
+}
+
+type FetchLinkPreviewsReq struct {
+	// Limit is how many post processed in a run
+	Limit int
+	Now   int64
+}
+
+type FetchLinkPreviewsRes struct {
+	Message string
+}
+
+func (req *FetchLinkPreviewsReq) Validate() error {
+	if req.Limit < 1 {
+		req.Limit = constants.LinkPreviewBatchSize
+	}
+	if req.Now == 0 {
+		req.Now = time.Now().Unix()
+	}
+	return nil
+}
+
+type SetContentWarningsReq struct {
+	PostID          string
+	UserID          string
+	// ContentWarnings replace the current categories, empty remove all of them
+	ContentWarnings []string
+}
+
+type SetContentWarningsRes struct {
+	Message string
+}
+
+func (req SetContentWarningsReq) Validate() error {
+	if req.PostID == "" {
+		return constants.ErrInvalidPostID
+	}
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if err := validateContentWarnings(req.ContentWarnings); err != nil {
+		return err
+	}
+	return nil
+}
+
+type VotePollReq struct {
+	PostID    string
+	UserID    string
+	OptionIDs []string
+}
+
+type VotePollRes struct {
+	Message string
+}
+
+func (req VotePollReq) Validate() error {
+	if req.PostID == "" {
+		return constants.ErrInvalidPostID
+	}
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if len(req.OptionIDs) == 0 {
+		return constants.ErrInvalidPollOption
+	}
+	return nil
+}
+
+type RepostPostReq struct {
+	PostID string
+	UserID string
+}
+
+type RepostPostRes struct {
+	Message string
+}
+
+func (req RepostPostReq) Validate() error {
+	if req.PostID == "" {
+		return constants.ErrInvalidPostID
+	}
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	return nil
+}
+
+type BookmarkPostReq struct {
+	PostID string
+	UserID string
+}
+
+type BookmarkPostRes struct {
+	Message string
+}
+
+func (req BookmarkPostReq) Validate() error {
+	if req.PostID == "" {
+		return constants.ErrInvalidPostID
+	}
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	return nil
+}
+
+type GetBookmarkListReq struct {
+	UserID     string
+	Pagination PaginationReq
+}
+
+type GetBookmarkListRes struct {
+	PostList   []entity.Post
+	Pagination PaginationRes
+}
+
+func (req *GetBookmarkListReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.Pagination.First < 1 {
+		req.Pagination.First = 20
+	}
+	return nil
+}
+
+type LogoutReq struct {
+	UserID    string
+	PushToken string
+}
+
+type LogoutRes struct {
+	Message string
+}
+
+func (req LogoutReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.PushToken == "" {
+		return constants.ErrInvalidPushToken
+	}
+	return nil
+}
+
+type FollowUserReq struct {
+	UserID string
+	FollowedID string
+	ClientIP   string
+}
+
+type FollowUserRes struct {
+	Message string
+}
+
+func (req FollowUserReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.FollowedID == "" {
+		return constants.ErrInvalidFollowedID
+	}
+	if req.FollowedID == req.UserID {
+		return constants.ErrFollowSelf
+	}
+	return nil
+}
+
+type BlockReq struct {
+	UserID    string
+	BlockedID string
+}
+
+type BlockRes struct {
+	Message string
+}
+
+func (req BlockReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.BlockedID == "" {
+		return constants.ErrInvalidID
+	}
+	if req.BlockedID == req.UserID {
+		return constants.ErrBlockSelf
+	}
+	return nil
+}
+
+type UnblockReq struct {
+	UserID    string
+	BlockedID string
+}
+
+type UnblockRes struct {
+	Message string
+}
+
+func (req UnblockReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.BlockedID == "" {
+		return constants.ErrInvalidID
+	}
+	return nil
+}
+
+type MuteReq struct {
+	UserID  string
+	MutedID string
+}
+
+type MuteRes struct {
+	Message string
+}
+
+func (req MuteReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.MutedID == "" {
+		return constants.ErrInvalidID
+	}
+	if req.MutedID == req.UserID {
+		return constants.ErrMuteSelf
+	}
+	return nil
+}
+
+type UnmuteReq struct {
+	UserID  string
+	MutedID string
+}
+
+type UnmuteRes struct {
+	Message string
+}
+
+func (req UnmuteReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.MutedID == "" {
+		return constants.ErrInvalidID
+	}
+	return nil
+}
+
+type MuteWordReq struct {
+	UserID    string
+	Word      string
+	// ExpiresIn is how long in second the word is muted, 0 mean forever
+	ExpiresIn int64
+}
+
+type MuteWordRes struct {
+	Message string
+}
+
+func (req *MuteWordReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	req.Word = strings.TrimSpace(req.Word)
+	if utils.NormalizeText(req.Word) == "" || len([]rune(req.Word)) > constants.MutedWordMaxLength {
+		return constants.ErrInvalidMutedWord
+	}
+	if req.ExpiresIn < 0 || req.ExpiresIn > constants.MutedWordMaxDuration {
+		return constants.ErrInvalidExpiresIn
+	}
+	return nil
+}
+
+type UnmuteWordReq struct {
+	UserID string
+	Word   string
+}
+
+type UnmuteWordRes struct {
+	Message string
+}
+
+func (req UnmuteWordReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if utils.NormalizeText(req.Word) == "" {
+		return constants.ErrInvalidMutedWord
+	}
+	return nil
+}
+
+type GetMutedWordListReq struct {
+	UserID string
+}
+
+type GetMutedWordListRes struct {
+	MutedWordList []entity.MutedWord
+}
+
+func (req GetMutedWordListReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	return nil
+}
+
+type FollowReq struct {
+	UserID     string
+	FollowedID string
+	ClientIP   string
+}
+
+type FollowRes struct {
+	Message string
+}
+
+func (req FollowReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.FollowedID == "" {
+		return constants.ErrInvalidFollowedID
+	}
+	if req.FollowedID == req.UserID {
+		return constants.ErrFollowSelf
+	}
+	return nil
+}
+
+type UnfollowReq struct {
+	UserID     string
+	FollowedID string
+}
+
+type UnfollowRes struct {
+	Message string
+}
+
+func (req UnfollowReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.FollowedID == "" {
+		return constants.ErrInvalidFollowedID
+	}
+	return nil
+}
+
+type TrendingReq struct {
+	UserID string
+	Window int
+	First  int
+}
+
+type TrendingRes struct {
+	PostList []entity.Post
+}
+
+func (req *TrendingReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.Window == 0 {
+		req.Window = constants.TrendingWindowDefault
+	}
+	if !isValidTrendingWindow(req.Window) {
+		return constants.ErrInvalidTrendingWindow
+	}
+	if req.First < 1 || req.First > constants.TrendingLimit {
+		req.First = constants.TrendingLimit
+	}
+	return nil
+}
+
+type TrendingTopicsReq struct {
+	Window int
+	First  int
+}
+
+type TrendingTopicsRes struct {
+	Topics []entity.Topic
+}
+
+func (req *TrendingTopicsReq) Validate() error {
+	if req.Window == 0 {
+		req.Window = constants.TrendingWindowDefault
+	}
+	if !isValidTrendingWindow(req.Window) {
+		return constants.ErrInvalidTrendingWindow
+	}
+	if req.First < 1 || req.First > constants.TrendingLimit {
+		req.First = constants.TrendingLimit
+	}
+	return nil
+}
+
+type RefreshTrendingReq struct {
+	Now int64
+}
+
+type RefreshTrendingRes struct {
+	Message string
+}
+
+func (req *RefreshTrendingReq) Validate() error {
+	if req.Now == 0 {
+		req.Now = time.Now().Unix()
+	}
+	return nil
+}
+
+func isValidTrendingWindow(window int) bool {
+	for _, v := range constants.TrendingWindows {
+		if window == v {
+			return true
+		}
+	}
+	return false
+}
+
+type ReportPostReq struct {
+	UserID  string
+	PostID  string
+	Reason  string
+	Details string
+}
+
+type ReportPostRes struct {
+	Message string
+}
+
+func (req *ReportPostReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.PostID == "" {
+		return constants.ErrInvalidPostID
+	}
+	if !isValidReportReason(req.Reason) {
+		return constants.ErrInvalidReportReason
+	}
+	req.Details = strings.TrimSpace(req.Details)
+	if len([]rune(req.Details)) > constants.ReportDetailsMaxLength {
+		return constants.ErrInvalidReportDetails
+	}
+	return nil
+}
+
+type GetModerationQueueReq struct {
+	UserID     string
+	Pagination PaginationReq
+}
+
+type GetModerationQueueRes struct {
+	ReportList []entity.Report
+	// ReportsCount is the number of pending report on each post, keyed by the post id
+	ReportsCount map[string]int
+	Pagination   PaginationRes
+}
+
+func (req *GetModerationQueueReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.Pagination.First < 1 {
+		req.Pagination.First = 20
+	}
+	return nil
+}
+
+type ResolveReportReq struct {
+	UserID   string
+	ReportID string
+	Action   string
+	// SuspendDuration is how long in second the user suspended by suspend_user action,
+	// constants.SuspendDurationDefault if not set
+	SuspendDuration int64
+	// Reason is recorded in the audit log, the report reason if not set
+	Reason          string
+}
+
+type ResolveReportRes struct {
+	Message string
+}
+
+func (req *ResolveReportReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.ReportID == "" {
+		return constants.ErrInvalidReportID
+	}
+	if !isValidReportAction(req.Action) {
+		return constants.ErrInvalidReportAction
+	}
+	if req.SuspendDuration < 0 || req.SuspendDuration > constants.SuspendMaxDuration {
+		return constants.ErrInvalidSuspendDuration
+	}
+	if req.Action == constants.ReportActionSuspendUser && req.SuspendDuration == 0 {
+		req.SuspendDuration = constants.SuspendDurationDefault
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if len([]rune(req.Reason)) > constants.ModerateReasonMaxLength {
+		return constants.ErrInvalidModerateReason
+	}
+	return nil
+}
+
+func isValidReportReason(reason string) bool {
+	for _, v := range constants.ReportReasons {
+		if reason == v {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidReportAction(action string) bool {
+	for _, v := range constants.ReportActions {
+		if action == v {
+			return true
+		}
+	}
+	return false
+}
+
+type CheckAccountStatusReq struct {
+	UserID string
+}
+
+type CheckAccountStatusRes struct {
+	Message string
+}
+
+func (req CheckAccountStatusReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	return nil
+}
+
+type ModerateUserReq struct {
+	UserID   string
+	TargetID string
+	Action   string
+	// Reason is shown to the user and recorded in the audit log
+	Reason   string
+	// Duration is how long in second the user suspended, constants.SuspendDurationDefault if not set
+	Duration int64
+}
+
+type ModerateUserRes struct {
+	Message string
+}
+
+func (req *ModerateUserReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if req.TargetID == "" {
+		return constants.ErrInvalidTargetID
+	}
+	if !isValidModerateAction(req.Action) {
+		return constants.ErrInvalidModerateAction
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" || len([]rune(req.Reason)) > constants.ModerateReasonMaxLength {
+		return constants.ErrInvalidModerateReason
+	}
+	if req.Duration < 0 || req.Duration > constants.SuspendMaxDuration {
+		return constants.ErrInvalidSuspendDuration
+	}
+	if req.Action == constants.ModerateActionSuspend && req.Duration == 0 {
+		req.Duration = constants.SuspendDurationDefault
+	}
+	return nil
+}
+
+func isValidModerateAction(action string) bool {
+	for _, v := range constants.ModerateActions {
+		if action == v {
+			return true
+		}
+	}
+	return false
+}
+
+type GetAuditLogReq struct {
+	UserID     string
+	Filter     entity.AuditLogFilter
+	Pagination PaginationReq
+}
+
+type GetAuditLogRes struct {
+	AuditLogList []entity.AuditLog
+	Pagination   PaginationRes
+}
+
+func (req *GetAuditLogReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	if err := validateAuditLogFilter(req.Filter); err != nil {
+		return err
+	}
+	if req.Pagination.First < 1 {
+		req.Pagination.First = 20
+	}
+	return nil
+}
+
+type ExportAuditLogReq struct {
+	UserID string
+	Filter entity.AuditLogFilter
+}
+
+type ExportAuditLogRes struct {
+	// AuditLogList is every log matching the filter, newest first
+	AuditLogList []entity.AuditLog
+}
+
+func (req *ExportAuditLogReq) Validate() error {
+	if req.UserID == "" {
+		return constants.ErrInvalidUserID
+	}
+	return validateAuditLogFilter(req.Filter)
+}
+
+func validateAuditLogFilter(filter entity.AuditLogFilter) error {
+	switch filter.TargetType {
+	case "", constants.AuditTargetUser, constants.AuditTargetPost, constants.AuditTargetAvatar:
+	default:
+		return constants.ErrInvalidAuditLogFilter
+	}
+	if filter.From < 0 || filter.To < 0 || (filter.To != 0 && filter.From > filter.To) {
+		return constants.ErrInvalidAuditLogFilter
 	}
 	return nil
 }
@@ -1,6 +1,12 @@
 package api
 
-import "testing"
+import (
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/aeramu/menfess-backend/entity"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestLoginReq_Validate(t *testing.T) {
 	type fields struct {
@@ -334,10 +340,14 @@ func TestGetPostListReq_Validate(t *testing.T) {
 
 func TestCreatePostReq_Validate(t *testing.T) {
 	type fields struct {
-		Body     string
-		UserID   string
-		AuthorID string
-		ParentID string
+		Body         string
+		UserID       string
+		AuthorID     string
+		ParentID     string
+		QuotedPostID string
+		PublishAt    int64
+		ExpiresIn    int64
+		ContentWarnings []string
 	}
 	tests := []struct {
 		name    string
@@ -374,14 +384,140 @@ func TestCreatePostReq_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "reply quoting post",
+			fields:  fields{
+				Body:         "body",
+				UserID:       "user-id",
+				AuthorID:     "author-id",
+				ParentID:     "parent-id",
+				QuotedPostID: "quoted-post-id",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "publish time in the past",
+			fields:  fields{
+				Body:      "body",
+				UserID:    "user-id",
+				AuthorID:  "author-id",
+				PublishAt: time.Now().Unix() - 60,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "publish time too far",
+			fields:  fields{
+				Body:      "body",
+				UserID:    "user-id",
+				AuthorID:  "author-id",
+				PublishAt: time.Now().Unix() + 365*24*3600,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "scheduled reply",
+			fields:  fields{
+				Body:      "body",
+				UserID:    "user-id",
+				AuthorID:  "author-id",
+				ParentID:  "parent-id",
+				PublishAt: time.Now().Unix() + 3600,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "success scheduled post",
+			fields:  fields{
+				Body:      "body",
+				UserID:    "user-id",
+				AuthorID:  "author-id",
+				PublishAt: time.Now().Unix() + 3600,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "expiry too short",
+			fields:  fields{
+				Body:      "body",
+				UserID:    "user-id",
+				AuthorID:  "author-id",
+				ExpiresIn: 10,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "expiry too long",
+			fields:  fields{
+				Body:      "body",
+				UserID:    "user-id",
+				AuthorID:  "author-id",
+				ExpiresIn: 365 * 24 * 3600,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "success expiring post",
+			fields:  fields{
+				Body:      "body",
+				UserID:    "user-id",
+				AuthorID:  "author-id",
+				ExpiresIn: 3600,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "unknown content warning",
+			fields:  fields{
+				Body:            "body",
+				UserID:          "user-id",
+				AuthorID:        "author-id",
+				ContentWarnings: []string{"gore"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "repeated content warning",
+			fields:  fields{
+				Body:            "body",
+				UserID:          "user-id",
+				AuthorID:        "author-id",
+				ContentWarnings: []string{constants.ContentWarningNSFW, constants.ContentWarningNSFW},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "success post with content warnings",
+			fields:  fields{
+				Body:            "body",
+				UserID:          "user-id",
+				AuthorID:        "author-id",
+				ContentWarnings: []string{constants.ContentWarningSelfHarm, constants.ContentWarningSpoiler},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "success quote post",
+			fields:  fields{
+				Body:         "body",
+				UserID:       "user-id",
+				AuthorID:     "author-id",
+				QuotedPostID: "quoted-post-id",
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			req := CreatePostReq{
-				Body:     tt.fields.Body,
-				UserID:   tt.fields.UserID,
-				AuthorID: tt.fields.AuthorID,
-				ParentID: tt.fields.ParentID,
+				Body:         tt.fields.Body,
+				UserID:       tt.fields.UserID,
+				AuthorID:     tt.fields.AuthorID,
+				ParentID:     tt.fields.ParentID,
+				QuotedPostID: tt.fields.QuotedPostID,
+				PublishAt:    tt.fields.PublishAt,
+				ExpiresIn:    tt.fields.ExpiresIn,
+				ContentWarnings: tt.fields.ContentWarnings,
 			}
 			if err := req.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -528,4 +664,1096 @@ func TestFeedReq_Validate(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestTrendingReq_Validate(t *testing.T) {
+	type fields struct {
+		UserID string
+		Window int
+		First  int
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				UserID: "id",
+				Window: 6,
+				First:  10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "default window",
+			fields: fields{
+				UserID: "id",
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty user id",
+			fields: fields{
+				Window: 6,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid window",
+			fields: fields{
+				UserID: "id",
+				Window: 5,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := TrendingReq{
+				UserID: tt.fields.UserID,
+				Window: tt.fields.Window,
+				First:  tt.fields.First,
+			}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTrendingTopicsReq_Validate(t *testing.T) {
+	type fields struct {
+		Window int
+		First  int
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				Window: 72,
+				First:  10,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "default window",
+			fields:  fields{},
+			wantErr: false,
+		},
+		{
+			name: "invalid window",
+			fields: fields{
+				Window: -1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := TrendingTopicsReq{
+				Window: tt.fields.Window,
+				First:  tt.fields.First,
+			}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBookmarkPostReq_Validate(t *testing.T) {
+	type fields struct {
+		PostID string
+		UserID string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				PostID: "post-id",
+				UserID: "user-id",
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty post id",
+			fields: fields{
+				UserID: "user-id",
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty user id",
+			fields: fields{
+				PostID: "post-id",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := BookmarkPostReq{
+				PostID: tt.fields.PostID,
+				UserID: tt.fields.UserID,
+			}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetBookmarkListReq_Validate(t *testing.T) {
+	type fields struct {
+		UserID     string
+		Pagination PaginationReq
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				UserID: "user-id",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "empty user id",
+			fields:  fields{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := GetBookmarkListReq{
+				UserID:     tt.fields.UserID,
+				Pagination: tt.fields.Pagination,
+			}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRepostPostReq_Validate(t *testing.T) {
+	type fields struct {
+		PostID string
+		UserID string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				PostID: "post-id",
+				UserID: "user-id",
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty post id",
+			fields: fields{
+				UserID: "user-id",
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty user id",
+			fields: fields{
+				PostID: "post-id",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := RepostPostReq{
+				PostID: tt.fields.PostID,
+				UserID: tt.fields.UserID,
+			}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPollReq_Validate(t *testing.T) {
+	type fields struct {
+		Options        []string
+		MultipleChoice bool
+		ExpiresIn      int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				Options:   []string{"yes", " no "},
+				ExpiresIn: 3600,
+			},
+			wantErr: false,
+		},
+		{
+			name: "too few options",
+			fields: fields{
+				Options: []string{"yes"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "too many options",
+			fields: fields{
+				Options: []string{"a", "b", "c", "d", "e", "f", "g"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty option",
+			fields: fields{
+				Options: []string{"yes", "  "},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate option",
+			fields: fields{
+				Options: []string{"Yes", "yes"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative expiry",
+			fields: fields{
+				Options:   []string{"yes", "no"},
+				ExpiresIn: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "expiry too long",
+			fields: fields{
+				Options:   []string{"yes", "no"},
+				ExpiresIn: 30 * 24 * 3600,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := PollReq{
+				Options:        tt.fields.Options,
+				MultipleChoice: tt.fields.MultipleChoice,
+				ExpiresIn:      tt.fields.ExpiresIn,
+			}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVotePollReq_Validate(t *testing.T) {
+	type fields struct {
+		PostID    string
+		UserID    string
+		OptionIDs []string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				PostID:    "post-id",
+				UserID:    "user-id",
+				OptionIDs: []string{"1"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty post id",
+			fields: fields{
+				UserID:    "user-id",
+				OptionIDs: []string{"1"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty user id",
+			fields: fields{
+				PostID:    "post-id",
+				OptionIDs: []string{"1"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty option",
+			fields: fields{
+				PostID: "post-id",
+				UserID: "user-id",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := VotePollReq{
+				PostID:    tt.fields.PostID,
+				UserID:    tt.fields.UserID,
+				OptionIDs: tt.fields.OptionIDs,
+			}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetScheduledPostListReq_Validate(t *testing.T) {
+	type fields struct {
+		UserID     string
+		Pagination PaginationReq
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				UserID: "user-id",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "empty user id",
+			fields:  fields{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := GetScheduledPostListReq{
+				UserID:     tt.fields.UserID,
+				Pagination: tt.fields.Pagination,
+			}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCancelScheduledPostReq_Validate(t *testing.T) {
+	type fields struct {
+		PostID string
+		UserID string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				PostID: "post-id",
+				UserID: "user-id",
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty post id",
+			fields: fields{
+				UserID: "user-id",
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty user id",
+			fields: fields{
+				PostID: "post-id",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := CancelScheduledPostReq{
+				PostID: tt.fields.PostID,
+				UserID: tt.fields.UserID,
+			}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+func TestUploadImageReq_Validate(t *testing.T) {
+	type fields struct {
+		UserID string
+		Data   []byte
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name:    "empty user id",
+			fields:  fields{
+				UserID: "",
+				Data:   []byte("\x89PNG\r\n\x1a\n"),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "empty data",
+			fields:  fields{
+				UserID: "user-id",
+				Data:   nil,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "too large",
+			fields:  fields{
+				UserID: "user-id",
+				Data:   append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, constants.ImageMaxSize)...),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "not an image",
+			fields:  fields{
+				UserID: "user-id",
+				Data:   []byte("<html></html>"),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			fields:  fields{
+				UserID: "user-id",
+				Data:   []byte("\x89PNG\r\n\x1a\n"),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := UploadImageReq{
+				UserID: tt.fields.UserID,
+				Data:   tt.fields.Data,
+			}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddAvatarReq_Validate(t *testing.T) {
+	type fields struct {
+		UserID string
+		URL    string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name:    "empty user id",
+			fields:  fields{
+				UserID: "",
+				URL:    "https://example.com/avatar.jpg",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "not an url",
+			fields:  fields{
+				UserID: "user-id",
+				URL:    "avatar",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "non http url",
+			fields:  fields{
+				UserID: "user-id",
+				URL:    "javascript:alert(1)",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			fields:  fields{
+				UserID: "user-id",
+				URL:    " https://example.com/avatar.jpg ",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := AddAvatarReq{
+				UserID: tt.fields.UserID,
+				URL:    tt.fields.URL,
+			}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateSettingsReq_Validate(t *testing.T) {
+	type fields struct {
+		UserID           string
+		SensitiveContent string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name:    "empty user id",
+			fields:  fields{
+				UserID:           "",
+				SensitiveContent: constants.SensitiveContentBlur,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unknown setting",
+			fields:  fields{
+				UserID:           "user-id",
+				SensitiveContent: "",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			fields:  fields{
+				UserID:           "user-id",
+				SensitiveContent: constants.SensitiveContentHide,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := UpdateSettingsReq{
+				UserID:           tt.fields.UserID,
+				SensitiveContent: tt.fields.SensitiveContent,
+			}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetHandleReq_Validate(t *testing.T) {
+	type fields struct {
+		UserID string
+		Handle string
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		wantHandle string
+		wantErr    error
+	}{
+		{
+			name:    "empty user id",
+			fields:  fields{
+				UserID: "",
+				Handle: "handle",
+			},
+			wantErr: constants.ErrInvalidUserID,
+		},
+		{
+			name:    "too short",
+			fields:  fields{
+				UserID: "user-id",
+				Handle: "ab",
+			},
+			wantErr: constants.ErrInvalidHandle,
+		},
+		{
+			name:    "too long",
+			fields:  fields{
+				UserID: "user-id",
+				Handle: "abcdefghijklmnopqrstu",
+			},
+			wantErr: constants.ErrInvalidHandle,
+		},
+		{
+			name:    "invalid character",
+			fields:  fields{
+				UserID: "user-id",
+				Handle: "john.doe",
+			},
+			wantErr: constants.ErrInvalidHandle,
+		},
+		{
+			name:    "reserved",
+			fields:  fields{
+				UserID: "user-id",
+				Handle: "Admin",
+			},
+			wantErr: constants.ErrHandleReserved,
+		},
+		{
+			name:    "reserved decorated with digits",
+			fields:  fields{
+				UserID: "user-id",
+				Handle: "_menfess_01",
+			},
+			wantErr: constants.ErrHandleReserved,
+		},
+		{
+			name:       "success normalized",
+			fields:     fields{
+				UserID: "user-id",
+				Handle: " @John_Doe ",
+			},
+			wantHandle: "john_doe",
+			wantErr:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := SetHandleReq{
+				UserID: tt.fields.UserID,
+				Handle: tt.fields.Handle,
+			}
+			err := req.Validate()
+			if err != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && req.Handle != tt.wantHandle {
+				t.Errorf("Validate() handle = %v, want %v", req.Handle, tt.wantHandle)
+			}
+		})
+	}
+}
+
+func TestMuteWordReq_Validate(t *testing.T) {
+	type fields struct {
+		UserID    string
+		Word      string
+		ExpiresIn int64
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		wantWord string
+		wantErr  error
+	}{
+		{
+			name:    "empty user id",
+			fields:  fields{
+				UserID: "",
+				Word:   "spoiler",
+			},
+			wantErr: constants.ErrInvalidUserID,
+		},
+		{
+			name:    "only punctuation",
+			fields:  fields{
+				UserID: "user-id",
+				Word:   " ?! ",
+			},
+			wantErr: constants.ErrInvalidMutedWord,
+		},
+		{
+			name:    "too long",
+			fields:  fields{
+				UserID: "user-id",
+				Word:   strings.Repeat("a", constants.MutedWordMaxLength+1),
+			},
+			wantErr: constants.ErrInvalidMutedWord,
+		},
+		{
+			name:    "negative expires in",
+			fields:  fields{
+				UserID:    "user-id",
+				Word:      "spoiler",
+				ExpiresIn: -1,
+			},
+			wantErr: constants.ErrInvalidExpiresIn,
+		},
+		{
+			name:    "expires in too long",
+			fields:  fields{
+				UserID:    "user-id",
+				Word:      "spoiler",
+				ExpiresIn: constants.MutedWordMaxDuration + 1,
+			},
+			wantErr: constants.ErrInvalidExpiresIn,
+		},
+		{
+			name:     "success trimmed",
+			fields:   fields{
+				UserID:    "user-id",
+				Word:      "  spoiler endgame ",
+				ExpiresIn: 3600,
+			},
+			wantWord: "spoiler endgame",
+			wantErr:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := MuteWordReq{
+				UserID:    tt.fields.UserID,
+				Word:      tt.fields.Word,
+				ExpiresIn: tt.fields.ExpiresIn,
+			}
+			err := req.Validate()
+			if err != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && req.Word != tt.wantWord {
+				t.Errorf("Validate() word = %v, want %v", req.Word, tt.wantWord)
+			}
+		})
+	}
+}
+
+func TestReportPostReq_Validate(t *testing.T) {
+	type fields struct {
+		UserID  string
+		PostID  string
+		Reason  string
+		Details string
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		wantDetails string
+		wantErr     error
+	}{
+		{
+			name:    "empty user id",
+			fields:  fields{
+				PostID: "post-id",
+				Reason: constants.ReportReasonSpam,
+			},
+			wantErr: constants.ErrInvalidUserID,
+		},
+		{
+			name:    "empty post id",
+			fields:  fields{
+				UserID: "user-id",
+				Reason: constants.ReportReasonSpam,
+			},
+			wantErr: constants.ErrInvalidPostID,
+		},
+		{
+			name:    "unknown reason",
+			fields:  fields{
+				UserID: "user-id",
+				PostID: "post-id",
+				Reason: "boring",
+			},
+			wantErr: constants.ErrInvalidReportReason,
+		},
+		{
+			name:    "details too long",
+			fields:  fields{
+				UserID:  "user-id",
+				PostID:  "post-id",
+				Reason:  constants.ReportReasonOther,
+				Details: strings.Repeat("a", constants.ReportDetailsMaxLength+1),
+			},
+			wantErr: constants.ErrInvalidReportDetails,
+		},
+		{
+			name:        "success trimmed",
+			fields:      fields{
+				UserID:  "user-id",
+				PostID:  "post-id",
+				Reason:  constants.ReportReasonPersonalData,
+				Details: "  it has my phone number ",
+			},
+			wantDetails: "it has my phone number",
+			wantErr:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := ReportPostReq{
+				UserID:  tt.fields.UserID,
+				PostID:  tt.fields.PostID,
+				Reason:  tt.fields.Reason,
+				Details: tt.fields.Details,
+			}
+			err := req.Validate()
+			if err != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && req.Details != tt.wantDetails {
+				t.Errorf("Validate() details = %v, want %v", req.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestResolveReportReq_Validate(t *testing.T) {
+	type fields struct {
+		UserID          string
+		ReportID        string
+		Action          string
+		SuspendDuration int64
+		Reason          string
+	}
+	tests := []struct {
+		name                string
+		fields              fields
+		wantSuspendDuration int64
+		wantErr             error
+	}{
+		{
+			name:    "empty report id",
+			fields:  fields{
+				UserID: "user-id",
+				Action: constants.ReportActionDismiss,
+			},
+			wantErr: constants.ErrInvalidReportID,
+		},
+		{
+			name:    "unknown action",
+			fields:  fields{
+				UserID:   "user-id",
+				ReportID: "report-id",
+				Action:   "ban",
+			},
+			wantErr: constants.ErrInvalidReportAction,
+		},
+		{
+			name:    "suspend duration too long",
+			fields:  fields{
+				UserID:          "user-id",
+				ReportID:        "report-id",
+				Action:          constants.ReportActionSuspendUser,
+				SuspendDuration: constants.SuspendMaxDuration + 1,
+			},
+			wantErr: constants.ErrInvalidSuspendDuration,
+		},
+		{
+			name:                "suspend with default duration",
+			fields:              fields{
+				UserID:   "user-id",
+				ReportID: "report-id",
+				Action:   constants.ReportActionSuspendUser,
+			},
+			wantSuspendDuration: constants.SuspendDurationDefault,
+			wantErr:             nil,
+		},
+		{
+			name:    "reason too long",
+			fields:  fields{
+				UserID:   "user-id",
+				ReportID: "report-id",
+				Action:   constants.ReportActionHidePost,
+				Reason:   strings.Repeat("a", constants.ModerateReasonMaxLength+1),
+			},
+			wantErr: constants.ErrInvalidModerateReason,
+		},
+		{
+			name:                "other action keep no duration",
+			fields:              fields{
+				UserID:   "user-id",
+				ReportID: "report-id",
+				Action:   constants.ReportActionHidePost,
+			},
+			wantSuspendDuration: 0,
+			wantErr:             nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := ResolveReportReq{
+				UserID:          tt.fields.UserID,
+				ReportID:        tt.fields.ReportID,
+				Action:          tt.fields.Action,
+				SuspendDuration: tt.fields.SuspendDuration,
+				Reason:          tt.fields.Reason,
+			}
+			err := req.Validate()
+			if err != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && req.SuspendDuration != tt.wantSuspendDuration {
+				t.Errorf("Validate() suspend duration = %v, want %v", req.SuspendDuration, tt.wantSuspendDuration)
+			}
+		})
+	}
+}
+
+func TestModerateUserReq_Validate(t *testing.T) {
+	type fields struct {
+		UserID   string
+		TargetID string
+		Action   string
+		Reason   string
+		Duration int64
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		wantDuration int64
+		wantErr      error
+	}{
+		{
+			name:    "empty target id",
+			fields:  fields{
+				UserID: "admin-id",
+				Action: constants.ModerateActionBan,
+				Reason: "spam",
+			},
+			wantErr: constants.ErrInvalidTargetID,
+		},
+		{
+			name:    "unknown action",
+			fields:  fields{
+				UserID:   "admin-id",
+				TargetID: "target-id",
+				Action:   "kick",
+				Reason:   "spam",
+			},
+			wantErr: constants.ErrInvalidModerateAction,
+		},
+		{
+			name:    "blank reason",
+			fields:  fields{
+				UserID:   "admin-id",
+				TargetID: "target-id",
+				Action:   constants.ModerateActionLift,
+				Reason:   "   ",
+			},
+			wantErr: constants.ErrInvalidModerateReason,
+		},
+		{
+			name:    "negative duration",
+			fields:  fields{
+				UserID:   "admin-id",
+				TargetID: "target-id",
+				Action:   constants.ModerateActionSuspend,
+				Reason:   "spam",
+				Duration: -1,
+			},
+			wantErr: constants.ErrInvalidSuspendDuration,
+		},
+		{
+			name:         "suspend with default duration",
+			fields:       fields{
+				UserID:   "admin-id",
+				TargetID: "target-id",
+				Action:   constants.ModerateActionSuspend,
+				Reason:   "spam",
+			},
+			wantDuration: constants.SuspendDurationDefault,
+			wantErr:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := ModerateUserReq{
+				UserID:   tt.fields.UserID,
+				TargetID: tt.fields.TargetID,
+				Action:   tt.fields.Action,
+				Reason:   tt.fields.Reason,
+				Duration: tt.fields.Duration,
+			}
+			err := req.Validate()
+			if err != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && req.Duration != tt.wantDuration {
+				t.Errorf("Validate() duration = %v, want %v", req.Duration, tt.wantDuration)
+			}
+		})
+	}
+}
+
+func TestGetAuditLogReq_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       GetAuditLogReq
+		wantFirst int
+		wantErr   error
+	}{
+		{
+			name:    "empty user id",
+			req:     GetAuditLogReq{},
+			wantErr: constants.ErrInvalidUserID,
+		},
+		{
+			name:    "unknown target type",
+			req:     GetAuditLogReq{
+				UserID: "admin-id",
+				Filter: entity.AuditLogFilter{TargetType: "report"},
+			},
+			wantErr: constants.ErrInvalidAuditLogFilter,
+		},
+		{
+			name:    "from after to",
+			req:     GetAuditLogReq{
+				UserID: "admin-id",
+				Filter: entity.AuditLogFilter{From: 200, To: 100},
+			},
+			wantErr: constants.ErrInvalidAuditLogFilter,
+		},
+		{
+			name:      "only from with default page size",
+			req:       GetAuditLogReq{
+				UserID: "admin-id",
+				Filter: entity.AuditLogFilter{TargetType: constants.AuditTargetPost, From: 200},
+			},
+			wantFirst: 20,
+			wantErr:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if err != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && tt.req.Pagination.First != tt.wantFirst {
+				t.Errorf("Validate() first = %v, want %v", tt.req.Pagination.First, tt.wantFirst)
+			}
+		})
+	}
+}
@@ -12,6 +12,18 @@ type Adapter struct {
 	AuthModule         AuthModule
 	NotificationModule NotificationModule
 	LogModule          LogModule
+	TrendingModule     TrendingModule
+	BookmarkModule     BookmarkModule
+	StorageModule      StorageModule
+	AttachmentModule   AttachmentModule
+	MediaModule        MediaModule
+	AvatarModule       AvatarModule
+	LinkPreviewModule  LinkPreviewModule
+	ReportModule       ReportModule
+	AuditModule        AuditModule
+	ModerationModule   ModerationModule
+	RedactionModule    RedactionModule
+	RateLimitModule    RateLimitModule
 }
 
 type AuthModule interface {
@@ -23,34 +35,198 @@ type AuthModule interface {
 type UserModule interface {
 	FindUserByID(ctx context.Context, id string) (*entity.User, error)
 	FindUserByEmail(ctx context.Context, email string) (*entity.User, error)
+	FindUserByHandle(ctx context.Context, handle string) (*entity.User, error)
+	FindUserListByHandles(ctx context.Context, handles []string) ([]entity.User, error)
+	FindUserListByIDs(ctx context.Context, ids []string) ([]entity.User, error)
 	InsertUser(ctx context.Context, user entity.User) (string, error)
 	SaveProfile(ctx context.Context, user entity.User) error
+	SaveSettings(ctx context.Context, user entity.User) error
+	// SaveHandle return ErrHandleTaken if other user already has the handle
+	SaveHandle(ctx context.Context, user entity.User) error
+	// SaveSuspension set the user suspension end and reason
+	SaveSuspension(ctx context.Context, user entity.User) error
+	// SaveBan set the user ban time and reason
+	SaveBan(ctx context.Context, user entity.User) error
+	// SaveShadowban set the user shadowban time, the posts and likes are updated by PostModule.UpdateShadowbanStatus
+	SaveShadowban(ctx context.Context, user entity.User) error
+	// BanDevices ban the push tokens, so the device can't register or login to other account
+	BanDevices(ctx context.Context, userID string, pushTokens []string, reason string) error
+	UnbanDevices(ctx context.Context, userID string) error
+	// FindBannedDevice return ErrBannedDeviceNotFound if the push token isn't banned
+	FindBannedDevice(ctx context.Context, pushToken string) (*entity.BannedDevice, error)
 	FindMenfessList(ctx context.Context) ([]entity.User, error)
 	GetFollowedUserID(ctx context.Context, userID string) ([]string, error)
-	UpdateFollowStatus(ctx context.Context, follower, followed, status string) error
+	// FindFollowedUserIDsIn return the ids the user follow among the given ids
+	FindFollowedUserIDsIn(ctx context.Context, userID string, ids []string) ([]string, error)
+	FindFollowerIDs(ctx context.Context, userID string, pagination api.PaginationReq) ([]string, *api.PaginationRes, error)
+	FindFollowingIDs(ctx context.Context, userID string, pagination api.PaginationReq) ([]string, *api.PaginationRes, error)
+	IsFollowing(ctx context.Context, follower, followed string) (bool, error)
+	CountFollowers(ctx context.Context, userID string) (int, error)
+	CountFollowing(ctx context.Context, userID string) (int, error)
+	// UpdateFollowStatus return false if the follow edge is already in the status, created or removed by other call
+	UpdateFollowStatus(ctx context.Context, follower, followed, status string) (bool, error)
+	UpdateBlockStatus(ctx context.Context, userID, blockedID, status string) error
+	UpdateMuteStatus(ctx context.Context, userID, mutedID, status string) error
+	// IsBlocked return true if the user has blocked the other user
+	IsBlocked(ctx context.Context, userID, blockedID string) (bool, error)
+	// FindBlockerIDsIn return the ids among the given ids that have blocked the user
+	FindBlockerIDsIn(ctx context.Context, blockedID string, ids []string) ([]string, error)
+	// FindHiddenUserIDs return the users blocked or muted by the user, their posts are not shown to the user
+	FindHiddenUserIDs(ctx context.Context, userID string) ([]string, error)
+	// SaveMutedWord add the muted word or update its expiry if the user already muted it
+	SaveMutedWord(ctx context.Context, userID string, word entity.MutedWord) error
+	DeleteMutedWord(ctx context.Context, userID string, word string) error
+	// FindMutedWordList return the muted word that hasn't expired
+	FindMutedWordList(ctx context.Context, userID string) ([]entity.MutedWord, error)
 }
 
 type PostModule interface {
+	// The post finder take the viewer userID, post of shadowbanned user is only returned to the user
 	FindPostByID(ctx context.Context, id string, userID string) (*entity.Post, error)
+	// FindPostListByParentIDAndAuthorIDs also exclude the repost and quote of the excluded author post
 	FindPostListByParentIDAndAuthorIDs(ctx context.Context,
 		parentID string,
 		authorIDs []string,
+		excludedAuthorIDs []string,
 		userID string,
 		pagination api.PaginationReq,
 	) ([]entity.Post, *api.PaginationRes, error)
+	FindPostListByIDs(ctx context.Context, ids []string, userID string) ([]entity.Post, error)
+	// FindPostListByIDsIncludeHidden also return the hidden post, for the moderator to review
+	FindPostListByIDsIncludeHidden(ctx context.Context, ids []string) ([]entity.Post, error)
+	// FindPostListCreatedAfter doesn't return post of shadowbanned user
+	FindPostListCreatedAfter(ctx context.Context, timestamp int64) ([]entity.Post, error)
+	FindRepost(ctx context.Context, postID string, userID string) (*entity.Post, error)
+	// FindRecentPostListByUserID return the body of the posts the user wrote since the timestamp, newest first,
+	// including the hidden and the scheduled one but not the repost
+	FindRecentPostListByUserID(ctx context.Context, userID string, timestamp int64, limit int) ([]entity.Post, error)
+	// CountPostByAuthorID count the published post shown under the author to the viewer, including replies and reposts
+	CountPostByAuthorID(ctx context.Context, authorID string, userID string) (int, error)
+	FindScheduledPostByID(ctx context.Context, id string) (*entity.Post, error)
+	FindScheduledPostListByUserID(ctx context.Context, userID string, pagination api.PaginationReq) ([]entity.Post, *api.PaginationRes, error)
+	FindDueScheduledPostList(ctx context.Context, timestamp int64) ([]entity.Post, error)
+	// PublishPost return false if the post isn't scheduled anymore, published by other call or cancelled
+	PublishPost(ctx context.Context, id string) (bool, error)
 	InsertPost(ctx context.Context, post entity.Post) (string, error)
-	LikePost(ctx context.Context, postID string, userID string) error
+	UpdateContentWarnings(ctx context.Context, id string, warnings []string) error
+	// FindPendingLinkPreviewPostList return the pending post due at now, the post retried least recently first
+	FindPendingLinkPreviewPostList(ctx context.Context, now int64, limit int) ([]entity.Post, error)
+	// DelayLinkPreview count the failed attempt and keep the post out of the pending list until nextAttemptAt
+	DelayLinkPreview(ctx context.Context, id string, nextAttemptAt int64) error
+	SaveLinkPreviews(ctx context.Context, id string, previews []entity.LinkPreview) error
+	UpdateHiddenStatus(ctx context.Context, id string, hidden bool) error
+	DeletePost(ctx context.Context, id string) error
+	// DeleteScheduledPost return ErrPostNotFound if the post isn't scheduled anymore, so a published post isn't deleted
+	DeleteScheduledPost(ctx context.Context, id string) error
+	// DeletePostWithReplies delete the post together with its replies and reposts
+	DeletePostWithReplies(ctx context.Context, id string) error
+	DeleteExpiredPost(ctx context.Context, timestamp int64) error
+	// LikePost doesn't count the like of shadowbanned user toward the likes count, except for the user
+	LikePost(ctx context.Context, postID string, userID string, shadowbanned bool) error
 	UnlikePost(ctx context.Context, postID string, userID string) error
+	// VotePoll return ErrAlreadyVoted if the user already voted on the poll
+	VotePoll(ctx context.Context, postID string, userID string, optionIDs []string) error
+	// UpdateShadowbanStatus apply or remove the shadowban on all the user posts and likes
+	UpdateShadowbanStatus(ctx context.Context, userID string, shadowbanned bool) error
 }
 
+// NotificationModule doesn't send notification triggered by shadowbanned user
 type NotificationModule interface {
 	AddPushToken(ctx context.Context, userID string, pushToken string) error
 	RemovePushToken(ctx context.Context, userID string, pushToken string) error
+	FindPushTokenList(ctx context.Context, userID string) ([]string, error)
 	SendLikeNotification(ctx context.Context, user entity.User, post entity.Post) error
 	SendCommentNotification(ctx context.Context, comment entity.Post, parent entity.Post) error
+	SendMentionNotification(ctx context.Context, post entity.Post, userIDs []string) error
+	SendFollowNotification(ctx context.Context, follower entity.User, followedID string) error
+	SendWarningNotification(ctx context.Context, userID string, reason string) error
 	BroadcastNewPostNotification(ctx context.Context, post entity.Post) error
 }
 
+type ReportModule interface {
+	// InsertReport return ErrAlreadyReported if the user already has a pending report on the post
+	InsertReport(ctx context.Context, report entity.Report) (string, error)
+	FindReportByID(ctx context.Context, id string) (*entity.Report, error)
+	// FindPendingReportList return the oldest report first
+	FindPendingReportList(ctx context.Context, pagination api.PaginationReq) ([]entity.Report, *api.PaginationRes, error)
+	CountPendingReportByPostID(ctx context.Context, postID string) (int, error)
+	// CountPendingReportByPostIDs return the pending report count by post id, the post without pending report is left out
+	CountPendingReportByPostIDs(ctx context.Context, postIDs []string) (map[string]int, error)
+	// ResolveReportsByPostID resolve every pending report on the post with the moderator action,
+	// it return false if there's no pending report left, resolved by other moderator
+	ResolveReportsByPostID(ctx context.Context, postID string, action string, moderatorID string) (bool, error)
+	// ReopenReportsByPostID put back the reports resolved by the moderator action that failed to be applied
+	ReopenReportsByPostID(ctx context.Context, postID string, action string, moderatorID string) error
+}
+
+// AuditModule has no update or delete, the audit log is append only
+type AuditModule interface {
+	InsertAuditLog(ctx context.Context, log entity.AuditLog) error
+	// FindAuditLogList return the log matching the filter, newest first
+	FindAuditLogList(ctx context.Context, filter entity.AuditLogFilter, pagination api.PaginationReq) ([]entity.AuditLog, *api.PaginationRes, error)
+}
+
+// ModerationModule is the content filter reviewing the post before it's saved
+type ModerationModule interface {
+	ReviewPost(ctx context.Context, post entity.Post) (*entity.ModerationResult, error)
+}
+
+// RedactionModule find the personal data of other people in the post, before the content filter review it
+type RedactionModule interface {
+	Redact(ctx context.Context, text string) (*entity.Redaction, error)
+}
+
+type RateLimitModule interface {
+	// Hit count the action on the key in the current window of the given length (in second),
+	// and return the count including this one and when the window ends
+	Hit(ctx context.Context, key string, window int64) (int, int64, error)
+}
+
+type TrendingModule interface {
+	SaveTrendingPostList(ctx context.Context, window int, posts []entity.TrendingPost) error
+	FindTrendingPostList(ctx context.Context, window int, limit int) ([]entity.TrendingPost, error)
+	SaveTrendingTopicList(ctx context.Context, window int, topics []entity.Topic) error
+	FindTrendingTopicList(ctx context.Context, window int, limit int) ([]entity.Topic, error)
+	SaveEngagementSnapshot(ctx context.Context, snapshot entity.EngagementSnapshot) error
+	// FindEngagementSnapshot return the newest snapshot taken at or before the timestamp
+	FindEngagementSnapshot(ctx context.Context, timestamp int64) (*entity.EngagementSnapshot, error)
+	DeleteEngagementSnapshotBefore(ctx context.Context, timestamp int64) error
+}
+
+type BookmarkModule interface {
+	InsertBookmark(ctx context.Context, userID string, postID string) error
+	DeleteBookmark(ctx context.Context, userID string, postID string) error
+	FindBookmarkedPostIDs(ctx context.Context, userID string, pagination api.PaginationReq) ([]string, *api.PaginationRes, error)
+}
+
+type StorageModule interface {
+	// Put store the object under the key and return its public url
+	Put(ctx context.Context, key string, data []byte, contentType string) (string, error)
+}
+
+type MediaModule interface {
+	// ProcessImage decode the image, drop its metadata and generate its resized variants
+	ProcessImage(ctx context.Context, data []byte, contentType string) (*entity.ProcessedImage, error)
+}
+
+type LinkPreviewModule interface {
+	// FetchLinkPreview return the OpenGraph metadata of the page, ErrLinkPreviewUnavailable if it has none or can't be fetched
+	FetchLinkPreview(ctx context.Context, url string) (*entity.LinkPreview, error)
+}
+
+type AttachmentModule interface {
+	InsertAttachment(ctx context.Context, attachment entity.Attachment) (string, error)
+	FindAttachmentListByIDs(ctx context.Context, ids []string) ([]entity.Attachment, error)
+	FindAttachmentByURL(ctx context.Context, url string) (*entity.Attachment, error)
+}
+
+type AvatarModule interface {
+	FindAvatarList(ctx context.Context) ([]entity.Avatar, error)
+	FindAvatarByURL(ctx context.Context, url string) (*entity.Avatar, error)
+	InsertAvatar(ctx context.Context, avatar entity.Avatar) (string, error)
+	DeleteAvatar(ctx context.Context, url string) error
+}
+
 type LogModule interface {
 	Log(err error, payload interface{}, message string)
 }
@@ -0,0 +1,47 @@
+package service_test
+
+import (
+	"context"
+	"github.com/aeramu/menfess-backend/entity"
+	"github.com/aeramu/menfess-backend/mocks"
+	"github.com/aeramu/menfess-backend/modules/linkpreview"
+	"github.com/aeramu/menfess-backend/service"
+	"github.com/aeramu/menfess-backend/service/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+// the fake link preview module import service, so this test is in the external test package
+func Test_service_FetchLinkPreviews_fake(t *testing.T) {
+	previews := map[string]entity.LinkPreview{
+		"https://a.com": {Title: "A", Description: "about a", SiteName: "a.com"},
+		"https://c.com": {Title: "C", SiteName: "c.com"},
+	}
+	postList := []entity.Post{
+		{ID: "post-1", Links: []string{"https://a.com", "https://b.com", "https://c.com"}},
+		{ID: "post-2", Links: []string{"https://b.com"}},
+	}
+
+	postModule := new(mocks.PostModule)
+	postModule.On("FindPendingLinkPreviewPostList", mock.Anything, mock.Anything, 10).
+		Return(postList, nil)
+	postModule.On("SaveLinkPreviews", mock.Anything, "post-1", []entity.LinkPreview{
+		{URL: "https://a.com", Title: "A", Description: "about a", SiteName: "a.com"},
+		{URL: "https://c.com", Title: "C", SiteName: "c.com"},
+	}).Return(nil).Once()
+	// no preview available is still saved, so the post isn't pending anymore
+	postModule.On("SaveLinkPreviews", mock.Anything, "post-2", []entity.LinkPreview{}).
+		Return(nil).Once()
+
+	s := service.NewService(service.Adapter{
+		PostModule:        postModule,
+		LinkPreviewModule: linkpreview.NewFakeLinkPreviewModule(previews),
+		LogModule:         new(mocks.LogModule),
+	}, service.Config{})
+
+	got, err := s.FetchLinkPreviews(context.Background(), api.FetchLinkPreviewsReq{Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.FetchLinkPreviewsRes{Message: "success"}, got)
+	postModule.AssertExpectations(t)
+}
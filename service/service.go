@@ -2,9 +2,16 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/aeramu/menfess-backend/constants"
 	"github.com/aeramu/menfess-backend/entity"
 	"github.com/aeramu/menfess-backend/service/api"
+	"github.com/aeramu/menfess-backend/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 type Service interface {
@@ -12,27 +19,80 @@ type Service interface {
 	Login(ctx context.Context, req api.LoginReq) (*api.LoginRes, error)
 	Register(ctx context.Context, req api.RegisterReq) (*api.RegisterRes, error)
 	Logout(ctx context.Context, req api.LogoutReq) (*api.LogoutRes, error)
+	CheckAccountStatus(ctx context.Context, req api.CheckAccountStatusReq) (*api.CheckAccountStatusRes, error)
 	UpdateProfile(ctx context.Context, req api.UpdateProfileReq) (*api.UpdateProfileRes, error)
+	UpdateSettings(ctx context.Context, req api.UpdateSettingsReq) (*api.UpdateSettingsRes, error)
 	GetUser(ctx context.Context, req api.GetUserReq) (*api.GetUserRes, error)
+	GetUserProfile(ctx context.Context, req api.GetUserProfileReq) (*api.GetUserProfileRes, error)
+	GetUserByHandle(ctx context.Context, req api.GetUserByHandleReq) (*api.GetUserByHandleRes, error)
+	SetHandle(ctx context.Context, req api.SetHandleReq) (*api.SetHandleRes, error)
+	CheckHandle(ctx context.Context, req api.CheckHandleReq) (*api.CheckHandleRes, error)
 	FollowUser(ctx context.Context, req api.FollowUserReq) (*api.FollowUserRes, error)
+	Follow(ctx context.Context, req api.FollowReq) (*api.FollowRes, error)
+	Unfollow(ctx context.Context, req api.UnfollowReq) (*api.UnfollowRes, error)
+	Block(ctx context.Context, req api.BlockReq) (*api.BlockRes, error)
+	Unblock(ctx context.Context, req api.UnblockReq) (*api.UnblockRes, error)
+	Mute(ctx context.Context, req api.MuteReq) (*api.MuteRes, error)
+	Unmute(ctx context.Context, req api.UnmuteReq) (*api.UnmuteRes, error)
+	MuteWord(ctx context.Context, req api.MuteWordReq) (*api.MuteWordRes, error)
+	UnmuteWord(ctx context.Context, req api.UnmuteWordReq) (*api.UnmuteWordRes, error)
+	GetMutedWordList(ctx context.Context, req api.GetMutedWordListReq) (*api.GetMutedWordListRes, error)
+	GetFollowerList(ctx context.Context, req api.GetFollowerListReq) (*api.GetFollowerListRes, error)
+	GetFollowingList(ctx context.Context, req api.GetFollowingListReq) (*api.GetFollowingListRes, error)
 	GetMenfessList(ctx context.Context, req api.GetMenfessListReq) (*api.GetMenfessListRes, error)
+	GetAvatarList(ctx context.Context, req api.GetAvatarListReq) (*api.GetAvatarListRes, error)
+	AddAvatar(ctx context.Context, req api.AddAvatarReq) (*api.AddAvatarRes, error)
+	RemoveAvatar(ctx context.Context, req api.RemoveAvatarReq) (*api.RemoveAvatarRes, error)
+	UploadAvatar(ctx context.Context, req api.UploadAvatarReq) (*api.UploadAvatarRes, error)
+	ModerateUser(ctx context.Context, req api.ModerateUserReq) (*api.ModerateUserRes, error)
 
 	// Post
 	Feed(ctx context.Context, req api.FeedReq) (*api.FeedRes, error)
 	GetPost(ctx context.Context, req api.GetPostReq) (*api.GetPostRes, error)
 	GetPostList(ctx context.Context, req api.GetPostListReq) (*api.GetPostListRes, error)
 	CreatePost(ctx context.Context, req api.CreatePostReq) (*api.CreatePostRes, error)
+	UploadImage(ctx context.Context, req api.UploadImageReq) (*api.UploadImageRes, error)
 	LikePost(ctx context.Context, req api.LikePostReq) (*api.LikePostRes, error)
+	RepostPost(ctx context.Context, req api.RepostPostReq) (*api.RepostPostRes, error)
+	VotePoll(ctx context.Context, req api.VotePollReq) (*api.VotePollRes, error)
+	SetContentWarnings(ctx context.Context, req api.SetContentWarningsReq) (*api.SetContentWarningsRes, error)
+	GetScheduledPostList(ctx context.Context, req api.GetScheduledPostListReq) (*api.GetScheduledPostListRes, error)
+	CancelScheduledPost(ctx context.Context, req api.CancelScheduledPostReq) (*api.CancelScheduledPostRes, error)
+	BookmarkPost(ctx context.Context, req api.BookmarkPostReq) (*api.BookmarkPostRes, error)
+	GetBookmarkList(ctx context.Context, req api.GetBookmarkListReq) (*api.GetBookmarkListRes, error)
+	Trending(ctx context.Context, req api.TrendingReq) (*api.TrendingRes, error)
+	TrendingTopics(ctx context.Context, req api.TrendingTopicsReq) (*api.TrendingTopicsRes, error)
+
+	// Moderation
+	ReportPost(ctx context.Context, req api.ReportPostReq) (*api.ReportPostRes, error)
+	GetModerationQueue(ctx context.Context, req api.GetModerationQueueReq) (*api.GetModerationQueueRes, error)
+	ResolveReport(ctx context.Context, req api.ResolveReportReq) (*api.ResolveReportRes, error)
+	GetAuditLog(ctx context.Context, req api.GetAuditLogReq) (*api.GetAuditLogRes, error)
+	ExportAuditLog(ctx context.Context, req api.ExportAuditLogReq) (*api.ExportAuditLogRes, error)
+
+	// Job
+	RefreshTrending(ctx context.Context, req api.RefreshTrendingReq) (*api.RefreshTrendingRes, error)
+	PublishScheduledPost(ctx context.Context, req api.PublishScheduledPostReq) (*api.PublishScheduledPostRes, error)
+	DeleteExpiredPost(ctx context.Context, req api.DeleteExpiredPostReq) (*api.DeleteExpiredPostRes, error)
+	FetchLinkPreviews(ctx context.Context, req api.FetchLinkPreviewsReq) (*api.FetchLinkPreviewsRes, error)
 }
 
-func NewService(adapter Adapter) Service {
+func NewService(adapter Adapter, config Config) Service {
 	return &service {
 		adapter: adapter,
+		config:  config,
 	}
 }
 
+type Config struct {
+	// ReportHideThreshold is how many pending report hide the post until it's reviewed,
+	// constants.ReportHideThresholdDefault if not set
+	ReportHideThreshold int
+}
+
 type service struct {
 	adapter Adapter
+	config  Config
 }
 
 func (s *service) Login(ctx context.Context, req api.LoginReq) (*api.LoginRes, error) {
@@ -53,6 +113,18 @@ func (s *service) Login(ctx context.Context, req api.LoginReq) (*api.LoginRes, e
 		return nil, constants.ErrWrongPassword
 	}
 
+	// checked after the password, so the account status isn't told to anyone knowing the email
+	if err := accountRestriction(user.Account, time.Now().Unix()); err != nil {
+		return nil, err
+	}
+	if err := s.checkBannedDevice(ctx, req.PushToken); err != nil {
+		if errors.Is(err, constants.ErrAccountBanned) {
+			return nil, err
+		}
+		s.adapter.LogModule.Log(err, req, "[Login] failed get banned device")
+		return nil, constants.ErrInternalServerError
+	}
+
 	if err := s.adapter.NotificationModule.AddPushToken(ctx, user.ID, req.PushToken); err != nil {
 		s.adapter.LogModule.Log(err, req, "[Login] failed add notification push token")
 		return nil, constants.ErrInternalServerError
@@ -68,6 +140,36 @@ func (s *service) Login(ctx context.Context, req api.LoginReq) (*api.LoginRes, e
 }
 
 func (s *service) Register(ctx context.Context, req api.RegisterReq) (*api.RegisterRes, error) {
+	if err := s.checkBannedDevice(ctx, req.PushToken); err != nil {
+		if errors.Is(err, constants.ErrAccountBanned) {
+			return nil, err
+		}
+		s.adapter.LogModule.Log(err, req, "[Register] failed get banned device")
+		return nil, constants.ErrInternalServerError
+	}
+
+	// the subnet limit catch a bot farm rotating its address, only that network is blocked
+	var limits []rateLimit
+	if req.PushToken != "" {
+		limits = append(limits, rateLimit{key: "register:device:" + req.PushToken, limit: constants.RateLimitRegisterDevice})
+	}
+	if ip := utils.IPNetwork(req.ClientIP, 32, constants.RateLimitIPv6Bits); ip != "" {
+		limits = append(limits,
+			rateLimit{key: "register:ip:" + ip, limit: constants.RateLimitRegisterIP},
+			rateLimit{
+				key:   "register:subnet:" + utils.IPNetwork(req.ClientIP, constants.RateLimitSubnetIPv4Bits, constants.RateLimitSubnetIPv6Bits),
+				limit: constants.RateLimitRegisterSubnet,
+			},
+		)
+	}
+	if err := s.checkRateLimit(ctx, limits); err != nil {
+		if errors.Is(err, constants.ErrRateLimited) {
+			return nil, err
+		}
+		s.adapter.LogModule.Log(err, req, "[Register] failed check rate limit")
+		return nil, constants.ErrInternalServerError
+	}
+
 	user := entity.User{}
 	id, err := s.adapter.UserModule.InsertUser(ctx, user)
 	if err != nil {
@@ -103,6 +205,98 @@ func (s *service) Logout(ctx context.Context, req api.LogoutReq) (*api.LogoutRes
 	return &api.LogoutRes{Message: "Success"}, nil
 }
 
+func (s *service) CheckAccountStatus(ctx context.Context, req api.CheckAccountStatusReq) (*api.CheckAccountStatusRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.adapter.UserModule.FindUserByID(ctx, req.UserID)
+	if err != nil {
+		if err == constants.ErrUserNotFound {
+			return nil, constants.ErrUserNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[CheckAccountStatus] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+	if err := accountRestriction(user.Account, time.Now().Unix()); err != nil {
+		return nil, err
+	}
+
+	return &api.CheckAccountStatusRes{Message: "success"}, nil
+}
+
+// accountRestriction return RestrictionError if the account is banned or still suspended
+func accountRestriction(account entity.Account, now int64) error {
+	if account.BannedAt != 0 {
+		return &constants.RestrictionError{
+			Err:    constants.ErrAccountBanned,
+			Reason: account.BanReason,
+		}
+	}
+	if account.SuspendedUntil > now {
+		return &constants.RestrictionError{
+			Err:    constants.ErrAccountSuspended,
+			Reason: account.SuspensionReason,
+			Until:  account.SuspendedUntil,
+		}
+	}
+	return nil
+}
+
+// checkBannedDevice return RestrictionError if the push token belong to a banned user device
+func (s *service) checkBannedDevice(ctx context.Context, pushToken string) error {
+	if pushToken == "" {
+		return nil
+	}
+	device, err := s.adapter.UserModule.FindBannedDevice(ctx, pushToken)
+	if err != nil {
+		if err == constants.ErrBannedDeviceNotFound {
+			return nil
+		}
+		return err
+	}
+	return &constants.RestrictionError{
+		Err:    constants.ErrAccountBanned,
+		Reason: device.Reason,
+	}
+}
+
+// rateLimit is the limit of an action for one subject, the key is "<action>:<subject>:<id>"
+type rateLimit struct {
+	key   string
+	limit constants.RateLimit
+}
+
+// userRateLimits return the per-user and per-ip limit of the action, the ip is skipped if it's unknown.
+// The ip stands in for the device, there's no device id the client can't change after registration
+func userRateLimits(action string, userID string, clientIP string, userLimit constants.RateLimit, ipLimit constants.RateLimit) []rateLimit {
+	limits := []rateLimit{{key: action + ":user:" + userID, limit: userLimit}}
+	if ip := utils.IPNetwork(clientIP, 32, constants.RateLimitIPv6Bits); ip != "" {
+		limits = append(limits, rateLimit{key: action + ":ip:" + ip, limit: ipLimit})
+	}
+	return limits
+}
+
+// checkRateLimit count the action against every limit, and return RateLimitError with the longest wait
+// if any of them is exceeded
+func (s *service) checkRateLimit(ctx context.Context, limits []rateLimit) error {
+	var retryAfter int64
+	now := time.Now().Unix()
+	for _, v := range limits {
+		count, resetAt, err := s.adapter.RateLimitModule.Hit(ctx, v.key, v.limit.Window)
+		if err != nil {
+			return err
+		}
+		if count > v.limit.Limit && resetAt-now > retryAfter {
+			retryAfter = resetAt - now
+		}
+	}
+	if retryAfter > 0 {
+		return &constants.RateLimitError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
 // TODO: Refactor case when user not found, expected to insert new profile
 func (s *service) UpdateProfile(ctx context.Context, req api.UpdateProfileReq) (*api.UpdateProfileRes, error) {
 	if err := req.Validate(); err != nil {
@@ -115,6 +309,17 @@ func (s *service) UpdateProfile(ctx context.Context, req api.UpdateProfileReq) (
 		return nil, constants.ErrInternalServerError
 	}
 
+	// keeping the current avatar is always allowed, even if it's no longer in the catalog
+	if req.Avatar != profile.Profile.Avatar {
+		if err := s.validateAvatar(ctx, req.ID, req.Avatar); err != nil {
+			if err == constants.ErrInvalidAvatar {
+				return nil, constants.ErrInvalidAvatar
+			}
+			s.adapter.LogModule.Log(err, req, "[UpdateProfile] failed validate avatar")
+			return nil, constants.ErrInternalServerError
+		}
+	}
+
 	profile.Profile.Name = req.Name
 	profile.Profile.Avatar = req.Avatar
 	profile.Profile.Bio = req.Bio
@@ -127,6 +332,29 @@ func (s *service) UpdateProfile(ctx context.Context, req api.UpdateProfileReq) (
 	return &api.UpdateProfileRes{Message: "success"}, nil
 }
 
+func (s *service) UpdateSettings(ctx context.Context, req api.UpdateSettingsReq) (*api.UpdateSettingsRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.adapter.UserModule.FindUserByID(ctx, req.UserID)
+	if err != nil {
+		if err == constants.ErrUserNotFound {
+			return nil, constants.ErrUserNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[UpdateSettings] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	user.Settings.SensitiveContent = req.SensitiveContent
+	if err := s.adapter.UserModule.SaveSettings(ctx, *user); err != nil {
+		s.adapter.LogModule.Log(err, req, "[UpdateSettings] failed save settings")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.UpdateSettingsRes{Message: "success"}, nil
+}
+
 func (s *service) GetUser(ctx context.Context, req api.GetUserReq) (*api.GetUserRes, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
@@ -141,106 +369,94 @@ func (s *service) GetUser(ctx context.Context, req api.GetUserReq) (*api.GetUser
 	return &api.GetUserRes{User: *user}, nil
 }
 
-func (s *service) GetMenfessList(ctx context.Context, req api.GetMenfessListReq) (*api.GetMenfessListRes, error) {
-	menfessList, err := s.adapter.UserModule.FindMenfessList(ctx)
+func (s *service) GetUserProfile(ctx context.Context, req api.GetUserProfileReq) (*api.GetUserProfileRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.adapter.UserModule.FindUserByID(ctx, req.ID)
 	if err != nil {
-		s.adapter.LogModule.Log(err, req, "[GetMenfessList] failed get menfess list")
+		if err == constants.ErrUserNotFound {
+			return nil, constants.ErrUserNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[GetUserProfile] failed get user")
 		return nil, constants.ErrInternalServerError
 	}
 
-	followed, err := s.adapter.UserModule.GetFollowedUserID(ctx, req.UserID)
+	followersCount, err := s.adapter.UserModule.CountFollowers(ctx, user.ID)
 	if err != nil {
-		s.adapter.LogModule.Log(err, req, "[FollowUser] failed get followed user")
+		s.adapter.LogModule.Log(err, req, "[GetUserProfile] failed count followers")
 		return nil, constants.ErrInternalServerError
 	}
 
-	return &api.GetMenfessListRes{MenfessList: menfessList, FollowedIDs: followed}, nil
-}
-
-func (s *service) GetPost(ctx context.Context, req api.GetPostReq) (*api.GetPostRes, error) {
-	if err := req.Validate(); err != nil {
-		return nil, err
+	followingCount, err := s.adapter.UserModule.CountFollowing(ctx, user.ID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetUserProfile] failed count following")
+		return nil, constants.ErrInternalServerError
 	}
 
-	post, err := s.adapter.PostModule.FindPostByID(ctx, req.ID, req.UserID)
+	postsCount, err := s.adapter.PostModule.CountPostByAuthorID(ctx, user.ID, req.UserID)
 	if err != nil {
-		if err == constants.ErrPostNotFound {
-			return nil, constants.ErrPostNotFound
-		}
-		s.adapter.LogModule.Log(err, req, "[GetPost] failed get post")
+		s.adapter.LogModule.Log(err, req, "[GetUserProfile] failed count post")
 		return nil, constants.ErrInternalServerError
 	}
 
-	return &api.GetPostRes{Post: *post}, nil
-}
+	res := &api.GetUserProfileRes{
+		User:           *user,
+		FollowersCount: followersCount,
+		FollowingCount: followingCount,
+		PostsCount:     postsCount,
+	}
+	if user.ID == req.UserID {
+		return res, nil
+	}
 
-func (s *service) GetPostList(ctx context.Context, req api.GetPostListReq) (*api.GetPostListRes, error) {
-	if err := req.Validate(); err != nil {
-		return nil, err
+	res.IsFollowed, err = s.adapter.UserModule.IsFollowing(ctx, req.UserID, user.ID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetUserProfile] failed get follow status")
+		return nil, constants.ErrInternalServerError
 	}
 
-	postList, pagination, err := s.adapter.PostModule.FindPostListByParentIDAndAuthorIDs(ctx,
-		req.ParentID,
-		nil,
-		req.UserID,
-		req.Pagination)
+	res.FollowsYou, err = s.adapter.UserModule.IsFollowing(ctx, user.ID, req.UserID)
 	if err != nil {
-		s.adapter.LogModule.Log(err, req, "[GetPostList] failed get post list")
+		s.adapter.LogModule.Log(err, req, "[GetUserProfile] failed get follow status")
 		return nil, constants.ErrInternalServerError
 	}
 
-	return &api.GetPostListRes{
-		PostList:   postList,
-		Pagination: *pagination,
-	}, nil
+	return res, nil
 }
 
-func (s *service) CreatePost(ctx context.Context, req api.CreatePostReq) (*api.CreatePostRes, error) {
+func (s *service) GetUserByHandle(ctx context.Context, req api.GetUserByHandleReq) (*api.GetUserByHandleRes, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	user, err := s.adapter.UserModule.FindUserByID(ctx, req.UserID)
+	user, err := s.adapter.UserModule.FindUserByHandle(ctx, req.Handle)
 	if err != nil {
 		if err == constants.ErrUserNotFound {
 			return nil, constants.ErrUserNotFound
 		}
-		s.adapter.LogModule.Log(err, req, "[CreatePost] failed get user")
+		s.adapter.LogModule.Log(err, req, "[GetUserByHandle] failed get user")
 		return nil, constants.ErrInternalServerError
 	}
 
-	post := entity.Post{
-		Body:         req.Body,
-		Parent:       &entity.Post{ID: req.ParentID},
-		Author:       entity.User{ID: req.AuthorID},
-		User:         *user,
-	}
-	id, err := s.adapter.PostModule.InsertPost(ctx, post)
+	followed, err := s.adapter.UserModule.GetFollowedUserID(ctx, req.UserID)
 	if err != nil {
-		s.adapter.LogModule.Log(err, req, "[CreatePost] failed save post")
+		s.adapter.LogModule.Log(err, req, "[GetUserByHandle] failed get followed user")
 		return nil, constants.ErrInternalServerError
 	}
-	post.ID = id
-
-	if req.ParentID != "" {
-		parent, err := s.adapter.PostModule.FindPostByID(ctx, req.ParentID, "")
-		if err != nil {
-			s.adapter.LogModule.Log(err, req, "[CreatePost] failed get post")
-			return nil, constants.ErrInternalServerError
-		}
-		if err := s.adapter.NotificationModule.SendCommentNotification(ctx, post, *parent); err != nil {
-			s.adapter.LogModule.Log(err, req, "[CreatePost] failed send notification")
-		}
-	} else {
-		if err := s.adapter.NotificationModule.BroadcastNewPostNotification(ctx, post); err != nil {
-			s.adapter.LogModule.Log(err, req, "[CreatePost] failed send notification")
+	isFollowed := false
+	for _, v := range followed {
+		if v == user.ID {
+			isFollowed = true
+			break
 		}
 	}
 
-	return &api.CreatePostRes{Message: "success"}, nil
+	return &api.GetUserByHandleRes{User: *user, IsFollowed: isFollowed}, nil
 }
 
-func (s *service) LikePost(ctx context.Context, req api.LikePostReq) (*api.LikePostRes, error) {
+func (s *service) SetHandle(ctx context.Context, req api.SetHandleReq) (*api.SetHandleRes, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
@@ -250,92 +466,2291 @@ func (s *service) LikePost(ctx context.Context, req api.LikePostReq) (*api.LikeP
 		if err == constants.ErrUserNotFound {
 			return nil, constants.ErrUserNotFound
 		}
-		s.adapter.LogModule.Log(err, req, "[LikePost] failed get user")
+		s.adapter.LogModule.Log(err, req, "[SetHandle] failed get user")
 		return nil, constants.ErrInternalServerError
 	}
+	if user.Profile.Handle == req.Handle {
+		return &api.SetHandleRes{Message: "success"}, nil
+	}
+	// setting the first handle is always allowed, changing it is limited so handle can't be hopped to impersonate
+	now := time.Now().Unix()
+	if user.Profile.Handle != "" && now < user.Account.HandleChangedAt+constants.HandleChangeCooldown {
+		return nil, constants.ErrHandleChangeTooSoon
+	}
 
-	post, err := s.adapter.PostModule.FindPostByID(ctx, req.PostID, req.UserID)
+	available, err := s.isHandleAvailable(ctx, req.Handle, req.UserID)
 	if err != nil {
-		if err == constants.ErrPostNotFound {
-			return nil, constants.ErrPostNotFound
-		}
-		s.adapter.LogModule.Log(err, req, "[LikePost] failed get post")
+		s.adapter.LogModule.Log(err, req, "[SetHandle] failed check handle")
 		return nil, constants.ErrInternalServerError
 	}
+	if !available {
+		return nil, constants.ErrHandleTaken
+	}
 
-	if post.IsLiked {
-		if err := s.adapter.PostModule.UnlikePost(ctx, req.PostID, req.UserID); err != nil {
-			s.adapter.LogModule.Log(err, req, "[LikePost] failed unlike post")
-			return nil, constants.ErrInternalServerError
+	user.Profile.Handle = req.Handle
+	user.Account.HandleChangedAt = now
+	if err := s.adapter.UserModule.SaveHandle(ctx, *user); err != nil {
+		if err == constants.ErrHandleTaken {
+			return nil, constants.ErrHandleTaken
 		}
-	} else {
-		if err := s.adapter.PostModule.LikePost(ctx, req.PostID, req.UserID); err != nil {
-			s.adapter.LogModule.Log(err, req, "[LikePost] failed like post")
-			return nil, constants.ErrInternalServerError
+		s.adapter.LogModule.Log(err, req, "[SetHandle] failed save handle")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.SetHandleRes{Message: "success"}, nil
+}
+
+func (s *service) CheckHandle(ctx context.Context, req api.CheckHandleReq) (*api.CheckHandleRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	available, err := s.isHandleAvailable(ctx, req.Handle, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[CheckHandle] failed check handle")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.CheckHandleRes{Available: available}, nil
+}
+
+// isHandleAvailable report whether nobody other than the user has the handle
+func (s *service) isHandleAvailable(ctx context.Context, handle string, userID string) (bool, error) {
+	user, err := s.adapter.UserModule.FindUserByHandle(ctx, handle)
+	if err != nil {
+		if err == constants.ErrUserNotFound {
+			return true, nil
 		}
-		if err := s.adapter.NotificationModule.SendLikeNotification(ctx, *user, *post); err != nil {
-			s.adapter.LogModule.Log(err, req, "[LikePost] failed send notification")
+		return false, err
+	}
+	return user.ID == userID, nil
+}
+
+func (s *service) Block(ctx context.Context, req api.BlockReq) (*api.BlockRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.adapter.UserModule.FindUserByID(ctx, req.BlockedID); err != nil {
+		if err == constants.ErrUserNotFound {
+			return nil, constants.ErrUserNotFound
 		}
+		s.adapter.LogModule.Log(err, req, "[Block] failed get blocked user")
+		return nil, constants.ErrInternalServerError
 	}
 
-	return &api.LikePostRes{Message: "success"}, nil
+	if err := s.adapter.UserModule.UpdateBlockStatus(ctx, req.UserID, req.BlockedID, constants.BlockStatusActive); err != nil {
+		s.adapter.LogModule.Log(err, req, "[Block] failed update block status")
+		return nil, constants.ErrInternalServerError
+	}
+	// blocking also end the follow both ways
+	if _, err := s.adapter.UserModule.UpdateFollowStatus(ctx, req.UserID, req.BlockedID, constants.FollowStatusInactive); err != nil {
+		s.adapter.LogModule.Log(err, req, "[Block] failed update follow status")
+		return nil, constants.ErrInternalServerError
+	}
+	if _, err := s.adapter.UserModule.UpdateFollowStatus(ctx, req.BlockedID, req.UserID, constants.FollowStatusInactive); err != nil {
+		s.adapter.LogModule.Log(err, req, "[Block] failed update follow status")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.BlockRes{Message: "success"}, nil
 }
 
-func (s *service) FollowUser(ctx context.Context, req api.FollowUserReq) (*api.FollowUserRes, error) {
+func (s *service) Unblock(ctx context.Context, req api.UnblockReq) (*api.UnblockRes, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	followed, err := s.adapter.UserModule.GetFollowedUserID(ctx, req.UserID)
-	if err != nil {
-		s.adapter.LogModule.Log(err, req, "[FollowUser] failed get followed user")
+	if err := s.adapter.UserModule.UpdateBlockStatus(ctx, req.UserID, req.BlockedID, constants.BlockStatusInactive); err != nil {
+		s.adapter.LogModule.Log(err, req, "[Unblock] failed update block status")
 		return nil, constants.ErrInternalServerError
 	}
 
-	status := constants.FollowStatusActive
-	for _, v := range followed {
-		if req.FollowedID == v {
-			status = constants.FollowStatusInactive
+	return &api.UnblockRes{Message: "success"}, nil
+}
+
+func (s *service) Mute(ctx context.Context, req api.MuteReq) (*api.MuteRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.adapter.UserModule.FindUserByID(ctx, req.MutedID); err != nil {
+		if err == constants.ErrUserNotFound {
+			return nil, constants.ErrUserNotFound
 		}
+		s.adapter.LogModule.Log(err, req, "[Mute] failed get muted user")
+		return nil, constants.ErrInternalServerError
 	}
 
-	if err := s.adapter.UserModule.UpdateFollowStatus(ctx, req.UserID, req.FollowedID, status); err != nil {
-		s.adapter.LogModule.Log(err, req, "[FollowUser] failed update follow status")
+	if err := s.adapter.UserModule.UpdateMuteStatus(ctx, req.UserID, req.MutedID, constants.MuteStatusActive); err != nil {
+		s.adapter.LogModule.Log(err, req, "[Mute] failed update mute status")
 		return nil, constants.ErrInternalServerError
 	}
-	return &api.FollowUserRes{Message: "success"}, nil
+
+	return &api.MuteRes{Message: "success"}, nil
 }
 
-func (s *service) Feed(ctx context.Context, req api.FeedReq) (*api.FeedRes, error) {
+func (s *service) Unmute(ctx context.Context, req api.UnmuteReq) (*api.UnmuteRes, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	var followed []string
-	if req.Type == constants.FeedTypeFollow {
-		userIDs, err := s.adapter.UserModule.GetFollowedUserID(ctx, req.UserID)
-		if err != nil {
-			s.adapter.LogModule.Log(err, req, "[Feed] failed get followed user")
-			return nil, constants.ErrInternalServerError
+	if err := s.adapter.UserModule.UpdateMuteStatus(ctx, req.UserID, req.MutedID, constants.MuteStatusInactive); err != nil {
+		s.adapter.LogModule.Log(err, req, "[Unmute] failed update mute status")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.UnmuteRes{Message: "success"}, nil
+}
+
+func (s *service) MuteWord(ctx context.Context, req api.MuteWordReq) (*api.MuteWordRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	words, err := s.adapter.UserModule.FindMutedWordList(ctx, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[MuteWord] failed get muted word list")
+		return nil, constants.ErrInternalServerError
+	}
+	if len(words) >= constants.MutedWordMaxCount {
+		// muting the same word again only update its expiry
+		normalized := utils.NormalizeText(req.Word)
+		exist := false
+		for _, v := range words {
+			if utils.NormalizeText(v.Word) == normalized {
+				exist = true
+				break
+			}
 		}
-		if len(userIDs) == 0 {
-			return nil, constants.ErrUserNotFollowAnyone
+		if !exist {
+			return nil, constants.ErrTooManyMutedWords
 		}
-		followed = userIDs
 	}
 
-	postList, pagination, err := s.adapter.PostModule.FindPostListByParentIDAndAuthorIDs(ctx,
-		"",
-		followed,
-		req.UserID,
-		req.Pagination)
-	if err != nil {
-		s.adapter.LogModule.Log(err, req, "[GetPostList] failed get post list")
+	word := entity.MutedWord{Word: req.Word}
+	if req.ExpiresIn != 0 {
+		word.ExpiredAt = time.Now().Unix() + req.ExpiresIn
+	}
+	if err := s.adapter.UserModule.SaveMutedWord(ctx, req.UserID, word); err != nil {
+		s.adapter.LogModule.Log(err, req, "[MuteWord] failed save muted word")
 		return nil, constants.ErrInternalServerError
 	}
 
-	return &api.FeedRes{
-		PostList:   postList,
-		Pagination: *pagination,
-	}, nil
+	return &api.MuteWordRes{Message: "success"}, nil
+}
+
+func (s *service) UnmuteWord(ctx context.Context, req api.UnmuteWordReq) (*api.UnmuteWordRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.adapter.UserModule.DeleteMutedWord(ctx, req.UserID, req.Word); err != nil {
+		s.adapter.LogModule.Log(err, req, "[UnmuteWord] failed delete muted word")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.UnmuteWordRes{Message: "success"}, nil
+}
+
+func (s *service) GetMutedWordList(ctx context.Context, req api.GetMutedWordListReq) (*api.GetMutedWordListRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	words, err := s.adapter.UserModule.FindMutedWordList(ctx, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetMutedWordList] failed get muted word list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.GetMutedWordListRes{MutedWordList: words}, nil
+}
+
+func (s *service) GetFollowerList(ctx context.Context, req api.GetFollowerListReq) (*api.GetFollowerListRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	ids, pagination, err := s.adapter.UserModule.FindFollowerIDs(ctx, req.ID, req.Pagination)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetFollowerList] failed get follower id")
+		return nil, constants.ErrInternalServerError
+	}
+	if len(ids) == 0 {
+		return &api.GetFollowerListRes{Pagination: *pagination}, nil
+	}
+
+	userList, followed, err := s.findFollowUserList(ctx, ids, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetFollowerList] failed get user list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.GetFollowerListRes{
+		UserList:    userList,
+		FollowedIDs: followed,
+		Pagination:  *pagination,
+	}, nil
+}
+
+func (s *service) GetFollowingList(ctx context.Context, req api.GetFollowingListReq) (*api.GetFollowingListRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	ids, pagination, err := s.adapter.UserModule.FindFollowingIDs(ctx, req.ID, req.Pagination)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetFollowingList] failed get following id")
+		return nil, constants.ErrInternalServerError
+	}
+	if len(ids) == 0 {
+		return &api.GetFollowingListRes{Pagination: *pagination}, nil
+	}
+
+	userList, followed, err := s.findFollowUserList(ctx, ids, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetFollowingList] failed get user list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.GetFollowingListRes{
+		UserList:    userList,
+		FollowedIDs: followed,
+		Pagination:  *pagination,
+	}, nil
+}
+
+// findFollowUserList return the users of the ids and which of them the user follow
+func (s *service) findFollowUserList(ctx context.Context, ids []string, userID string) ([]entity.User, []string, error) {
+	userList, err := s.adapter.UserModule.FindUserListByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	followed, err := s.adapter.UserModule.FindFollowedUserIDsIn(ctx, userID, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return userList, followed, nil
+}
+
+func (s *service) GetMenfessList(ctx context.Context, req api.GetMenfessListReq) (*api.GetMenfessListRes, error) {
+	menfessList, err := s.adapter.UserModule.FindMenfessList(ctx)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetMenfessList] failed get menfess list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	followed, err := s.adapter.UserModule.GetFollowedUserID(ctx, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[FollowUser] failed get followed user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.GetMenfessListRes{MenfessList: menfessList, FollowedIDs: followed}, nil
+}
+
+func (s *service) GetAvatarList(ctx context.Context, req api.GetAvatarListReq) (*api.GetAvatarListRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	avatarList, err := s.adapter.AvatarModule.FindAvatarList(ctx)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetAvatarList] failed get avatar list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.GetAvatarListRes{AvatarList: avatarList}, nil
+}
+
+func (s *service) AddAvatar(ctx context.Context, req api.AddAvatarReq) (*api.AddAvatarRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeAdmin(ctx, req.UserID); err != nil {
+		if err == constants.ErrForbidden || err == constants.ErrUserNotFound {
+			return nil, err
+		}
+		s.adapter.LogModule.Log(err, req, "[AddAvatar] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	_, err := s.adapter.AvatarModule.FindAvatarByURL(ctx, req.URL)
+	if err == nil {
+		return &api.AddAvatarRes{Message: "success"}, nil
+	}
+	if err != constants.ErrAvatarNotFound {
+		s.adapter.LogModule.Log(err, req, "[AddAvatar] failed get avatar")
+		return nil, constants.ErrInternalServerError
+	}
+
+	id, err := s.adapter.AvatarModule.InsertAvatar(ctx, entity.Avatar{URL: req.URL})
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[AddAvatar] failed save avatar")
+		return nil, constants.ErrInternalServerError
+	}
+	// the id is only known after the insert, so the avatar is removed again when the audit log can't be written
+	if err := s.adapter.AuditModule.InsertAuditLog(ctx, entity.AuditLog{
+		ActorID:    req.UserID,
+		Action:     constants.AuditActionAddAvatar,
+		TargetType: constants.AuditTargetAvatar,
+		TargetID:   id,
+		After:      entity.Snapshot{"url": req.URL},
+	}); err != nil {
+		s.adapter.LogModule.Log(err, req, "[AddAvatar] failed insert audit log")
+		if err := s.adapter.AvatarModule.DeleteAvatar(ctx, req.URL); err != nil {
+			s.adapter.LogModule.Log(err, req, "[AddAvatar] failed delete avatar")
+		}
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.AddAvatarRes{Message: "success"}, nil
+}
+
+func (s *service) RemoveAvatar(ctx context.Context, req api.RemoveAvatarReq) (*api.RemoveAvatarRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeAdmin(ctx, req.UserID); err != nil {
+		if err == constants.ErrForbidden || err == constants.ErrUserNotFound {
+			return nil, err
+		}
+		s.adapter.LogModule.Log(err, req, "[RemoveAvatar] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	avatar, err := s.adapter.AvatarModule.FindAvatarByURL(ctx, req.URL)
+	if err != nil {
+		if err == constants.ErrAvatarNotFound {
+			return &api.RemoveAvatarRes{Message: "success"}, nil
+		}
+		s.adapter.LogModule.Log(err, req, "[RemoveAvatar] failed get avatar")
+		return nil, constants.ErrInternalServerError
+	}
+
+	audit := entity.AuditLog{
+		ActorID:    req.UserID,
+		Action:     constants.AuditActionRemoveAvatar,
+		TargetType: constants.AuditTargetAvatar,
+		TargetID:   avatar.ID,
+		Before:     entity.Snapshot{"url": avatar.URL},
+	}
+	if err := s.adapter.AuditModule.InsertAuditLog(ctx, audit); err != nil {
+		s.adapter.LogModule.Log(err, req, "[RemoveAvatar] failed insert audit log")
+		return nil, constants.ErrInternalServerError
+	}
+
+	// user already using the avatar keep it, it just can't be chosen anymore
+	if err := s.adapter.AvatarModule.DeleteAvatar(ctx, req.URL); err != nil {
+		s.adapter.LogModule.Log(err, req, "[RemoveAvatar] failed delete avatar")
+		s.recordFailedAudit(ctx, audit)
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.RemoveAvatarRes{Message: "success"}, nil
+}
+
+func (s *service) UploadAvatar(ctx context.Context, req api.UploadAvatarReq) (*api.UploadAvatarRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.adapter.UserModule.FindUserByID(ctx, req.UserID)
+	if err != nil {
+		if err == constants.ErrUserNotFound {
+			return nil, constants.ErrUserNotFound
+		}
+		s.adapter.LogModule.Log(err, req.UserID, "[UploadAvatar] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	attachment, err := s.storeImage(ctx, req.UserID, req.Data, req.ContentType)
+	if err != nil {
+		if err == constants.ErrInvalidImage {
+			return nil, constants.ErrInvalidImage
+		}
+		s.adapter.LogModule.Log(err, req.UserID, "[UploadAvatar] failed store image")
+		return nil, constants.ErrInternalServerError
+	}
+
+	user.Profile.Avatar = avatarURL(*attachment)
+	if err := s.adapter.UserModule.SaveProfile(ctx, *user); err != nil {
+		s.adapter.LogModule.Log(err, req.UserID, "[UploadAvatar] failed save profile")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.UploadAvatarRes{URL: user.Profile.Avatar}, nil
+}
+
+func (s *service) ModerateUser(ctx context.Context, req api.ModerateUserReq) (*api.ModerateUserRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeAdmin(ctx, req.UserID); err != nil {
+		if err == constants.ErrForbidden || err == constants.ErrUserNotFound {
+			return nil, err
+		}
+		s.adapter.LogModule.Log(err, req, "[ModerateUser] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	target, err := s.adapter.UserModule.FindUserByID(ctx, req.TargetID)
+	if err != nil {
+		if err == constants.ErrUserNotFound {
+			return nil, constants.ErrUserNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[ModerateUser] failed get target user")
+		return nil, constants.ErrInternalServerError
+	}
+	// admin can't be suspended or banned, including by themselves
+	if target.Account.Role == constants.RoleAdmin {
+		return nil, constants.ErrForbidden
+	}
+
+	var action string
+	after := target.Account
+	switch req.Action {
+	case constants.ModerateActionSuspend:
+		action = constants.AuditActionSuspendUser
+		after.SuspendedUntil = time.Now().Unix() + req.Duration
+		after.SuspensionReason = req.Reason
+	case constants.ModerateActionBan:
+		action = constants.AuditActionBanUser
+		after.BannedAt = time.Now().Unix()
+		after.BanReason = req.Reason
+	case constants.ModerateActionShadowban:
+		action = constants.AuditActionShadowbanUser
+		after.ShadowbannedAt = time.Now().Unix()
+	case constants.ModerateActionLift:
+		action = constants.AuditActionLiftRestriction
+		after.SuspendedUntil, after.SuspensionReason = 0, ""
+		after.BannedAt, after.BanReason = 0, ""
+		after.ShadowbannedAt = 0
+	}
+
+	// the audit log is written before the change, so a change is never made without it
+	audit := entity.AuditLog{
+		ActorID:    req.UserID,
+		Action:     action,
+		TargetType: constants.AuditTargetUser,
+		TargetID:   target.ID,
+		Before:     accountSnapshot(target.Account),
+		After:      accountSnapshot(after),
+		Reason:     req.Reason,
+	}
+	if err := s.adapter.AuditModule.InsertAuditLog(ctx, audit); err != nil {
+		s.adapter.LogModule.Log(err, req, "[ModerateUser] failed insert audit log")
+		return nil, constants.ErrInternalServerError
+	}
+
+	if err := s.applyModeration(ctx, req, *target, after); err != nil {
+		s.recordFailedAudit(ctx, audit)
+		return nil, err
+	}
+
+	return &api.ModerateUserRes{Message: "success"}, nil
+}
+
+// applyModeration save the account restriction of the target, the error is already logged
+func (s *service) applyModeration(ctx context.Context, req api.ModerateUserReq, target entity.User, after entity.Account) error {
+	switch req.Action {
+	case constants.ModerateActionSuspend:
+		if err := s.adapter.UserModule.SaveSuspension(ctx, entity.User{
+			ID: target.ID,
+			Account: entity.Account{
+				SuspendedUntil:   after.SuspendedUntil,
+				SuspensionReason: after.SuspensionReason,
+			},
+		}); err != nil {
+			s.adapter.LogModule.Log(err, req, "[ModerateUser] failed suspend user")
+			return constants.ErrInternalServerError
+		}
+	case constants.ModerateActionBan:
+		if err := s.adapter.UserModule.SaveBan(ctx, entity.User{
+			ID: target.ID,
+			Account: entity.Account{
+				BannedAt:  after.BannedAt,
+				BanReason: after.BanReason,
+			},
+		}); err != nil {
+			s.adapter.LogModule.Log(err, req, "[ModerateUser] failed ban user")
+			return constants.ErrInternalServerError
+		}
+		pushTokens, err := s.adapter.NotificationModule.FindPushTokenList(ctx, target.ID)
+		if err != nil {
+			s.adapter.LogModule.Log(err, req, "[ModerateUser] failed get push token list")
+			return constants.ErrInternalServerError
+		}
+		if err := s.adapter.UserModule.BanDevices(ctx, target.ID, pushTokens, req.Reason); err != nil {
+			s.adapter.LogModule.Log(err, req, "[ModerateUser] failed ban devices")
+			return constants.ErrInternalServerError
+		}
+	case constants.ModerateActionShadowban:
+		if err := s.adapter.UserModule.SaveShadowban(ctx, entity.User{
+			ID:      target.ID,
+			Account: entity.Account{ShadowbannedAt: after.ShadowbannedAt},
+		}); err != nil {
+			s.adapter.LogModule.Log(err, req, "[ModerateUser] failed shadowban user")
+			return constants.ErrInternalServerError
+		}
+		if err := s.adapter.PostModule.UpdateShadowbanStatus(ctx, target.ID, true); err != nil {
+			s.adapter.LogModule.Log(err, req, "[ModerateUser] failed shadowban post")
+			return constants.ErrInternalServerError
+		}
+	case constants.ModerateActionLift:
+		if err := s.adapter.UserModule.SaveSuspension(ctx, entity.User{ID: target.ID}); err != nil {
+			s.adapter.LogModule.Log(err, req, "[ModerateUser] failed lift suspension")
+			return constants.ErrInternalServerError
+		}
+		if err := s.adapter.UserModule.SaveBan(ctx, entity.User{ID: target.ID}); err != nil {
+			s.adapter.LogModule.Log(err, req, "[ModerateUser] failed lift ban")
+			return constants.ErrInternalServerError
+		}
+		if err := s.adapter.UserModule.UnbanDevices(ctx, target.ID); err != nil {
+			s.adapter.LogModule.Log(err, req, "[ModerateUser] failed unban devices")
+			return constants.ErrInternalServerError
+		}
+		// the posts are restored first, so a failure is retried while the user is still shadowbanned
+		if target.Account.ShadowbannedAt != 0 {
+			if err := s.adapter.PostModule.UpdateShadowbanStatus(ctx, target.ID, false); err != nil {
+				s.adapter.LogModule.Log(err, req, "[ModerateUser] failed lift shadowban post")
+				return constants.ErrInternalServerError
+			}
+			if err := s.adapter.UserModule.SaveShadowban(ctx, entity.User{ID: target.ID}); err != nil {
+				s.adapter.LogModule.Log(err, req, "[ModerateUser] failed lift shadowban")
+				return constants.ErrInternalServerError
+			}
+		}
+	}
+
+	return nil
+}
+
+// recordFailedAudit follow the audit log whose change failed after it was written with the AuditActionFailed entry,
+// so the log doesn't show a change that never happened
+func (s *service) recordFailedAudit(ctx context.Context, logs ...entity.AuditLog) {
+	for _, log := range logs {
+		if err := s.adapter.AuditModule.InsertAuditLog(ctx, entity.AuditLog{
+			ActorID:    log.ActorID,
+			Action:     constants.AuditActionFailed,
+			TargetType: log.TargetType,
+			TargetID:   log.TargetID,
+			Before:     log.After,
+			Reason:     log.Action,
+		}); err != nil {
+			s.adapter.LogModule.Log(err, log, "[recordFailedAudit] failed insert audit log")
+		}
+	}
+}
+
+// accountSnapshot is the account restriction recorded in the audit log
+func accountSnapshot(account entity.Account) entity.Snapshot {
+	return entity.Snapshot{
+		"suspended_until":   account.SuspendedUntil,
+		"suspension_reason": account.SuspensionReason,
+		"banned_at":         account.BannedAt,
+		"ban_reason":        account.BanReason,
+		"shadowbanned_at":   account.ShadowbannedAt,
+	}
+}
+
+// postSnapshot is the post content and visibility recorded in the audit log
+func postSnapshot(post entity.Post) entity.Snapshot {
+	return entity.Snapshot{
+		"body":             post.Body,
+		"user_id":          post.User.ID,
+		"author_id":        post.Author.ID,
+		"hidden":           post.IsHidden,
+		"content_warnings": post.ContentWarnings,
+	}
+}
+
+// changeSnapshot copy the snapshot with the field replaced, nil stay nil since the target doesn't exist
+func changeSnapshot(snapshot entity.Snapshot, key string, value interface{}) entity.Snapshot {
+	if snapshot == nil {
+		return nil
+	}
+	result := make(entity.Snapshot, len(snapshot))
+	for k, v := range snapshot {
+		result[k] = v
+	}
+	result[key] = value
+	return result
+}
+
+// authorizeAdmin return ErrForbidden if the user isn't admin
+func (s *service) authorizeAdmin(ctx context.Context, userID string) error {
+	user, err := s.adapter.UserModule.FindUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Account.Role != constants.RoleAdmin {
+		return constants.ErrForbidden
+	}
+	return nil
+}
+
+// authorizeModerator return ErrForbidden if the user is neither moderator nor admin
+func (s *service) authorizeModerator(ctx context.Context, userID string) error {
+	user, err := s.adapter.UserModule.FindUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Account.Role != constants.RoleModerator && user.Account.Role != constants.RoleAdmin {
+		return constants.ErrForbidden
+	}
+	return nil
+}
+
+// validateAvatar check the avatar is either in the catalog or an image uploaded by the user
+func (s *service) validateAvatar(ctx context.Context, userID string, url string) error {
+	_, err := s.adapter.AvatarModule.FindAvatarByURL(ctx, url)
+	if err == nil {
+		return nil
+	}
+	if err != constants.ErrAvatarNotFound {
+		return err
+	}
+
+	attachment, err := s.adapter.AttachmentModule.FindAttachmentByURL(ctx, url)
+	if err != nil {
+		if err == constants.ErrAttachmentNotFound {
+			return constants.ErrInvalidAvatar
+		}
+		return err
+	}
+	if attachment.OwnerID != userID {
+		return constants.ErrInvalidAvatar
+	}
+	return nil
+}
+
+// avatarURL pick the thumbnail of uploaded avatar, it's never shown bigger than that
+func avatarURL(attachment entity.Attachment) string {
+	for _, v := range attachment.Variants {
+		if v.Name == constants.ImageVariantThumbnail {
+			return v.URL
+		}
+	}
+	return attachment.URL
+}
+
+func (s *service) GetPost(ctx context.Context, req api.GetPostReq) (*api.GetPostRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	post, err := s.adapter.PostModule.FindPostByID(ctx, req.ID, req.UserID)
+	if err != nil {
+		if err == constants.ErrPostNotFound {
+			return nil, constants.ErrPostNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[GetPost] failed get post")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.GetPostRes{Post: *post}, nil
+}
+
+func (s *service) GetPostList(ctx context.Context, req api.GetPostListReq) (*api.GetPostListRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	hidden, err := s.adapter.UserModule.FindHiddenUserIDs(ctx, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetPostList] failed get hidden user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	postList, pagination, err := s.adapter.PostModule.FindPostListByParentIDAndAuthorIDs(ctx,
+		req.ParentID,
+		nil,
+		hidden,
+		req.UserID,
+		req.Pagination)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetPostList] failed get post list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	postList, err = s.filterMutedWords(ctx, postList, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetPostList] failed filter muted word")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.GetPostListRes{
+		PostList:   postList,
+		Pagination: *pagination,
+	}, nil
+}
+
+func (s *service) CreatePost(ctx context.Context, req api.CreatePostReq) (*api.CreatePostRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkRateLimit(ctx, userRateLimits("create_post", req.UserID, req.ClientIP,
+		constants.RateLimitCreatePostUser, constants.RateLimitCreatePostIP)); err != nil {
+		if errors.Is(err, constants.ErrRateLimited) {
+			return nil, err
+		}
+		s.adapter.LogModule.Log(err, req, "[CreatePost] failed check rate limit")
+		return nil, constants.ErrInternalServerError
+	}
+
+	user, err := s.adapter.UserModule.FindUserByID(ctx, req.UserID)
+	if err != nil {
+		if err == constants.ErrUserNotFound {
+			return nil, constants.ErrUserNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[CreatePost] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	var parent *entity.Post
+	if req.ParentID != "" {
+		parent, err = s.adapter.PostModule.FindPostByID(ctx, req.ParentID, req.UserID)
+		if err != nil {
+			if err == constants.ErrPostNotFound {
+				return nil, constants.ErrPostNotFound
+			}
+			s.adapter.LogModule.Log(err, req, "[CreatePost] failed get post")
+			return nil, constants.ErrInternalServerError
+		}
+		blocked, err := s.adapter.UserModule.IsBlocked(ctx, parent.Author.ID, req.UserID)
+		if err != nil {
+			s.adapter.LogModule.Log(err, req, "[CreatePost] failed get block status")
+			return nil, constants.ErrInternalServerError
+		}
+		if blocked {
+			return nil, constants.ErrBlocked
+		}
+	}
+
+	post := entity.Post{
+		Body:         req.Body,
+		Parent:       &entity.Post{ID: req.ParentID},
+		Author:       entity.User{ID: req.AuthorID},
+		User:         *user,
+		IsShadowbanned: user.Account.ShadowbannedAt != 0,
+	}
+	if req.QuotedPostID != "" {
+		quoted, err := s.adapter.PostModule.FindPostByID(ctx, req.QuotedPostID, req.UserID)
+		if err != nil {
+			if err == constants.ErrPostNotFound {
+				return nil, constants.ErrPostNotFound
+			}
+			s.adapter.LogModule.Log(err, req, "[CreatePost] failed get quoted post")
+			return nil, constants.ErrInternalServerError
+		}
+		post.QuotedPost = originalPost(*quoted)
+	}
+	if len(req.AttachmentIDs) > 0 {
+		attachments, err := s.adapter.AttachmentModule.FindAttachmentListByIDs(ctx, req.AttachmentIDs)
+		if err != nil {
+			s.adapter.LogModule.Log(err, req, "[CreatePost] failed get attachment")
+			return nil, constants.ErrInternalServerError
+		}
+		if len(attachments) != len(req.AttachmentIDs) {
+			return nil, constants.ErrAttachmentNotFound
+		}
+		for _, v := range attachments {
+			if v.OwnerID != req.UserID {
+				return nil, constants.ErrAttachmentNotFound
+			}
+		}
+		post.Attachments = attachments
+	}
+	if req.Poll != nil {
+		post.Poll = newPoll(*req.Poll, time.Now().Unix())
+	}
+	post.ContentWarnings = req.ContentWarnings
+	// personal data is masked before anything else read the body
+	unconfirmed, err := s.redactPersonalData(ctx, &post)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[CreatePost] failed redact personal data")
+		return nil, constants.ErrInternalServerError
+	}
+	if len(unconfirmed) > 0 && !req.ConfirmPersonalData {
+		return nil, &constants.PersonalDataError{Texts: unconfirmed}
+	}
+	post.MentionIDs, err = s.findMentionIDs(ctx, post.Body, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[CreatePost] failed get mentioned user")
+		return nil, constants.ErrInternalServerError
+	}
+	// preview is fetched by the worker, so creating post doesn't wait for other site
+	post.Links = utils.ExtractURLs(post.Body)
+	if len(post.Links) > constants.PostMaxLinkPreviews {
+		post.Links = post.Links[:constants.PostMaxLinkPreviews]
+	}
+	if req.PublishAt != 0 {
+		post.IsScheduled = true
+		post.Timestamp = req.PublishAt
+	}
+	if req.ExpiresIn != 0 {
+		publishedAt := time.Now().Unix()
+		if post.IsScheduled {
+			publishedAt = post.Timestamp
+		}
+		post.ExpiredAt = publishedAt + req.ExpiresIn
+	}
+	// reply can't outlive its parent, it is deleted together with the parent
+	if parent != nil && parent.ExpiredAt != 0 && (post.ExpiredAt == 0 || post.ExpiredAt > parent.ExpiredAt) {
+		post.ExpiredAt = parent.ExpiredAt
+	}
+
+	duplicate, err := s.isDuplicatePost(ctx, post)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[CreatePost] failed get recent post")
+		return nil, constants.ErrInternalServerError
+	}
+	if duplicate {
+		return nil, constants.ErrDuplicatePost
+	}
+
+	moderation, err := s.adapter.ModerationModule.ReviewPost(ctx, post)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[CreatePost] failed review post")
+		return nil, constants.ErrInternalServerError
+	}
+	if moderation.Decision == constants.ModerationDecisionReject {
+		return nil, &constants.ModerationError{Reasons: moderation.Reasons}
+	}
+	// flagged post is saved hidden and queued for moderator, dismissing the report publish it
+	post.IsHidden = moderation.Decision == constants.ModerationDecisionFlag
+
+	id, err := s.adapter.PostModule.InsertPost(ctx, post)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[CreatePost] failed save post")
+		return nil, constants.ErrInternalServerError
+	}
+	post.ID = id
+
+	if post.IsHidden {
+		if _, err := s.adapter.ReportModule.InsertReport(ctx, entity.Report{
+			PostID:         id,
+			ReportedUserID: req.UserID,
+			Reason:         constants.ReportReasonAutomated,
+			Details:        strings.Join(moderation.Reasons, ", "),
+		}); err != nil {
+			s.adapter.LogModule.Log(err, req, "[CreatePost] failed queue flagged post")
+			// hidden post not in the queue would never be reviewed, the user can post it again
+			if err := s.adapter.PostModule.DeletePost(ctx, id); err != nil {
+				s.adapter.LogModule.Log(err, req, "[CreatePost] failed delete unqueued flagged post")
+			}
+			return nil, constants.ErrInternalServerError
+		}
+		return &api.CreatePostRes{Message: "pending review"}, nil
+	}
+
+	// scheduled post notification is sent when it's published
+	if post.IsScheduled {
+		return &api.CreatePostRes{Message: "success"}, nil
+	}
+
+	s.sendMentionNotification(ctx, post, parent)
+	if parent != nil {
+		if s.canNotify(ctx, parent.User.ID, req.UserID, post.Body) {
+			if err := s.adapter.NotificationModule.SendCommentNotification(ctx, post, *parent); err != nil {
+				s.adapter.LogModule.Log(err, req, "[CreatePost] failed send notification")
+			}
+		}
+	} else {
+		if err := s.adapter.NotificationModule.BroadcastNewPostNotification(ctx, post); err != nil {
+			s.adapter.LogModule.Log(err, req, "[CreatePost] failed send notification")
+		}
+	}
+
+	return &api.CreatePostRes{Message: "success"}, nil
+}
+
+// isDuplicatePost compare the body with the recent posts of the user by simhash,
+// so the same spam with a few words changed is caught too
+func (s *service) isDuplicatePost(ctx context.Context, post entity.Post) (bool, error) {
+	if utf8.RuneCountInString(post.Body) < constants.PostDuplicateMinLength {
+		return false, nil
+	}
+	recent, err := s.adapter.PostModule.FindRecentPostListByUserID(ctx, post.User.ID,
+		time.Now().Unix()-constants.PostDuplicateWindow, constants.PostDuplicateLookback)
+	if err != nil {
+		return false, err
+	}
+	hash := utils.Simhash(post.Body)
+	for _, v := range recent {
+		if utf8.RuneCountInString(v.Body) < constants.PostDuplicateMinLength {
+			continue
+		}
+		if utils.HammingDistance(hash, utils.Simhash(v.Body)) <= constants.PostDuplicateMaxDistance {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// redactPersonalData mask the personal data in the body and the poll options,
+// and return the found text that needs the user confirmation
+func (s *service) redactPersonalData(ctx context.Context, post *entity.Post) ([]string, error) {
+	var unconfirmed []string
+	redact := func(text string) (string, error) {
+		redaction, err := s.adapter.RedactionModule.Redact(ctx, text)
+		if err != nil {
+			return "", err
+		}
+		for _, v := range redaction.Unconfirmed {
+			unconfirmed = append(unconfirmed, v.Text)
+		}
+		return redaction.Text, nil
+	}
+
+	var err error
+	if post.Body, err = redact(post.Body); err != nil {
+		return nil, err
+	}
+	if post.Poll != nil {
+		for i := range post.Poll.Options {
+			if post.Poll.Options[i].Text, err = redact(post.Poll.Options[i].Text); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return unconfirmed, nil
+}
+
+// findMentionIDs resolve the mentioned handles to user id, unknown handle and self mention are ignored
+func (s *service) findMentionIDs(ctx context.Context, body string, userID string) ([]string, error) {
+	handles := utils.ExtractMentions(body)
+	if len(handles) == 0 {
+		return nil, nil
+	}
+	if len(handles) > constants.PostMaxMentions {
+		handles = handles[:constants.PostMaxMentions]
+	}
+	users, err := s.adapter.UserModule.FindUserListByHandles(ctx, handles)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, v := range users {
+		if v.ID != userID {
+			ids = append(ids, v.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	// user who blocked the poster can't be mentioned
+	blockers, err := s.adapter.UserModule.FindBlockerIDsIn(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	mapBlocker := make(map[string]bool)
+	for _, v := range blockers {
+		mapBlocker[v] = true
+	}
+	var result []string
+	for _, v := range ids {
+		if !mapBlocker[v] {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+// canNotify return false if the receiver blocked the user who trigger the notification,
+// the notification is dropped too if the block status can't be checked.
+// It also return false if the text shown in the notification has the receiver muted word.
+func (s *service) canNotify(ctx context.Context, receiverID string, userID string, text string) bool {
+	blocked, err := s.adapter.UserModule.IsBlocked(ctx, receiverID, userID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, receiverID, "[canNotify] failed get block status")
+		return false
+	}
+	if blocked {
+		return false
+	}
+	if text == "" {
+		return true
+	}
+
+	words, err := s.adapter.UserModule.FindMutedWordList(ctx, receiverID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, receiverID, "[canNotify] failed get muted word list")
+		return true
+	}
+	return !newMutedWordMatcher(words).MatchAny(text)
+}
+
+// filterMutedWords drop the post, or the repost of a post, containing the user muted word.
+// The user own post is always shown.
+func (s *service) filterMutedWords(ctx context.Context, posts []entity.Post, userID string) ([]entity.Post, error) {
+	if len(posts) == 0 {
+		return posts, nil
+	}
+	words, err := s.adapter.UserModule.FindMutedWordList(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) == 0 {
+		return posts, nil
+	}
+
+	matcher := newMutedWordMatcher(words)
+	result := make([]entity.Post, 0, len(posts))
+	for _, v := range posts {
+		if v.User.ID != userID && matcher.MatchAny(v.Body) {
+			continue
+		}
+		if v.QuotedPost != nil && v.QuotedPost.User.ID != userID && matcher.MatchAny(v.QuotedPost.Body) {
+			continue
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+func newMutedWordMatcher(words []entity.MutedWord) *utils.Matcher {
+	patterns := make([]string, len(words))
+	for i, v := range words {
+		patterns[i] = v.Word
+	}
+	return utils.NewMatcher(patterns)
+}
+
+// sendMentionNotification notify the mentioned users, parent author is skipped since it get comment notification
+func (s *service) sendMentionNotification(ctx context.Context, post entity.Post, parent *entity.Post) {
+	var userIDs []string
+	for _, v := range post.MentionIDs {
+		if parent != nil && parent.User.ID == v {
+			continue
+		}
+		if !s.canNotify(ctx, v, post.User.ID, post.Body) {
+			continue
+		}
+		userIDs = append(userIDs, v)
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+	if err := s.adapter.NotificationModule.SendMentionNotification(ctx, post, userIDs); err != nil {
+		s.adapter.LogModule.Log(err, post, "[SendMentionNotification] failed send notification")
+	}
+}
+
+func (s *service) UploadImage(ctx context.Context, req api.UploadImageReq) (*api.UploadImageRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.storeImage(ctx, req.UserID, req.Data, req.ContentType)
+	if err != nil {
+		if err == constants.ErrInvalidImage {
+			return nil, constants.ErrInvalidImage
+		}
+		s.adapter.LogModule.Log(err, req.UserID, "[UploadImage] failed store image")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.UploadImageRes{Attachment: *attachment}, nil
+}
+
+// storeImage process the image, store all of its variants and save it as attachment owned by the user
+func (s *service) storeImage(ctx context.Context, userID string, data []byte, contentType string) (*entity.Attachment, error) {
+	// re-encoding also drop exif, uploader's location and device could leak from it
+	img, err := s.adapter.MediaModule.ProcessImage(ctx, data, contentType)
+	if err != nil {
+		return nil, constants.ErrInvalidImage
+	}
+
+	key := "attachments/" + utils.RandomHex(16) + "/"
+	size := len(img.Variants[0].Data)
+	for i, v := range img.Variants {
+		url, err := s.adapter.StorageModule.Put(ctx, key+v.Name+constants.ImageExtensions[v.ContentType], v.Data, v.ContentType)
+		if err != nil {
+			return nil, err
+		}
+		img.Variants[i].URL = url
+		img.Variants[i].Data = nil
+	}
+
+	original := img.Variants[0]
+	attachment := entity.Attachment{
+		URL:         original.URL,
+		ContentType: original.ContentType,
+		Size:        size,
+		Width:       original.Width,
+		Height:      original.Height,
+		BlurHash:    img.BlurHash,
+		Variants:    img.Variants,
+		OwnerID:     userID,
+	}
+	id, err := s.adapter.AttachmentModule.InsertAttachment(ctx, attachment)
+	if err != nil {
+		return nil, err
+	}
+	attachment.ID = id
+
+	return &attachment, nil
+}
+
+func (s *service) LikePost(ctx context.Context, req api.LikePostReq) (*api.LikePostRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkRateLimit(ctx, userRateLimits("like_post", req.UserID, req.ClientIP,
+		constants.RateLimitLikePostUser, constants.RateLimitLikePostIP)); err != nil {
+		if errors.Is(err, constants.ErrRateLimited) {
+			return nil, err
+		}
+		s.adapter.LogModule.Log(err, req, "[LikePost] failed check rate limit")
+		return nil, constants.ErrInternalServerError
+	}
+
+	user, err := s.adapter.UserModule.FindUserByID(ctx, req.UserID)
+	if err != nil {
+		if err == constants.ErrUserNotFound {
+			return nil, constants.ErrUserNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[LikePost] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	post, err := s.adapter.PostModule.FindPostByID(ctx, req.PostID, req.UserID)
+	if err != nil {
+		if err == constants.ErrPostNotFound {
+			return nil, constants.ErrPostNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[LikePost] failed get post")
+		return nil, constants.ErrInternalServerError
+	}
+
+	if post.IsLiked {
+		if err := s.adapter.PostModule.UnlikePost(ctx, req.PostID, req.UserID); err != nil {
+			s.adapter.LogModule.Log(err, req, "[LikePost] failed unlike post")
+			return nil, constants.ErrInternalServerError
+		}
+	} else {
+		blocked, err := s.adapter.UserModule.IsBlocked(ctx, post.Author.ID, req.UserID)
+		if err != nil {
+			s.adapter.LogModule.Log(err, req, "[LikePost] failed get block status")
+			return nil, constants.ErrInternalServerError
+		}
+		if blocked {
+			return nil, constants.ErrBlocked
+		}
+		if err := s.adapter.PostModule.LikePost(ctx, req.PostID, req.UserID, user.Account.ShadowbannedAt != 0); err != nil {
+			s.adapter.LogModule.Log(err, req, "[LikePost] failed like post")
+			return nil, constants.ErrInternalServerError
+		}
+		// the liked post is the receiver own post, so muted word doesn't apply
+		if s.canNotify(ctx, post.User.ID, req.UserID, "") {
+			if err := s.adapter.NotificationModule.SendLikeNotification(ctx, *user, *post); err != nil {
+				s.adapter.LogModule.Log(err, req, "[LikePost] failed send notification")
+			}
+		}
+	}
+
+	return &api.LikePostRes{Message: "success"}, nil
+}
+
+func (s *service) GetScheduledPostList(ctx context.Context, req api.GetScheduledPostListReq) (*api.GetScheduledPostListRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	postList, pagination, err := s.adapter.PostModule.FindScheduledPostListByUserID(ctx, req.UserID, req.Pagination)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetScheduledPostList] failed get scheduled post list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.GetScheduledPostListRes{
+		PostList:   postList,
+		Pagination: *pagination,
+	}, nil
+}
+
+func (s *service) CancelScheduledPost(ctx context.Context, req api.CancelScheduledPostReq) (*api.CancelScheduledPostRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	post, err := s.adapter.PostModule.FindScheduledPostByID(ctx, req.PostID)
+	if err != nil {
+		if err == constants.ErrPostNotFound {
+			return nil, constants.ErrPostNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[CancelScheduledPost] failed get scheduled post")
+		return nil, constants.ErrInternalServerError
+	}
+	// don't leak other user scheduled post
+	if post.User.ID != req.UserID {
+		return nil, constants.ErrPostNotFound
+	}
+
+	if err := s.adapter.PostModule.DeleteScheduledPost(ctx, req.PostID); err != nil {
+		if err == constants.ErrPostNotFound {
+			return nil, constants.ErrPostNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[CancelScheduledPost] failed delete post")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.CancelScheduledPostRes{Message: "success"}, nil
+}
+
+func (s *service) SetContentWarnings(ctx context.Context, req api.SetContentWarningsReq) (*api.SetContentWarningsRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	post, err := s.adapter.PostModule.FindPostByID(ctx, req.PostID, req.UserID)
+	if err != nil {
+		if err == constants.ErrPostNotFound {
+			return nil, constants.ErrPostNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[SetContentWarnings] failed get post")
+		return nil, constants.ErrInternalServerError
+	}
+	// other than the author, only moderator can mark the post
+	if post.User.ID != req.UserID {
+		if err := s.authorizeModerator(ctx, req.UserID); err != nil {
+			if err == constants.ErrForbidden {
+				return nil, constants.ErrForbidden
+			}
+			s.adapter.LogModule.Log(err, req, "[SetContentWarnings] failed get user")
+			return nil, constants.ErrInternalServerError
+		}
+	}
+
+	// the author marking their own post isn't moderation
+	var audits []entity.AuditLog
+	if post.User.ID != req.UserID {
+		audits = append(audits, entity.AuditLog{
+			ActorID:    req.UserID,
+			Action:     constants.AuditActionSetContentWarnings,
+			TargetType: constants.AuditTargetPost,
+			TargetID:   req.PostID,
+			Before:     postSnapshot(*post),
+			After:      changeSnapshot(postSnapshot(*post), "content_warnings", req.ContentWarnings),
+		})
+	}
+	for _, audit := range audits {
+		if err := s.adapter.AuditModule.InsertAuditLog(ctx, audit); err != nil {
+			s.adapter.LogModule.Log(err, req, "[SetContentWarnings] failed insert audit log")
+			return nil, constants.ErrInternalServerError
+		}
+	}
+
+	if err := s.adapter.PostModule.UpdateContentWarnings(ctx, req.PostID, req.ContentWarnings); err != nil {
+		s.adapter.LogModule.Log(err, req, "[SetContentWarnings] failed update content warnings")
+		s.recordFailedAudit(ctx, audits...)
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.SetContentWarningsRes{Message: "success"}, nil
+}
+
+func (s *service) VotePoll(ctx context.Context, req api.VotePollReq) (*api.VotePollRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	post, err := s.adapter.PostModule.FindPostByID(ctx, req.PostID, req.UserID)
+	if err != nil {
+		if err == constants.ErrPostNotFound {
+			return nil, constants.ErrPostNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[VotePoll] failed get post")
+		return nil, constants.ErrInternalServerError
+	}
+	if post.Poll == nil {
+		return nil, constants.ErrPostHasNoPoll
+	}
+	if post.Poll.IsClosed(time.Now().Unix()) {
+		return nil, constants.ErrPollClosed
+	}
+	if len(post.Poll.MyVotes) > 0 {
+		return nil, constants.ErrAlreadyVoted
+	}
+	if !post.Poll.MultipleChoice && len(req.OptionIDs) > 1 {
+		return nil, constants.ErrInvalidPollOption
+	}
+	validOption := make(map[string]bool)
+	for _, v := range post.Poll.Options {
+		validOption[v.ID] = true
+	}
+	for _, v := range req.OptionIDs {
+		if !validOption[v] {
+			return nil, constants.ErrInvalidPollOption
+		}
+		// prevent the same option counted twice
+		validOption[v] = false
+	}
+
+	if err := s.adapter.PostModule.VotePoll(ctx, req.PostID, req.UserID, req.OptionIDs); err != nil {
+		if err == constants.ErrAlreadyVoted {
+			return nil, err
+		}
+		s.adapter.LogModule.Log(err, req, "[VotePoll] failed vote poll")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.VotePollRes{Message: "success"}, nil
+}
+
+func (s *service) RepostPost(ctx context.Context, req api.RepostPostReq) (*api.RepostPostRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.adapter.UserModule.FindUserByID(ctx, req.UserID)
+	if err != nil {
+		if err == constants.ErrUserNotFound {
+			return nil, constants.ErrUserNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[RepostPost] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	post, err := s.adapter.PostModule.FindPostByID(ctx, req.PostID, req.UserID)
+	if err != nil {
+		if err == constants.ErrPostNotFound {
+			return nil, constants.ErrPostNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[RepostPost] failed get post")
+		return nil, constants.ErrInternalServerError
+	}
+	original := originalPost(*post)
+
+	repost, err := s.adapter.PostModule.FindRepost(ctx, original.ID, req.UserID)
+	if err != nil && err != constants.ErrPostNotFound {
+		s.adapter.LogModule.Log(err, req, "[RepostPost] failed get repost")
+		return nil, constants.ErrInternalServerError
+	}
+
+	if repost != nil {
+		if err := s.adapter.PostModule.DeletePost(ctx, repost.ID); err != nil {
+			s.adapter.LogModule.Log(err, req, "[RepostPost] failed delete repost")
+			return nil, constants.ErrInternalServerError
+		}
+	} else {
+		if _, err := s.adapter.PostModule.InsertPost(ctx, entity.Post{
+			IsRepost:   true,
+			QuotedPost: original,
+			Parent:     &entity.Post{},
+			Author:     *user,
+			User:       *user,
+		}); err != nil {
+			s.adapter.LogModule.Log(err, req, "[RepostPost] failed insert repost")
+			return nil, constants.ErrInternalServerError
+		}
+	}
+
+	return &api.RepostPostRes{Message: "success"}, nil
+}
+
+func (s *service) BookmarkPost(ctx context.Context, req api.BookmarkPostReq) (*api.BookmarkPostRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	post, err := s.adapter.PostModule.FindPostByID(ctx, req.PostID, req.UserID)
+	if err != nil {
+		if err == constants.ErrPostNotFound {
+			return nil, constants.ErrPostNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[BookmarkPost] failed get post")
+		return nil, constants.ErrInternalServerError
+	}
+
+	if post.IsBookmarked {
+		if err := s.adapter.BookmarkModule.DeleteBookmark(ctx, req.UserID, req.PostID); err != nil {
+			s.adapter.LogModule.Log(err, req, "[BookmarkPost] failed delete bookmark")
+			return nil, constants.ErrInternalServerError
+		}
+	} else {
+		if err := s.adapter.BookmarkModule.InsertBookmark(ctx, req.UserID, req.PostID); err != nil {
+			s.adapter.LogModule.Log(err, req, "[BookmarkPost] failed insert bookmark")
+			return nil, constants.ErrInternalServerError
+		}
+	}
+
+	return &api.BookmarkPostRes{Message: "success"}, nil
+}
+
+func (s *service) GetBookmarkList(ctx context.Context, req api.GetBookmarkListReq) (*api.GetBookmarkListRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	ids, pagination, err := s.adapter.BookmarkModule.FindBookmarkedPostIDs(ctx, req.UserID, req.Pagination)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetBookmarkList] failed get bookmarked post id")
+		return nil, constants.ErrInternalServerError
+	}
+	if len(ids) == 0 {
+		return &api.GetBookmarkListRes{Pagination: *pagination}, nil
+	}
+
+	postList, err := s.adapter.PostModule.FindPostListByIDs(ctx, ids, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetBookmarkList] failed get post list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.GetBookmarkListRes{
+		PostList:   postList,
+		Pagination: *pagination,
+	}, nil
+}
+
+// FollowUser toggle the follow status, kept for the old follow mutation
+func (s *service) FollowUser(ctx context.Context, req api.FollowUserReq) (*api.FollowUserRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	isFollowing, err := s.adapter.UserModule.IsFollowing(ctx, req.UserID, req.FollowedID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[FollowUser] failed get follow status")
+		return nil, constants.ErrInternalServerError
+	}
+
+	if isFollowing {
+		if _, err := s.Unfollow(ctx, api.UnfollowReq{UserID: req.UserID, FollowedID: req.FollowedID}); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := s.Follow(ctx, api.FollowReq{UserID: req.UserID, FollowedID: req.FollowedID, ClientIP: req.ClientIP}); err != nil {
+			return nil, err
+		}
+	}
+	return &api.FollowUserRes{Message: "success"}, nil
+}
+
+func (s *service) Follow(ctx context.Context, req api.FollowReq) (*api.FollowRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkRateLimit(ctx, userRateLimits("follow", req.UserID, req.ClientIP,
+		constants.RateLimitFollowUser, constants.RateLimitFollowIP)); err != nil {
+		if errors.Is(err, constants.ErrRateLimited) {
+			return nil, err
+		}
+		s.adapter.LogModule.Log(err, req, "[Follow] failed check rate limit")
+		return nil, constants.ErrInternalServerError
+	}
+
+	if _, err := s.adapter.UserModule.FindUserByID(ctx, req.FollowedID); err != nil {
+		if err == constants.ErrUserNotFound {
+			return nil, constants.ErrUserNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[Follow] failed get followed user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	blocked, err := s.adapter.UserModule.IsBlocked(ctx, req.FollowedID, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[Follow] failed get block status")
+		return nil, constants.ErrInternalServerError
+	}
+	if blocked {
+		return nil, constants.ErrBlocked
+	}
+
+	user, err := s.adapter.UserModule.FindUserByID(ctx, req.UserID)
+	if err != nil {
+		if err == constants.ErrUserNotFound {
+			return nil, constants.ErrUserNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[Follow] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	created, err := s.adapter.UserModule.UpdateFollowStatus(ctx, req.UserID, req.FollowedID, constants.FollowStatusActive)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[Follow] failed update follow status")
+		return nil, constants.ErrInternalServerError
+	}
+	// retried or concurrent request doesn't notify the followed user twice
+	if !created {
+		return &api.FollowRes{Message: "success"}, nil
+	}
+	if err := s.adapter.NotificationModule.SendFollowNotification(ctx, *user, req.FollowedID); err != nil {
+		s.adapter.LogModule.Log(err, req, "[Follow] failed send notification")
+	}
+
+	return &api.FollowRes{Message: "success"}, nil
+}
+
+func (s *service) Unfollow(ctx context.Context, req api.UnfollowReq) (*api.UnfollowRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.adapter.UserModule.UpdateFollowStatus(ctx, req.UserID, req.FollowedID, constants.FollowStatusInactive); err != nil {
+		s.adapter.LogModule.Log(err, req, "[Unfollow] failed update follow status")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.UnfollowRes{Message: "success"}, nil
+}
+
+func (s *service) Feed(ctx context.Context, req api.FeedReq) (*api.FeedRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var followed []string
+	if req.Type == constants.FeedTypeFollow {
+		userIDs, err := s.adapter.UserModule.GetFollowedUserID(ctx, req.UserID)
+		if err != nil {
+			s.adapter.LogModule.Log(err, req, "[Feed] failed get followed user")
+			return nil, constants.ErrInternalServerError
+		}
+		if len(userIDs) == 0 {
+			return nil, constants.ErrUserNotFollowAnyone
+		}
+		followed = userIDs
+	}
+
+	hidden, err := s.adapter.UserModule.FindHiddenUserIDs(ctx, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[Feed] failed get hidden user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	postList, pagination, err := s.adapter.PostModule.FindPostListByParentIDAndAuthorIDs(ctx,
+		"",
+		followed,
+		hidden,
+		req.UserID,
+		req.Pagination)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetPostList] failed get post list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	user, err := s.adapter.UserModule.FindUserByID(ctx, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[Feed] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	postList, err = s.filterMutedWords(ctx, postList, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[Feed] failed filter muted word")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.FeedRes{
+		PostList:   applySensitiveContent(postList, req.UserID, user.Settings.SensitiveContent),
+		Pagination: *pagination,
+	}, nil
+}
+
+// applySensitiveContent hide or blur post with content warning following the user setting,
+// the user own post is always shown as is
+func applySensitiveContent(posts []entity.Post, userID string, setting string) []entity.Post {
+	if setting == "" {
+		setting = constants.SensitiveContentDefault
+	}
+	if setting == constants.SensitiveContentShow {
+		return posts
+	}
+
+	result := make([]entity.Post, 0, len(posts))
+	for _, v := range posts {
+		isSensitive := len(v.ContentWarnings) > 0 && v.User.ID != userID
+		// repost show the quoted post, so its warning apply to the repost too
+		isQuotedSensitive := v.QuotedPost != nil && len(v.QuotedPost.ContentWarnings) > 0 && v.QuotedPost.User.ID != userID
+		if setting == constants.SensitiveContentHide && (isSensitive || isQuotedSensitive) {
+			continue
+		}
+		v.IsBlurred = isSensitive
+		if isQuotedSensitive {
+			quoted := *v.QuotedPost
+			quoted.IsBlurred = true
+			v.QuotedPost = &quoted
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+func (s *service) Trending(ctx context.Context, req api.TrendingReq) (*api.TrendingRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	trending, err := s.adapter.TrendingModule.FindTrendingPostList(ctx, req.Window, req.First)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[Trending] failed get trending post list")
+		return nil, constants.ErrInternalServerError
+	}
+	if len(trending) == 0 {
+		return &api.TrendingRes{}, nil
+	}
+
+	ids := make([]string, len(trending))
+	for i, v := range trending {
+		ids[i] = v.PostID
+	}
+	postList, err := s.adapter.PostModule.FindPostListByIDs(ctx, ids, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[Trending] failed get post list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	// the ranking is shared by every user, so the viewer filters of the feed apply here
+	hidden, err := s.adapter.UserModule.FindHiddenUserIDs(ctx, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[Trending] failed get hidden user")
+		return nil, constants.ErrInternalServerError
+	}
+	isHidden := make(map[string]bool)
+	for _, v := range hidden {
+		isHidden[v] = true
+	}
+	var visible []entity.Post
+	for _, v := range postList {
+		if isHidden[v.Author.ID] || (v.QuotedPost != nil && isHidden[v.QuotedPost.Author.ID]) {
+			continue
+		}
+		visible = append(visible, v)
+	}
+
+	user, err := s.adapter.UserModule.FindUserByID(ctx, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[Trending] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	visible, err = s.filterMutedWords(ctx, visible, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[Trending] failed filter muted word")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.TrendingRes{PostList: applySensitiveContent(visible, req.UserID, user.Settings.SensitiveContent)}, nil
+}
+
+func (s *service) TrendingTopics(ctx context.Context, req api.TrendingTopicsReq) (*api.TrendingTopicsRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	topics, err := s.adapter.TrendingModule.FindTrendingTopicList(ctx, req.Window, req.First)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[TrendingTopics] failed get trending topic list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.TrendingTopicsRes{Topics: topics}, nil
+}
+
+func (s *service) ReportPost(ctx context.Context, req api.ReportPostReq) (*api.ReportPostRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	post, err := s.adapter.PostModule.FindPostByID(ctx, req.PostID, req.UserID)
+	if err != nil {
+		if err == constants.ErrPostNotFound {
+			return nil, constants.ErrPostNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[ReportPost] failed get post")
+		return nil, constants.ErrInternalServerError
+	}
+	if post.User.ID == req.UserID {
+		return nil, constants.ErrReportOwnPost
+	}
+
+	if _, err := s.adapter.ReportModule.InsertReport(ctx, entity.Report{
+		PostID:         post.ID,
+		ReportedUserID: post.User.ID,
+		ReporterID:     req.UserID,
+		Reason:         req.Reason,
+		Details:        req.Details,
+	}); err != nil {
+		// reporting the same post again doesn't count twice
+		if err == constants.ErrAlreadyReported {
+			return &api.ReportPostRes{Message: "success"}, nil
+		}
+		s.adapter.LogModule.Log(err, req, "[ReportPost] failed insert report")
+		return nil, constants.ErrInternalServerError
+	}
+
+	// the report is already saved, failing to hide only leave the post shown until it's reviewed
+	count, err := s.adapter.ReportModule.CountPendingReportByPostID(ctx, post.ID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[ReportPost] failed count report")
+		return &api.ReportPostRes{Message: "success"}, nil
+	}
+	if count >= s.reportHideThreshold() {
+		if err := s.adapter.PostModule.UpdateHiddenStatus(ctx, post.ID, true); err != nil {
+			s.adapter.LogModule.Log(err, req, "[ReportPost] failed hide post")
+		}
+	}
+
+	return &api.ReportPostRes{Message: "success"}, nil
+}
+
+func (s *service) reportHideThreshold() int {
+	if s.config.ReportHideThreshold < 1 {
+		return constants.ReportHideThresholdDefault
+	}
+	return s.config.ReportHideThreshold
+}
+
+func (s *service) GetModerationQueue(ctx context.Context, req api.GetModerationQueueReq) (*api.GetModerationQueueRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeModerator(ctx, req.UserID); err != nil {
+		if err == constants.ErrForbidden || err == constants.ErrUserNotFound {
+			return nil, err
+		}
+		s.adapter.LogModule.Log(err, req, "[GetModerationQueue] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	reports, pagination, err := s.adapter.ReportModule.FindPendingReportList(ctx, req.Pagination)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetModerationQueue] failed get report list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	var postIDs []string
+	found := make(map[string]bool)
+	for _, v := range reports {
+		if found[v.PostID] {
+			continue
+		}
+		found[v.PostID] = true
+		postIDs = append(postIDs, v.PostID)
+	}
+	counts, err := s.adapter.ReportModule.CountPendingReportByPostIDs(ctx, postIDs)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetModerationQueue] failed count report")
+		return nil, constants.ErrInternalServerError
+	}
+	// the report resolved after the list was read is counted as zero
+	reportsCount := make(map[string]int)
+	for _, id := range postIDs {
+		reportsCount[id] = counts[id]
+	}
+
+	posts, err := s.adapter.PostModule.FindPostListByIDsIncludeHidden(ctx, postIDs)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetModerationQueue] failed get post list")
+		return nil, constants.ErrInternalServerError
+	}
+	mapPost := make(map[string]entity.Post)
+	for _, v := range posts {
+		mapPost[v.ID] = v
+	}
+	for i, v := range reports {
+		if post, ok := mapPost[v.PostID]; ok {
+			reports[i].Post = &post
+		}
+	}
+
+	return &api.GetModerationQueueRes{
+		ReportList:   reports,
+		ReportsCount: reportsCount,
+		Pagination:   *pagination,
+	}, nil
+}
+
+func (s *service) ResolveReport(ctx context.Context, req api.ResolveReportReq) (*api.ResolveReportRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeModerator(ctx, req.UserID); err != nil {
+		if err == constants.ErrForbidden || err == constants.ErrUserNotFound {
+			return nil, err
+		}
+		s.adapter.LogModule.Log(err, req, "[ResolveReport] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	report, err := s.adapter.ReportModule.FindReportByID(ctx, req.ReportID)
+	if err != nil {
+		if err == constants.ErrReportNotFound {
+			return nil, constants.ErrReportNotFound
+		}
+		s.adapter.LogModule.Log(err, req, "[ResolveReport] failed get report")
+		return nil, constants.ErrInternalServerError
+	}
+	if report.Status != constants.ReportStatusPending {
+		return nil, constants.ErrReportAlreadyResolved
+	}
+	reason := req.Reason
+	if reason == "" {
+		reason = report.Reason
+	}
+
+	// admin can't be suspended, checked before anything is changed
+	var reportedUser *entity.User
+	if req.Action == constants.ReportActionSuspendUser {
+		reportedUser, err = s.adapter.UserModule.FindUserByID(ctx, report.ReportedUserID)
+		if err != nil {
+			if err == constants.ErrUserNotFound {
+				return nil, constants.ErrUserNotFound
+			}
+			s.adapter.LogModule.Log(err, req, "[ResolveReport] failed get reported user")
+			return nil, constants.ErrInternalServerError
+		}
+		if reportedUser.Account.Role == constants.RoleAdmin {
+			return nil, constants.ErrForbidden
+		}
+	}
+
+	// the post is read for the audit log before it's changed, it may be already deleted by its author
+	posts, err := s.adapter.PostModule.FindPostListByIDsIncludeHidden(ctx, []string{report.PostID})
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[ResolveReport] failed get post")
+		return nil, constants.ErrInternalServerError
+	}
+	postAudit := entity.AuditLog{
+		ActorID:    req.UserID,
+		TargetType: constants.AuditTargetPost,
+		TargetID:   report.PostID,
+		Reason:     reason,
+	}
+	if len(posts) > 0 {
+		postAudit.Before = postSnapshot(posts[0])
+	}
+	switch req.Action {
+	case constants.ReportActionDismiss:
+		postAudit.Action = constants.AuditActionDismissReport
+		postAudit.After = changeSnapshot(postAudit.Before, "hidden", false)
+	case constants.ReportActionDeletePost:
+		postAudit.Action = constants.AuditActionDeletePost
+	default:
+		postAudit.Action = constants.AuditActionHidePost
+		postAudit.After = changeSnapshot(postAudit.Before, "hidden", true)
+	}
+	audits := []entity.AuditLog{postAudit}
+	var suspended entity.Account
+	switch req.Action {
+	case constants.ReportActionWarnUser:
+		audits = append(audits, entity.AuditLog{
+			ActorID:    req.UserID,
+			Action:     constants.AuditActionWarnUser,
+			TargetType: constants.AuditTargetUser,
+			TargetID:   report.ReportedUserID,
+			Reason:     reason,
+		})
+	case constants.ReportActionSuspendUser:
+		suspended = reportedUser.Account
+		suspended.SuspendedUntil = time.Now().Unix() + req.SuspendDuration
+		suspended.SuspensionReason = reason
+		audits = append(audits, entity.AuditLog{
+			ActorID:    req.UserID,
+			Action:     constants.AuditActionSuspendUser,
+			TargetType: constants.AuditTargetUser,
+			TargetID:   report.ReportedUserID,
+			Before:     accountSnapshot(reportedUser.Account),
+			After:      accountSnapshot(suspended),
+			Reason:     reason,
+		})
+	}
+	// the decision is about the post, so the other pending reports on it are resolved together.
+	// They're resolved first, so a moderator resolving it concurrently or a retry doesn't apply the action twice
+	resolved, err := s.adapter.ReportModule.ResolveReportsByPostID(ctx, report.PostID, req.Action, req.UserID)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[ResolveReport] failed resolve report")
+		return nil, constants.ErrInternalServerError
+	}
+	if !resolved {
+		return nil, constants.ErrReportAlreadyResolved
+	}
+
+	// the audit log is written before the change, so a change is never made without it
+	for i, audit := range audits {
+		if err := s.adapter.AuditModule.InsertAuditLog(ctx, audit); err != nil {
+			s.adapter.LogModule.Log(err, req, "[ResolveReport] failed insert audit log")
+			s.recordFailedAudit(ctx, audits[:i]...)
+			s.reopenReports(ctx, req, report.PostID)
+			return nil, constants.ErrInternalServerError
+		}
+	}
+
+	switch req.Action {
+	case constants.ReportActionDismiss:
+		// the post may be hidden by the reports
+		err = s.adapter.PostModule.UpdateHiddenStatus(ctx, report.PostID, false)
+	case constants.ReportActionDeletePost:
+		err = s.adapter.PostModule.DeletePostWithReplies(ctx, report.PostID)
+	default:
+		// warned or suspended user post stay hidden
+		err = s.adapter.PostModule.UpdateHiddenStatus(ctx, report.PostID, true)
+	}
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[ResolveReport] failed update post")
+		s.recordFailedAudit(ctx, audits...)
+		s.reopenReports(ctx, req, report.PostID)
+		return nil, constants.ErrInternalServerError
+	}
+
+	switch req.Action {
+	case constants.ReportActionWarnUser:
+		if err := s.adapter.NotificationModule.SendWarningNotification(ctx, report.ReportedUserID, reason); err != nil {
+			s.adapter.LogModule.Log(err, req, "[ResolveReport] failed send warning notification")
+		}
+	case constants.ReportActionSuspendUser:
+		if err := s.adapter.UserModule.SaveSuspension(ctx, entity.User{
+			ID: report.ReportedUserID,
+			Account: entity.Account{
+				SuspendedUntil:   suspended.SuspendedUntil,
+				SuspensionReason: suspended.SuspensionReason,
+			},
+		}); err != nil {
+			s.adapter.LogModule.Log(err, req, "[ResolveReport] failed suspend user")
+			// the post change is done, only the suspension failed
+			s.recordFailedAudit(ctx, audits[1:]...)
+			s.reopenReports(ctx, req, report.PostID)
+			return nil, constants.ErrInternalServerError
+		}
+	}
+
+	return &api.ResolveReportRes{Message: "success"}, nil
+}
+
+// reopenReports put back the reports of the resolve that failed, so the moderator can resolve them again
+func (s *service) reopenReports(ctx context.Context, req api.ResolveReportReq, postID string) {
+	if err := s.adapter.ReportModule.ReopenReportsByPostID(ctx, postID, req.Action, req.UserID); err != nil {
+		s.adapter.LogModule.Log(err, req, "[ResolveReport] failed reopen report")
+	}
+}
+
+func (s *service) GetAuditLog(ctx context.Context, req api.GetAuditLogReq) (*api.GetAuditLogRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeAdmin(ctx, req.UserID); err != nil {
+		if err == constants.ErrForbidden || err == constants.ErrUserNotFound {
+			return nil, err
+		}
+		s.adapter.LogModule.Log(err, req, "[GetAuditLog] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	logs, pagination, err := s.adapter.AuditModule.FindAuditLogList(ctx, req.Filter, req.Pagination)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[GetAuditLog] failed get audit log list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.GetAuditLogRes{
+		AuditLogList: logs,
+		Pagination:   *pagination,
+	}, nil
+}
+
+func (s *service) ExportAuditLog(ctx context.Context, req api.ExportAuditLogReq) (*api.ExportAuditLogRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeAdmin(ctx, req.UserID); err != nil {
+		if err == constants.ErrForbidden || err == constants.ErrUserNotFound {
+			return nil, err
+		}
+		s.adapter.LogModule.Log(err, req, "[ExportAuditLog] failed get user")
+		return nil, constants.ErrInternalServerError
+	}
+
+	var logs []entity.AuditLog
+	pagination := api.PaginationReq{First: constants.AuditLogExportBatchSize}
+	for {
+		list, page, err := s.adapter.AuditModule.FindAuditLogList(ctx, req.Filter, pagination)
+		if err != nil {
+			s.adapter.LogModule.Log(err, req, "[ExportAuditLog] failed get audit log list")
+			return nil, constants.ErrInternalServerError
+		}
+		logs = append(logs, list...)
+		if len(logs) > constants.AuditLogExportMaxEntries {
+			return nil, constants.ErrTooManyAuditLogs
+		}
+		if !page.HasNextPage {
+			break
+		}
+		pagination.After = page.EndCursor
+	}
+
+	return &api.ExportAuditLogRes{AuditLogList: logs}, nil
+}
+
+func (s *service) RefreshTrending(ctx context.Context, req api.RefreshTrendingReq) (*api.RefreshTrendingRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	// fetch once using the widest window, narrower window is a subset of it
+	maxWindow := 0
+	for _, v := range constants.TrendingWindows {
+		if v > maxWindow {
+			maxWindow = v
+		}
+	}
+	postList, err := s.adapter.PostModule.FindPostListCreatedAfter(ctx, req.Now-int64(maxWindow)*3600)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[RefreshTrending] failed get post list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	for _, window := range constants.TrendingWindows {
+		start := req.Now - int64(window)*3600
+		snapshot, err := s.adapter.TrendingModule.FindEngagementSnapshot(ctx, start)
+		if err != nil && err != constants.ErrEngagementSnapshotNotFound {
+			s.adapter.LogModule.Log(err, req, "[RefreshTrending] failed get engagement snapshot")
+			return nil, constants.ErrInternalServerError
+		}
+		scores := engagementGrowth(postList, snapshot, start, window)
+
+		var posts []entity.Post
+		for _, v := range postList {
+			if v.Timestamp >= start {
+				posts = append(posts, v)
+			}
+		}
+		if err := s.adapter.TrendingModule.SaveTrendingPostList(ctx, window, rankTrendingPost(postList, scores)); err != nil {
+			s.adapter.LogModule.Log(err, req, "[RefreshTrending] failed save trending post list")
+			return nil, constants.ErrInternalServerError
+		}
+		if err := s.adapter.TrendingModule.SaveTrendingTopicList(ctx, window, rankTrendingTopic(posts, scores)); err != nil {
+			s.adapter.LogModule.Log(err, req, "[RefreshTrending] failed save trending topic list")
+			return nil, constants.ErrInternalServerError
+		}
+	}
+
+	// the next runs measure the growth from this snapshot
+	engagement := make(map[string]int, len(postList))
+	for _, v := range postList {
+		engagement[v.ID] = v.LikesCount + v.RepliesCount
+	}
+	if err := s.adapter.TrendingModule.SaveEngagementSnapshot(ctx, entity.EngagementSnapshot{
+		TakenAt:    req.Now,
+		Engagement: engagement,
+	}); err != nil {
+		s.adapter.LogModule.Log(err, req, "[RefreshTrending] failed save engagement snapshot")
+		return nil, constants.ErrInternalServerError
+	}
+	// the widest window need the snapshot taken before its start, keep twice of it to be safe
+	if err := s.adapter.TrendingModule.DeleteEngagementSnapshotBefore(ctx, req.Now-2*int64(maxWindow)*3600); err != nil {
+		s.adapter.LogModule.Log(err, req, "[RefreshTrending] failed delete old engagement snapshot")
+	}
+
+	return &api.RefreshTrendingRes{Message: "success"}, nil
+}
+
+func newPoll(req api.PollReq, now int64) *entity.Poll {
+	poll := &entity.Poll{
+		MultipleChoice: req.MultipleChoice,
+	}
+	for i, v := range req.Options {
+		poll.Options = append(poll.Options, entity.PollOption{
+			ID:   strconv.Itoa(i + 1),
+			Text: v,
+		})
+	}
+	if req.ExpiresIn > 0 {
+		poll.ExpiredAt = now + req.ExpiresIn
+	}
+	return poll
+}
+
+// originalPost return the reposted post for a repost, so repost and quote always point to the original content
+func originalPost(post entity.Post) *entity.Post {
+	if post.IsRepost && post.QuotedPost != nil {
+		return &entity.Post{ID: post.QuotedPost.ID}
+	}
+	return &entity.Post{ID: post.ID}
+}
+
+func (s *service) PublishScheduledPost(ctx context.Context, req api.PublishScheduledPostReq) (*api.PublishScheduledPostRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	postList, err := s.adapter.PostModule.FindDueScheduledPostList(ctx, req.Now)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[PublishScheduledPost] failed get due scheduled post list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	for _, v := range postList {
+		published, err := s.adapter.PostModule.PublishPost(ctx, v.ID)
+		if err != nil {
+			// keep publishing the rest, the failed one retried on the next run
+			s.adapter.LogModule.Log(err, v, "[PublishScheduledPost] failed publish post")
+			continue
+		}
+		// already published by other worker, or cancelled by the user
+		if !published {
+			continue
+		}
+		// flagged post stay hidden until reviewed, nobody is notified
+		if v.IsHidden {
+			continue
+		}
+		if err := s.adapter.NotificationModule.BroadcastNewPostNotification(ctx, v); err != nil {
+			s.adapter.LogModule.Log(err, v, "[PublishScheduledPost] failed send notification")
+		}
+		s.sendMentionNotification(ctx, v, nil)
+	}
+
+	return &api.PublishScheduledPostRes{Message: "success"}, nil
+}
+
+func (s *service) DeleteExpiredPost(ctx context.Context, req api.DeleteExpiredPostReq) (*api.DeleteExpiredPostRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.adapter.PostModule.DeleteExpiredPost(ctx, req.Now); err != nil {
+		s.adapter.LogModule.Log(err, req, "[DeleteExpiredPost] failed delete expired post")
+		return nil, constants.ErrInternalServerError
+	}
+
+	return &api.DeleteExpiredPostRes{Message: "success"}, nil
+}
+
+func (s *service) FetchLinkPreviews(ctx context.Context, req api.FetchLinkPreviewsReq) (*api.FetchLinkPreviewsRes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	postList, err := s.adapter.PostModule.FindPendingLinkPreviewPostList(ctx, req.Now, req.Limit)
+	if err != nil {
+		s.adapter.LogModule.Log(err, req, "[FetchLinkPreviews] failed get pending post list")
+		return nil, constants.ErrInternalServerError
+	}
+
+	for _, v := range postList {
+		previews, err := s.fetchLinkPreviews(ctx, v.Links)
+		if err != nil {
+			s.adapter.LogModule.Log(err, v, "[FetchLinkPreviews] failed fetch link preview")
+			if v.LinkPreviewAttempts+1 < constants.LinkPreviewMaxAttempts {
+				// back off so the failing post doesn't hold the newer one in the batch
+				delay := int64(constants.LinkPreviewRetryDelay) << v.LinkPreviewAttempts
+				if err := s.adapter.PostModule.DelayLinkPreview(ctx, v.ID, req.Now+delay); err != nil {
+					s.adapter.LogModule.Log(err, v, "[FetchLinkPreviews] failed delay link preview")
+				}
+				continue
+			}
+			// give up, the post is shown without preview
+			previews = []entity.LinkPreview{}
+		}
+		if err := s.adapter.PostModule.SaveLinkPreviews(ctx, v.ID, previews); err != nil {
+			s.adapter.LogModule.Log(err, v, "[FetchLinkPreviews] failed save link previews")
+		}
+	}
+
+	return &api.FetchLinkPreviewsRes{Message: "success"}, nil
+}
+
+// fetchLinkPreviews skip link without preview, error is returned only if the preview can't be looked up at all
+func (s *service) fetchLinkPreviews(ctx context.Context, links []string) ([]entity.LinkPreview, error) {
+	previews := []entity.LinkPreview{}
+	for _, v := range links {
+		preview, err := s.adapter.LinkPreviewModule.FetchLinkPreview(ctx, v)
+		if err != nil {
+			if err == constants.ErrLinkPreviewUnavailable {
+				continue
+			}
+			return nil, err
+		}
+		previews = append(previews, *preview)
+	}
+	return previews, nil
+}
+
+// engagementGrowth is likes + replies gained per hour in the window by post id,
+// measured from the snapshot taken at the window start. post created in the window start from zero,
+// older post missing from the snapshot is skipped since its growth isn't known
+func engagementGrowth(posts []entity.Post, snapshot *entity.EngagementSnapshot, start int64, window int) map[string]float64 {
+	scores := make(map[string]float64)
+	for _, v := range posts {
+		var baseline int
+		if v.Timestamp < start {
+			if snapshot == nil {
+				continue
+			}
+			count, ok := snapshot.Engagement[v.ID]
+			if !ok {
+				continue
+			}
+			baseline = count
+		}
+		if growth := v.LikesCount + v.RepliesCount - baseline; growth > 0 {
+			scores[v.ID] = float64(growth) / float64(window)
+		}
+	}
+	return scores
+}
+
+func rankTrendingPost(posts []entity.Post, scores map[string]float64) []entity.TrendingPost {
+	var result []entity.TrendingPost
+	for _, v := range posts {
+		score := scores[v.ID]
+		if score == 0 {
+			continue
+		}
+		result = append(result, entity.TrendingPost{PostID: v.ID, Score: score})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+	if len(result) > constants.TrendingLimit {
+		result = result[:constants.TrendingLimit]
+	}
+	return result
+}
+
+func rankTrendingTopic(posts []entity.Post, scores map[string]float64) []entity.Topic {
+	mapTopic := make(map[string]*entity.Topic)
+	var names []string
+	for _, v := range posts {
+		for _, tag := range utils.ExtractHashtags(v.Body) {
+			topic, ok := mapTopic[tag]
+			if !ok {
+				topic = &entity.Topic{Name: tag}
+				mapTopic[tag] = topic
+				names = append(names, tag)
+			}
+			topic.PostsCount++
+			topic.Score += scores[v.ID]
+		}
+	}
+	result := make([]entity.Topic, len(names))
+	for i, v := range names {
+		result[i] = *mapTopic[v]
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].PostsCount != result[j].PostsCount {
+			return result[i].PostsCount > result[j].PostsCount
+		}
+		return result[i].Score > result[j].Score
+	})
+	if len(result) > constants.TrendingLimit {
+		result = result[:constants.TrendingLimit]
+	}
+	return result
 }
@@ -3,13 +3,16 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/aeramu/menfess-backend/constants"
 	"github.com/aeramu/menfess-backend/entity"
 	"github.com/aeramu/menfess-backend/mocks"
 	"github.com/aeramu/menfess-backend/service/api"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"strings"
 	"testing"
+	"time"
 )
 
 var (
@@ -19,6 +22,18 @@ var (
 	mockAuthModule *mocks.AuthModule
 	mockLogModule  *mocks.LogModule
 	mockNotificationModule *mocks.NotificationModule
+	mockTrendingModule *mocks.TrendingModule
+	mockBookmarkModule *mocks.BookmarkModule
+	mockStorageModule *mocks.StorageModule
+	mockAttachmentModule *mocks.AttachmentModule
+	mockMediaModule *mocks.MediaModule
+	mockAvatarModule *mocks.AvatarModule
+	mockLinkPreviewModule *mocks.LinkPreviewModule
+	mockReportModule *mocks.ReportModule
+	mockAuditModule *mocks.AuditModule
+	mockModerationModule *mocks.ModerationModule
+	mockRedactionModule *mocks.RedactionModule
+	mockRateLimitModule *mocks.RateLimitModule
 )
 
 func initTest()  {
@@ -27,15 +42,45 @@ func initTest()  {
 	mockAuthModule = new(mocks.AuthModule)
 	mockNotificationModule = new(mocks.NotificationModule)
 	mockLogModule = new(mocks.LogModule)
+	mockTrendingModule = new(mocks.TrendingModule)
+	mockBookmarkModule = new(mocks.BookmarkModule)
+	mockStorageModule = new(mocks.StorageModule)
+	mockAttachmentModule = new(mocks.AttachmentModule)
+	mockMediaModule = new(mocks.MediaModule)
+	mockAvatarModule = new(mocks.AvatarModule)
+	mockLinkPreviewModule = new(mocks.LinkPreviewModule)
+	mockReportModule = new(mocks.ReportModule)
+	mockAuditModule = new(mocks.AuditModule)
+	mockModerationModule = new(mocks.ModerationModule)
+	mockRedactionModule = new(mocks.RedactionModule)
+	mockRateLimitModule = new(mocks.RateLimitModule)
 	adapter = Adapter{
 		UserModule:         mockUserModule,
 		PostModule:         mockPostModule,
 		AuthModule:         mockAuthModule,
 		NotificationModule: mockNotificationModule,
 		LogModule:          mockLogModule,
+		TrendingModule:     mockTrendingModule,
+		BookmarkModule:     mockBookmarkModule,
+		StorageModule:      mockStorageModule,
+		AttachmentModule:   mockAttachmentModule,
+		MediaModule:        mockMediaModule,
+		AvatarModule:       mockAvatarModule,
+		LinkPreviewModule:  mockLinkPreviewModule,
+		ReportModule:       mockReportModule,
+		AuditModule:        mockAuditModule,
+		ModerationModule:   mockModerationModule,
+		RedactionModule:    mockRedactionModule,
+		RateLimitModule:    mockRateLimitModule,
 	}
 }
 
+// allowRateLimit let every rate limited action through, the case testing the limit mock Hit in its prepare
+func allowRateLimit() {
+	mockRateLimitModule.On("Hit", mock.Anything, mock.Anything, mock.Anything).
+		Return(1, time.Now().Unix()+60, nil)
+}
+
 func Test_service_Login(t *testing.T)  {
 	var (
 		ctx = context.Background()
@@ -119,6 +164,45 @@ func Test_service_Login(t *testing.T)  {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name:    "suspended user",
+			prepare: func() {
+				mockUserModule.On("FindUserByEmail", mock.Anything, req.Email).
+					Return(&entity.User{
+						ID:      user.ID,
+						Account: entity.Account{
+							Password:         user.Account.Password,
+							SuspendedUntil:   time.Now().Unix() + 3600,
+							SuspensionReason: "spam",
+						},
+					}, nil)
+				mockAuthModule.On("ComparePassword", mock.Anything, user.Account.Password, req.Password).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "banned device",
+			prepare: func() {
+				mockUserModule.On("FindUserByEmail", mock.Anything, req.Email).
+					Return(&user, nil)
+				mockAuthModule.On("ComparePassword", mock.Anything, user.Account.Password, req.Password).
+					Return(nil)
+				mockUserModule.On("FindBannedDevice", mock.Anything, req.PushToken).
+					Return(&entity.BannedDevice{PushToken: req.PushToken, Reason: "spam"}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "error when add push token",
 			prepare: func() {
@@ -126,6 +210,8 @@ func Test_service_Login(t *testing.T)  {
 					Return(&user, nil)
 				mockAuthModule.On("ComparePassword", mock.Anything, user.Account.Password, req.Password).
 					Return(nil)
+				mockUserModule.On("FindBannedDevice", mock.Anything, req.PushToken).
+					Return(nil, constants.ErrBannedDeviceNotFound)
 				mockNotificationModule.On("AddPushToken", mock.Anything, user.ID, req.PushToken).
 					Return(err)
 				mockLogModule.On("Log", err, req, mock.Anything)
@@ -144,6 +230,8 @@ func Test_service_Login(t *testing.T)  {
 					Return(&user, nil)
 				mockAuthModule.On("ComparePassword", mock.Anything, user.Account.Password, req.Password).
 					Return(nil)
+				mockUserModule.On("FindBannedDevice", mock.Anything, req.PushToken).
+					Return(nil, constants.ErrBannedDeviceNotFound)
 				mockNotificationModule.On("AddPushToken", mock.Anything, user.ID, req.PushToken).
 					Return(nil)
 				mockAuthModule.On("GenerateToken", mock.Anything, user).
@@ -164,6 +252,8 @@ func Test_service_Login(t *testing.T)  {
 					Return(&user, nil)
 				mockAuthModule.On("ComparePassword", mock.Anything, user.Account.Password, req.Password).
 					Return(nil)
+				mockUserModule.On("FindBannedDevice", mock.Anything, req.PushToken).
+					Return(nil, constants.ErrBannedDeviceNotFound)
 				mockNotificationModule.On("AddPushToken", mock.Anything, user.ID, req.PushToken).
 					Return(nil)
 				mockAuthModule.On("GenerateToken", mock.Anything, user).
@@ -208,6 +298,7 @@ func Test_service_Register(t *testing.T)  {
 		pushToken = "asdf1234"
 		req = api.RegisterReq{
 			PushToken: pushToken,
+			ClientIP:  "10.1.2.3",
 		}
 		token = "hadslfkjwq1434"
 	)
@@ -222,9 +313,87 @@ func Test_service_Register(t *testing.T)  {
 		want    *api.RegisterRes
 		wantErr bool
 	}{
+		{
+			name:    "banned device",
+			prepare: func() {
+				mockUserModule.On("FindBannedDevice", mock.Anything, req.PushToken).
+					Return(&entity.BannedDevice{PushToken: req.PushToken, Reason: "spam"}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "rate limited subnet",
+			prepare: func() {
+				mockUserModule.On("FindBannedDevice", mock.Anything, req.PushToken).
+					Return(nil, constants.ErrBannedDeviceNotFound)
+				mockRateLimitModule.On("Hit", mock.Anything, "register:ip:10.1.2.3/32", constants.RateLimitRegisterIP.Window).
+					Return(1, time.Now().Unix()+600, nil)
+				mockRateLimitModule.On("Hit", mock.Anything, "register:subnet:10.1.2.0/24", constants.RateLimitRegisterSubnet.Window).
+					Return(constants.RateLimitRegisterSubnet.Limit+1, time.Now().Unix()+600, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "rate limited device",
+			prepare: func() {
+				mockUserModule.On("FindBannedDevice", mock.Anything, req.PushToken).
+					Return(nil, constants.ErrBannedDeviceNotFound)
+				mockRateLimitModule.On("Hit", mock.Anything, "register:device:"+pushToken, constants.RateLimitRegisterDevice.Window).
+					Return(constants.RateLimitRegisterDevice.Limit+1, time.Now().Unix()+3600, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.RegisterReq{PushToken: pushToken},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "rate limited ip",
+			prepare: func() {
+				mockUserModule.On("FindBannedDevice", mock.Anything, req.PushToken).
+					Return(nil, constants.ErrBannedDeviceNotFound)
+				mockRateLimitModule.On("Hit", mock.Anything, "register:ip:10.1.2.3/32", constants.RateLimitRegisterIP.Window).
+					Return(constants.RateLimitRegisterIP.Limit+1, time.Now().Unix()+600, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when check rate limit",
+			prepare: func() {
+				mockUserModule.On("FindBannedDevice", mock.Anything, req.PushToken).
+					Return(nil, constants.ErrBannedDeviceNotFound)
+				mockRateLimitModule.On("Hit", mock.Anything, mock.Anything, mock.Anything).
+					Return(0, int64(0), err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "error when insert user",
 			prepare: func() {
+				mockUserModule.On("FindBannedDevice", mock.Anything, req.PushToken).
+					Return(nil, constants.ErrBannedDeviceNotFound)
 				mockUserModule.On("InsertUser", mock.Anything, mock.Anything).
 					Return("", err)
 				mockLogModule.On("Log", err, req, mock.Anything)
@@ -239,6 +408,8 @@ func Test_service_Register(t *testing.T)  {
 		{
 			name:    "error when add push token",
 			prepare: func() {
+				mockUserModule.On("FindBannedDevice", mock.Anything, req.PushToken).
+					Return(nil, constants.ErrBannedDeviceNotFound)
 				mockUserModule.On("InsertUser", mock.Anything, mock.Anything).
 					Return(user.ID, nil)
 				mockNotificationModule.On("AddPushToken", mock.Anything, user.ID, req.PushToken).
@@ -255,6 +426,8 @@ func Test_service_Register(t *testing.T)  {
 		{
 			name:    "error when generate token",
 			prepare: func() {
+				mockUserModule.On("FindBannedDevice", mock.Anything, req.PushToken).
+					Return(nil, constants.ErrBannedDeviceNotFound)
 				mockUserModule.On("InsertUser", mock.Anything, entity.User{}).Return(user.ID, nil)
 				mockNotificationModule.On("AddPushToken", mock.Anything, user.ID, req.PushToken).
 					Return(nil)
@@ -272,6 +445,8 @@ func Test_service_Register(t *testing.T)  {
 		{
 			name:    "success",
 			prepare: func() {
+				mockUserModule.On("FindBannedDevice", mock.Anything, req.PushToken).
+					Return(nil, constants.ErrBannedDeviceNotFound)
 				mockUserModule.On("InsertUser", mock.Anything, entity.User{}).Return(user.ID, nil)
 				mockNotificationModule.On("AddPushToken", mock.Anything, user.ID, req.PushToken).
 					Return(nil)
@@ -294,6 +469,7 @@ func Test_service_Register(t *testing.T)  {
 			if tt.prepare != nil {
 				tt.prepare()
 			}
+			allowRateLimit()
 			s := &service{
 				adapter: adapter,
 			}
@@ -360,6 +536,8 @@ func Test_service_UpdateProfile(t *testing.T)  {
 			prepare: func() {
 				mockUserModule.On("FindUserByID", mock.Anything, req.ID).
 					Return(&entity.User{ID: req.ID}, nil)
+				mockAvatarModule.On("FindAvatarByURL", mock.Anything, req.Avatar).
+					Return(&entity.Avatar{URL: req.Avatar}, nil)
 				mockUserModule.On("SaveProfile", mock.Anything, mock.Anything).
 					Return(err)
 				mockLogModule.On("Log", err, req, mock.Anything)
@@ -371,11 +549,80 @@ func Test_service_UpdateProfile(t *testing.T)  {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name:    "avatar not in catalog and not uploaded by user",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.ID).
+					Return(&entity.User{ID: req.ID}, nil)
+				mockAvatarModule.On("FindAvatarByURL", mock.Anything, req.Avatar).
+					Return(nil, constants.ErrAvatarNotFound)
+				mockAttachmentModule.On("FindAttachmentByURL", mock.Anything, req.Avatar).
+					Return(&entity.Attachment{OwnerID: "other-id"}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when get avatar",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.ID).
+					Return(&entity.User{ID: req.ID}, nil)
+				mockAvatarModule.On("FindAvatarByURL", mock.Anything, req.Avatar).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success with uploaded avatar",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.ID).
+					Return(&entity.User{ID: req.ID}, nil)
+				mockAvatarModule.On("FindAvatarByURL", mock.Anything, req.Avatar).
+					Return(nil, constants.ErrAvatarNotFound)
+				mockAttachmentModule.On("FindAttachmentByURL", mock.Anything, req.Avatar).
+					Return(&entity.Attachment{OwnerID: req.ID}, nil)
+				mockUserModule.On("SaveProfile", mock.Anything, mock.Anything).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.UpdateProfileRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success keeping current avatar",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.ID).
+					Return(&entity.User{ID: req.ID, Profile: entity.Profile{Avatar: req.Avatar}}, nil)
+				mockUserModule.On("SaveProfile", mock.Anything, mock.Anything).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.UpdateProfileRes{Message: "success"},
+			wantErr: false,
+		},
 		{
 			name:    "success",
 			prepare: func() {
 				mockUserModule.On("FindUserByID", mock.Anything, req.ID).
 					Return(&entity.User{ID: req.ID}, nil)
+				mockAvatarModule.On("FindAvatarByURL", mock.Anything, req.Avatar).
+					Return(&entity.Avatar{URL: req.Avatar}, nil)
 				mockUserModule.On("SaveProfile", mock.Anything, mock.MatchedBy(func(u entity.User) bool{
 					assert.Equal(t, req.ID, u.ID)
 					assert.Equal(t, req.Name, u.Profile.Name)
@@ -715,13 +962,30 @@ func Test_service_GetPostList(t *testing.T)  {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name:    "error when get hidden user",
+			prepare: func() {
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "error when get post list",
 			prepare: func() {
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, req.UserID).
+					Return([]string{"blocked-id"}, nil)
 				mockPostModule.On("FindPostListByParentIDAndAuthorIDs",
 					mock.Anything,
 					req.ParentID,
 					mock.Anything,
+					[]string{"blocked-id"},
 					req.UserID,
 					req.Pagination,
 				).Return(nil, nil, err)
@@ -737,10 +1001,13 @@ func Test_service_GetPostList(t *testing.T)  {
 		{
 			name:    "success",
 			prepare: func() {
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, req.UserID).
+					Return([]string{"blocked-id"}, nil)
 				mockPostModule.On("FindPostListByParentIDAndAuthorIDs",
 					mock.Anything,
 					req.ParentID,
 					mock.Anything,
+					[]string{"blocked-id"},
 					req.UserID,
 					req.Pagination,
 				).Return([]entity.Post{}, &paginationRes, nil)
@@ -781,6 +1048,10 @@ func Test_service_CreatePost(t *testing.T)  {
 	var (
 		ctx = context.Background()
 		err = errors.New("some error")
+		publishAt = time.Now().Unix() + 3600
+		noRedaction = func(ctx context.Context, text string) *entity.Redaction {
+			return &entity.Redaction{Text: text}
+		}
 		req = api.CreatePostReq{
 			Body:     "body",
 			UserID:   "user-id",
@@ -809,6 +1080,93 @@ func Test_service_CreatePost(t *testing.T)  {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name:    "rate limited",
+			prepare: func() {
+				mockRateLimitModule.On("Hit", mock.Anything, "create_post:user:"+req.UserID, mock.Anything).
+					Return(constants.RateLimitCreatePostUser.Limit+1, time.Now().Unix()+120, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when check rate limit",
+			prepare: func() {
+				mockRateLimitModule.On("Hit", mock.Anything, mock.Anything, mock.Anything).
+					Return(0, int64(0), err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "near-duplicate of recent post",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.ParentID, req.UserID).
+					Return(&entity.Post{}, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, mock.Anything, req.UserID).
+					Return(false, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockUserModule.On("FindMutedWordList", mock.Anything, mock.Anything).
+					Return([]entity.MutedWord{}, nil)
+				mockPostModule.On("FindRecentPostListByUserID", mock.Anything, req.UserID, mock.Anything, constants.PostDuplicateLookback).
+					Return([]entity.Post{
+						{ID: "post-1", Body: "Ada yang tau jadwal UTS kalkulus minggu depan?"},
+						{ID: "post-2", Body: "PROMO murah!! cek link di bio sekarang juga, dijamin untung"},
+					}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					Body:     "promo murah cek link di bio sekarang juga ya, dijamin untung",
+					UserID:   req.UserID,
+					AuthorID: req.AuthorID,
+					ParentID: req.ParentID,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when get recent post",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.ParentID, req.UserID).
+					Return(&entity.Post{}, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, mock.Anything, req.UserID).
+					Return(false, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockUserModule.On("FindMutedWordList", mock.Anything, mock.Anything).
+					Return([]entity.MutedWord{}, nil)
+				mockPostModule.On("FindRecentPostListByUserID", mock.Anything, req.UserID, mock.Anything, mock.Anything).
+					Return(nil, err)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					Body:     "promo murah cek link di bio sekarang juga ya, dijamin untung",
+					UserID:   req.UserID,
+					AuthorID: req.AuthorID,
+					ParentID: req.ParentID,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "error when get user from db",
 			prepare: func() {
@@ -841,6 +1199,16 @@ func Test_service_CreatePost(t *testing.T)  {
 			prepare: func() {
 				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
 					Return(&entity.User{}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.ParentID, req.UserID).
+					Return(&entity.Post{}, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, mock.Anything, req.UserID).
+					Return(false, nil)
+				mockUserModule.On("FindMutedWordList", mock.Anything, mock.Anything).
+					Return([]entity.MutedWord{}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{Decision: constants.ModerationDecisionAllow}, nil)
 				mockPostModule.On("InsertPost", mock.Anything, mock.Anything).
 					Return("", err)
 				mockLogModule.On("Log", err, req, mock.Anything)
@@ -857,9 +1225,7 @@ func Test_service_CreatePost(t *testing.T)  {
 			prepare: func() {
 				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
 					Return(&entity.User{}, nil)
-				mockPostModule.On("InsertPost", mock.Anything, mock.Anything).
-					Return("post-id", nil)
-				mockPostModule.On("FindPostByID", mock.Anything, req.ParentID, "").
+				mockPostModule.On("FindPostByID", mock.Anything, req.ParentID, req.UserID).
 					Return(nil, err)
 				mockLogModule.On("Log", err, req, mock.Anything)
 			},
@@ -871,65 +1237,5834 @@ func Test_service_CreatePost(t *testing.T)  {
 			wantErr: true,
 		},
 		{
-			name:    "error when send reply notification",
+			name:    "error when parent post not found",
 			prepare: func() {
 				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
-					Return(&entity.User{ID: "user-id"}, nil)
-				mockPostModule.On("InsertPost", mock.Anything, mock.Anything).
-					Return("post-id", nil)
-				mockPostModule.On("FindPostByID", mock.Anything, req.ParentID, "").
-					Return(&entity.Post{}, nil)
-				mockNotificationModule.On("SendCommentNotification", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
-					assert.Equal(t, req.Body, p.Body)
-					assert.Equal(t, req.AuthorID, p.Author.ID)
-					assert.Equal(t, req.ParentID, p.Parent.ID)
-					assert.Equal(t, req.UserID, p.User.ID)
-					return true
-				}), entity.Post{}).
-					Return(err)
-				mockLogModule.On("Log", err, req, mock.Anything)
+					Return(&entity.User{}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.ParentID, req.UserID).
+					Return(nil, constants.ErrPostNotFound)
 			},
 			args:    args{
 				ctx: ctx,
 				req: req,
 			},
-			want:   &api.CreatePostRes{Message: "success"},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
-			name:    "error when broadcast notification create post",
+			name:    "reply inherit earlier expiry from parent",
 			prepare: func() {
 				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
 					Return(&entity.User{ID: req.UserID}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.ParentID, req.UserID).
+					Return(&entity.Post{ExpiredAt: publishAt}, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, mock.Anything, req.UserID).
+					Return(false, nil)
+				mockUserModule.On("FindMutedWordList", mock.Anything, mock.Anything).
+					Return([]entity.MutedWord{}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{Decision: constants.ModerationDecisionAllow}, nil)
 				mockPostModule.On("InsertPost", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
-					assert.Equal(t, req.Body, p.Body)
-					assert.Equal(t, req.AuthorID, p.Author.ID)
-					assert.Equal(t, req.UserID, p.User.ID)
-					return true
+					return p.ExpiredAt == publishAt
 				})).Return("post-id", nil)
-				mockNotificationModule.On("BroadcastNewPostNotification", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
-					assert.Equal(t, req.Body, p.Body)
-					assert.Equal(t, req.AuthorID, p.Author.ID)
-					assert.Equal(t, req.UserID, p.User.ID)
-					return true
-				})).
-					Return(err)
-				modifiedReq := req
-				modifiedReq.ParentID = ""
-				mockLogModule.On("Log", err, modifiedReq, mock.Anything)
+				mockNotificationModule.On("SendCommentNotification", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.CreatePostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "error when get mentioned user",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockUserModule.On("FindUserListByHandles", mock.Anything, []string{"someone"}).
+					Return(nil, err)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
 			},
 			args:    args{
 				ctx: ctx,
 				req: api.CreatePostReq{
-					Body:     req.Body,
+					Body:     "hi @someone",
 					UserID:   req.UserID,
 					AuthorID: req.AuthorID,
-					ParentID: "",
 				},
 			},
-			want:    &api.CreatePostRes{Message: "success"},
-			wantErr: false,
-		},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success reply with mentions",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.ParentID, req.UserID).
+					Return(&entity.Post{ID: req.ParentID, User: entity.User{ID: "parent-user-id"}}, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, mock.Anything, req.UserID).
+					Return(false, nil)
+				mockUserModule.On("FindMutedWordList", mock.Anything, mock.Anything).
+					Return([]entity.MutedWord{}, nil)
+				mockUserModule.On("FindUserListByHandles", mock.Anything, []string{"myself", "poster", "friend", "nobody", "hater"}).
+					Return([]entity.User{{ID: req.UserID}, {ID: "parent-user-id"}, {ID: "friend-id"}, {ID: "hater-id"}}, nil)
+				mockUserModule.On("FindBlockerIDsIn", mock.Anything, req.UserID, []string{"parent-user-id", "friend-id", "hater-id"}).
+					Return([]string{"hater-id"}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockPostModule.On("FindRecentPostListByUserID", mock.Anything, req.UserID, mock.Anything, mock.Anything).
+					Return([]entity.Post{}, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{Decision: constants.ModerationDecisionAllow}, nil)
+				mockPostModule.On("InsertPost", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					return assert.Equal(t, []string{"parent-user-id", "friend-id"}, p.MentionIDs)
+				})).Return("post-id", nil)
+				mockNotificationModule.On("SendMentionNotification", mock.Anything, mock.Anything, []string{"friend-id"}).
+					Return(nil).Once()
+				mockNotificationModule.On("SendCommentNotification", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					Body:     "@myself @Poster @friend @nobody @hater email@friend.com",
+					UserID:   req.UserID,
+					AuthorID: req.AuthorID,
+					ParentID: req.ParentID,
+				},
+			},
+			want:    &api.CreatePostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "error when reply to user who blocked the poster",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.ParentID, req.UserID).
+					Return(&entity.Post{ID: req.ParentID, Author: entity.User{ID: "parent-user-id"}}, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, "parent-user-id", req.UserID).
+					Return(true, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success reply without notification to user who blocked the poster",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.ParentID, req.UserID).
+					Return(&entity.Post{
+						ID:     req.ParentID,
+						Author: entity.User{ID: "menfess-id"},
+						User:   entity.User{ID: "parent-user-id"},
+					}, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, "menfess-id", req.UserID).
+					Return(false, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, "parent-user-id", req.UserID).
+					Return(true, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{Decision: constants.ModerationDecisionAllow}, nil)
+				mockPostModule.On("InsertPost", mock.Anything, mock.Anything).
+					Return("post-id", nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.CreatePostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "error when send reply notification",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: "user-id"}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.ParentID, req.UserID).
+					Return(&entity.Post{}, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, mock.Anything, req.UserID).
+					Return(false, nil)
+				mockUserModule.On("FindMutedWordList", mock.Anything, mock.Anything).
+					Return([]entity.MutedWord{}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{Decision: constants.ModerationDecisionAllow}, nil)
+				mockPostModule.On("InsertPost", mock.Anything, mock.Anything).
+					Return("post-id", nil)
+				mockNotificationModule.On("SendCommentNotification", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					assert.Equal(t, req.Body, p.Body)
+					assert.Equal(t, req.AuthorID, p.Author.ID)
+					assert.Equal(t, req.ParentID, p.Parent.ID)
+					assert.Equal(t, req.UserID, p.User.ID)
+					return true
+				}), entity.Post{}).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:   &api.CreatePostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success create post by shadowbanned user",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID, Account: entity.Account{ShadowbannedAt: 1}}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{Decision: constants.ModerationDecisionAllow}, nil)
+				mockPostModule.On("InsertPost", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					return p.IsShadowbanned
+				})).Return("post-id", nil).Once()
+				mockNotificationModule.On("BroadcastNewPostNotification", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					return p.IsShadowbanned
+				})).Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					Body:     req.Body,
+					UserID:   req.UserID,
+					AuthorID: req.AuthorID,
+				},
+			},
+			want:    &api.CreatePostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "error when review post",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(nil, err)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{Body: req.Body, UserID: req.UserID, AuthorID: req.AuthorID},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "personal data masked before review and save",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, "wa 081234567890").
+					Return(&entity.Redaction{
+						Text:   "wa [redacted]",
+						Masked: []entity.PersonalData{{Type: constants.PersonalDataPhoneNumber, Text: "081234567890"}},
+					}, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					return p.Body == "wa [redacted]"
+				})).Return(&entity.ModerationResult{Decision: constants.ModerationDecisionAllow}, nil)
+				mockPostModule.On("InsertPost", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					return p.Body == "wa [redacted]"
+				})).Return("post-id", nil).Once()
+				mockNotificationModule.On("BroadcastNewPostNotification", mock.Anything, mock.Anything).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{Body: "wa 081234567890", UserID: req.UserID, AuthorID: req.AuthorID},
+			},
+			want:    &api.CreatePostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "unconfirmed personal data",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, "buat Budi Santoso").
+					Return(&entity.Redaction{
+						Text:        "buat Budi Santoso",
+						Unconfirmed: []entity.PersonalData{{Type: constants.PersonalDataFullName, Text: "Budi Santoso"}},
+					}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{Body: "buat Budi Santoso", UserID: req.UserID, AuthorID: req.AuthorID},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "confirmed personal data",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, "buat Budi Santoso").
+					Return(&entity.Redaction{
+						Text:        "buat Budi Santoso",
+						Unconfirmed: []entity.PersonalData{{Type: constants.PersonalDataFullName, Text: "Budi Santoso"}},
+					}, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{Decision: constants.ModerationDecisionAllow}, nil)
+				mockPostModule.On("InsertPost", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					return p.Body == "buat Budi Santoso"
+				})).Return("post-id", nil).Once()
+				mockNotificationModule.On("BroadcastNewPostNotification", mock.Anything, mock.Anything).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					Body:                "buat Budi Santoso",
+					UserID:              req.UserID,
+					AuthorID:            req.AuthorID,
+					ConfirmPersonalData: true,
+				},
+			},
+			want:    &api.CreatePostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "rejected by content filter",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					return p.Body == req.Body
+				})).Return(&entity.ModerationResult{
+					Decision: constants.ModerationDecisionReject,
+					Reasons:  []string{constants.ModerationReasonNIK},
+				}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{Body: req.Body, UserID: req.UserID, AuthorID: req.AuthorID},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "flagged by content filter saved hidden and queued",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{
+						Decision: constants.ModerationDecisionFlag,
+						Reasons:  []string{constants.ModerationReasonPhoneNumber, constants.ModerationReasonStudentID},
+					}, nil)
+				mockPostModule.On("InsertPost", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					return p.IsHidden
+				})).Return("post-id", nil).Once()
+				mockReportModule.On("InsertReport", mock.Anything, entity.Report{
+					PostID:         "post-id",
+					ReportedUserID: req.UserID,
+					Reason:         constants.ReportReasonAutomated,
+					Details:        "phone_number, student_id",
+				}).Return("report-id", nil).Once()
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{Body: req.Body, UserID: req.UserID, AuthorID: req.AuthorID},
+			},
+			want:    &api.CreatePostRes{Message: "pending review"},
+			wantErr: false,
+		},
+		{
+			name:    "flagged post deleted when it can't be queued",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{
+						Decision: constants.ModerationDecisionFlag,
+						Reasons:  []string{constants.ModerationReasonPhoneNumber},
+					}, nil)
+				mockPostModule.On("InsertPost", mock.Anything, mock.Anything).
+					Return("post-id", nil).Once()
+				mockReportModule.On("InsertReport", mock.Anything, mock.Anything).
+					Return("", errors.New("some error")).Once()
+				mockLogModule.On("Log", mock.Anything, mock.Anything, mock.Anything)
+				mockPostModule.On("DeletePost", mock.Anything, "post-id").
+					Return(nil).Once()
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{Body: req.Body, UserID: req.UserID, AuthorID: req.AuthorID},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when broadcast notification create post",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{Decision: constants.ModerationDecisionAllow}, nil)
+				mockPostModule.On("InsertPost", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					assert.Equal(t, req.Body, p.Body)
+					assert.Equal(t, req.AuthorID, p.Author.ID)
+					assert.Equal(t, req.UserID, p.User.ID)
+					return true
+				})).Return("post-id", nil)
+				mockNotificationModule.On("BroadcastNewPostNotification", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					assert.Equal(t, req.Body, p.Body)
+					assert.Equal(t, req.AuthorID, p.Author.ID)
+					assert.Equal(t, req.UserID, p.User.ID)
+					return true
+				})).
+					Return(err)
+				modifiedReq := req
+				modifiedReq.ParentID = ""
+				mockLogModule.On("Log", err, modifiedReq, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					Body:     req.Body,
+					UserID:   req.UserID,
+					AuthorID: req.AuthorID,
+					ParentID: "",
+				},
+			},
+			want:    &api.CreatePostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "quoted post not found",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, "quoted-id", req.UserID).
+					Return(nil, constants.ErrPostNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					Body:         req.Body,
+					UserID:       req.UserID,
+					AuthorID:     req.AuthorID,
+					QuotedPostID: "quoted-id",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when get quoted post",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, "quoted-id", req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					Body:         req.Body,
+					UserID:       req.UserID,
+					AuthorID:     req.AuthorID,
+					QuotedPostID: "quoted-id",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success create post with poll",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{Decision: constants.ModerationDecisionAllow}, nil)
+				mockPostModule.On("InsertPost", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					return assert.Equal(t, []entity.PollOption{
+						{ID: "1", Text: "yes"},
+						{ID: "2", Text: "no"},
+					}, p.Poll.Options) && assert.NotZero(t, p.Poll.ExpiredAt)
+				})).Return("post-id", nil)
+				mockNotificationModule.On("BroadcastNewPostNotification", mock.Anything, mock.Anything).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					Body:     req.Body,
+					UserID:   req.UserID,
+					AuthorID: req.AuthorID,
+					Poll:     &api.PollReq{
+						Options:   []string{"yes", "no"},
+						ExpiresIn: 3600,
+					},
+				},
+			},
+			want:    &api.CreatePostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success scheduled post without notification",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{Decision: constants.ModerationDecisionAllow}, nil)
+				mockPostModule.On("InsertPost", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					return p.IsScheduled && p.Timestamp == publishAt
+				})).Return("post-id", nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					Body:      req.Body,
+					UserID:    req.UserID,
+					AuthorID:  req.AuthorID,
+					PublishAt: publishAt,
+				},
+			},
+			want:    &api.CreatePostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "attachment owned by other user",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockAttachmentModule.On("FindAttachmentListByIDs", mock.Anything, []string{"attachment-id"}).
+					Return([]entity.Attachment{{ID: "attachment-id", OwnerID: "other-user-id"}}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					Body:          req.Body,
+					UserID:        req.UserID,
+					AuthorID:      req.AuthorID,
+					AttachmentIDs: []string{"attachment-id"},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when get attachment",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockAttachmentModule.On("FindAttachmentListByIDs", mock.Anything, []string{"attachment-id"}).
+					Return(nil, err)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					Body:          req.Body,
+					UserID:        req.UserID,
+					AuthorID:      req.AuthorID,
+					AttachmentIDs: []string{"attachment-id"},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success create post with attachment",
+			prepare: func() {
+				attachments := []entity.Attachment{{ID: "attachment-id", OwnerID: req.UserID}}
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockAttachmentModule.On("FindAttachmentListByIDs", mock.Anything, []string{"attachment-id"}).
+					Return(attachments, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{Decision: constants.ModerationDecisionAllow}, nil)
+				mockPostModule.On("InsertPost", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					return assert.Equal(t, attachments, p.Attachments)
+				})).Return("post-id", nil)
+				mockNotificationModule.On("BroadcastNewPostNotification", mock.Anything, mock.Anything).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					UserID:        req.UserID,
+					AuthorID:      req.AuthorID,
+					AttachmentIDs: []string{"attachment-id"},
+				},
+			},
+			want:    &api.CreatePostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success create post with links",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockPostModule.On("FindRecentPostListByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return([]entity.Post{}, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{Decision: constants.ModerationDecisionAllow}, nil)
+				mockPostModule.On("InsertPost", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					return assert.Equal(t, []string{"https://a.com", "https://b.com", "https://c.com"}, p.Links)
+				})).Return("post-id", nil)
+				mockNotificationModule.On("BroadcastNewPostNotification", mock.Anything, mock.Anything).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					Body:     "https://a.com, https://b.com https://a.com (https://c.com) https://d.com",
+					UserID:   req.UserID,
+					AuthorID: req.AuthorID,
+				},
+			},
+			want:    &api.CreatePostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success scheduled post with expiry",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{Decision: constants.ModerationDecisionAllow}, nil)
+				mockPostModule.On("InsertPost", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					return p.ExpiredAt == publishAt+3600
+				})).Return("post-id", nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					Body:      req.Body,
+					UserID:    req.UserID,
+					AuthorID:  req.AuthorID,
+					PublishAt: publishAt,
+					ExpiresIn: 3600,
+				},
+			},
+			want:    &api.CreatePostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success quote a repost",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, "quoted-id", req.UserID).
+					Return(&entity.Post{
+						ID:         "quoted-id",
+						IsRepost:   true,
+						QuotedPost: &entity.Post{ID: "original-id"},
+					}, nil)
+				mockRedactionModule.On("Redact", mock.Anything, mock.Anything).
+					Return(noRedaction, nil)
+				mockModerationModule.On("ReviewPost", mock.Anything, mock.Anything).
+					Return(&entity.ModerationResult{Decision: constants.ModerationDecisionAllow}, nil)
+				mockPostModule.On("InsertPost", mock.Anything, mock.MatchedBy(func(p entity.Post) bool {
+					return p.QuotedPost != nil && p.QuotedPost.ID == "original-id"
+				})).Return("post-id", nil)
+				mockNotificationModule.On("BroadcastNewPostNotification", mock.Anything, mock.Anything).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.CreatePostReq{
+					Body:         req.Body,
+					UserID:       req.UserID,
+					AuthorID:     req.AuthorID,
+					QuotedPostID: "quoted-id",
+				},
+			},
+			want:    &api.CreatePostRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			allowRateLimit()
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.CreatePost(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			mockPostModule.AssertExpectations(t)
+			mockReportModule.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_service_LikePost(t *testing.T)  {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.LikePostReq{
+			PostID: "post-id",
+			UserID: "user-id",
+		}
+		post = entity.Post{
+			ID:      "post-id",
+			IsLiked: false,
+			User:    entity.User{ID: "user-post-id"},
+		}
+		user = entity.User{
+			ID:      "id",
+			Profile: entity.Profile{
+				Name: "John",
+			},
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.LikePostReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.LikePostRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.LikePostReq{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "rate limited",
+			prepare: func() {
+				mockRateLimitModule.On("Hit", mock.Anything, "like_post:user:"+req.UserID, constants.RateLimitLikePostUser.Window).
+					Return(constants.RateLimitLikePostUser.Limit+1, time.Now().Unix()+10, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when get user",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error user not found",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(nil, constants.ErrUserNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when get post",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error post not found",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(nil, constants.ErrPostNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when like post",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&post, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, mock.Anything, req.UserID).
+					Return(false, nil)
+				mockPostModule.On("LikePost", mock.Anything, req.PostID, req.UserID, false).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when author blocked the user",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{Author: entity.User{ID: "author-id"}}, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, "author-id", req.UserID).
+					Return(true, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when unlike post",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{}, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{IsLiked: true}, nil)
+				mockPostModule.On("UnlikePost", mock.Anything, req.PostID, req.UserID).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when send notification",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&user, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&post, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, mock.Anything, req.UserID).
+					Return(false, nil)
+				mockPostModule.On("LikePost", mock.Anything, req.PostID, req.UserID, false).
+					Return(nil)
+				mockNotificationModule.On("SendLikeNotification", mock.Anything,
+					user,
+					post,
+				).Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.LikePostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success like post",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&user, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&post, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, mock.Anything, req.UserID).
+					Return(false, nil)
+				mockPostModule.On("LikePost", mock.Anything, req.PostID, req.UserID, false).
+					Return(nil)
+				mockNotificationModule.On("SendLikeNotification", mock.Anything,
+					user,
+					post,
+				).Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.LikePostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success like post by shadowbanned user",
+			prepare: func() {
+				shadowbanned := entity.User{ID: req.UserID, Account: entity.Account{ShadowbannedAt: 1}}
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&shadowbanned, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&post, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, mock.Anything, req.UserID).
+					Return(false, nil)
+				mockPostModule.On("LikePost", mock.Anything, req.PostID, req.UserID, true).
+					Return(nil).Once()
+				mockNotificationModule.On("SendLikeNotification", mock.Anything,
+					shadowbanned,
+					post,
+				).Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.LikePostRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			allowRateLimit()
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.LikePost(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_Logout(t *testing.T)  {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.LogoutReq{
+			UserID:    "user-id",
+			PushToken: "token",
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.LogoutReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.LogoutRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid argument",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req : api.LogoutReq{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when remove push token",
+			prepare: func() {
+				mockNotificationModule.On("RemovePushToken", mock.Anything, req.UserID, req.PushToken).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockNotificationModule.On("RemovePushToken", mock.Anything, req.UserID, req.PushToken).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.LogoutRes{Message: "Success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.Logout(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_FollowUser(t *testing.T)  {
+	var (
+		ctx = context.Background()
+		userID = "user-id"
+		followedID = "followed-id"
+	)
+	type args struct {
+		ctx context.Context
+		req api.FollowUserReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.FollowUserRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.FollowUserReq{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "follow self",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.FollowUserReq{
+					UserID:     userID,
+					FollowedID: userID,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error get follow status",
+			prepare: func() {
+				mockUserModule.On("IsFollowing", mock.Anything, userID, followedID).
+					Return(false, errors.New("err"))
+				mockLogModule.On("Log", mock.Anything, mock.Anything, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.FollowUserReq{
+					UserID:     userID,
+					FollowedID: followedID,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error update follow status",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, followedID).
+					Return(&entity.User{ID: followedID}, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, followedID, userID).
+					Return(false, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, userID).
+					Return(&entity.User{ID: userID}, nil)
+				mockUserModule.On("IsFollowing", mock.Anything, userID, followedID).
+					Return(false, nil)
+				mockUserModule.On("UpdateFollowStatus", mock.Anything, userID, followedID, constants.FollowStatusActive).
+					Return(false, errors.New("err"))
+				mockLogModule.On("Log", mock.Anything, mock.Anything, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.FollowUserReq{
+					UserID:     userID,
+					FollowedID: followedID,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success update follow status: active",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, followedID).
+					Return(&entity.User{ID: followedID}, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, followedID, userID).
+					Return(false, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, userID).
+					Return(&entity.User{ID: userID}, nil)
+				mockUserModule.On("IsFollowing", mock.Anything, userID, followedID).
+					Return(false, nil)
+				mockUserModule.On("UpdateFollowStatus", mock.Anything, userID, followedID, constants.FollowStatusActive).
+					Return(true, nil)
+				mockNotificationModule.On("SendFollowNotification", mock.Anything, entity.User{ID: userID}, followedID).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.FollowUserReq{
+					UserID:     userID,
+					FollowedID: followedID,
+				},
+			},
+			want:    &api.FollowUserRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success update follow status: inactive",
+			prepare: func() {
+				mockUserModule.On("IsFollowing", mock.Anything, userID, followedID).
+					Return(true, nil)
+				mockUserModule.On("UpdateFollowStatus", mock.Anything, userID, followedID, constants.FollowStatusInactive).
+					Return(true, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.FollowUserReq{
+					UserID:     userID,
+					FollowedID: followedID,
+				},
+			},
+			want:    &api.FollowUserRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			allowRateLimit()
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.FollowUser(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_Feed(t *testing.T)  {
+	var (
+		ctx = context.Background()
+		reqAll = api.FeedReq{
+			UserID:     "id",
+			Type:       "all",
+			Pagination: api.PaginationReq{},
+		}
+		reqFollow = api.FeedReq{
+			UserID:     "id",
+			Type:       "follow",
+			Pagination: api.PaginationReq{},
+		}
+		sensitivePosts = []entity.Post{
+			{ID: "post-1", User: entity.User{ID: "other-id"}},
+			{ID: "post-2", ContentWarnings: []string{constants.ContentWarningNSFW}, User: entity.User{ID: "other-id"}},
+			{ID: "post-3", ContentWarnings: []string{constants.ContentWarningSpoiler}, User: entity.User{ID: "id"}},
+			{ID: "post-4", QuotedPost: &entity.Post{ID: "post-2", ContentWarnings: []string{constants.ContentWarningNSFW}, User: entity.User{ID: "other-id"}}},
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.FeedReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.FeedRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "type follow, error get followed ids",
+			prepare: func() {
+				mockUserModule.On("GetFollowedUserID", mock.Anything, reqFollow.UserID).
+					Return(nil, errors.New("err"))
+				mockLogModule.On("Log", mock.Anything, reqFollow, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: reqFollow,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "type all, error get post list",
+			prepare: func() {
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, reqAll.UserID).
+					Return([]string{}, nil)
+				mockPostModule.On("FindPostListByParentIDAndAuthorIDs", mock.Anything, "", mock.Anything, []string{}, reqAll.UserID, reqAll.Pagination).
+					Return(nil, nil, errors.New("err"))
+				mockLogModule.On("Log", mock.Anything, reqAll, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: reqAll,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error get user",
+			prepare: func() {
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, reqAll.UserID).
+					Return([]string{}, nil)
+				mockPostModule.On("FindPostListByParentIDAndAuthorIDs", mock.Anything, "", mock.Anything, []string{}, reqAll.UserID, reqAll.Pagination).
+					Return([]entity.Post{}, &api.PaginationRes{}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, reqAll.UserID).
+					Return(nil, errors.New("err"))
+				mockLogModule.On("Log", mock.Anything, reqAll, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: reqAll,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "sensitive content blurred by default",
+			prepare: func() {
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, reqAll.UserID).
+					Return([]string{}, nil)
+				mockPostModule.On("FindPostListByParentIDAndAuthorIDs", mock.Anything, "", mock.Anything, []string{}, reqAll.UserID, reqAll.Pagination).
+					Return(sensitivePosts, &api.PaginationRes{}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, reqAll.UserID).
+					Return(&entity.User{ID: reqAll.UserID}, nil)
+				mockUserModule.On("FindMutedWordList", mock.Anything, reqAll.UserID).
+					Return([]entity.MutedWord{}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: reqAll,
+			},
+			want:    &api.FeedRes{
+				PostList:   []entity.Post{
+					sensitivePosts[0],
+					{ID: "post-2", ContentWarnings: []string{constants.ContentWarningNSFW}, IsBlurred: true, User: entity.User{ID: "other-id"}},
+					sensitivePosts[2],
+					{ID: "post-4", QuotedPost: &entity.Post{ID: "post-2", ContentWarnings: []string{constants.ContentWarningNSFW}, IsBlurred: true, User: entity.User{ID: "other-id"}}},
+				},
+				Pagination: api.PaginationRes{},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "sensitive content hidden",
+			prepare: func() {
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, reqAll.UserID).
+					Return([]string{}, nil)
+				mockPostModule.On("FindPostListByParentIDAndAuthorIDs", mock.Anything, "", mock.Anything, []string{}, reqAll.UserID, reqAll.Pagination).
+					Return(sensitivePosts, &api.PaginationRes{}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, reqAll.UserID).
+					Return(&entity.User{
+						ID:       reqAll.UserID,
+						Settings: entity.Settings{SensitiveContent: constants.SensitiveContentHide},
+					}, nil)
+				mockUserModule.On("FindMutedWordList", mock.Anything, reqAll.UserID).
+					Return([]entity.MutedWord{}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: reqAll,
+			},
+			want:    &api.FeedRes{
+				PostList:   []entity.Post{sensitivePosts[0], sensitivePosts[2]},
+				Pagination: api.PaginationRes{},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "sensitive content shown",
+			prepare: func() {
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, reqAll.UserID).
+					Return([]string{}, nil)
+				mockPostModule.On("FindPostListByParentIDAndAuthorIDs", mock.Anything, "", mock.Anything, []string{}, reqAll.UserID, reqAll.Pagination).
+					Return(sensitivePosts, &api.PaginationRes{}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, reqAll.UserID).
+					Return(&entity.User{
+						ID:       reqAll.UserID,
+						Settings: entity.Settings{SensitiveContent: constants.SensitiveContentShow},
+					}, nil)
+				mockUserModule.On("FindMutedWordList", mock.Anything, reqAll.UserID).
+					Return([]entity.MutedWord{}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: reqAll,
+			},
+			want:    &api.FeedRes{
+				PostList:   sensitivePosts,
+				Pagination: api.PaginationRes{},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "post with muted word hidden",
+			prepare: func() {
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, reqAll.UserID).
+					Return([]string{}, nil)
+				mockPostModule.On("FindPostListByParentIDAndAuthorIDs", mock.Anything, "", mock.Anything, []string{}, reqAll.UserID, reqAll.Pagination).
+					Return([]entity.Post{
+						{ID: "post-1", Body: "Spoiler ENDGAME!!", User: entity.User{ID: "other-id"}},
+						{ID: "post-2", Body: "nonton endgames", User: entity.User{ID: "other-id"}},
+						{ID: "post-3", QuotedPost: &entity.Post{ID: "post-1", Body: "Spoiler ENDGAME!!"}, User: entity.User{ID: "other-id"}},
+						{ID: "post-4", Body: "endgame", User: entity.User{ID: reqAll.UserID}},
+					}, &api.PaginationRes{}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, reqAll.UserID).
+					Return(&entity.User{ID: reqAll.UserID}, nil)
+				mockUserModule.On("FindMutedWordList", mock.Anything, reqAll.UserID).
+					Return([]entity.MutedWord{{Word: "endgame"}}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: reqAll,
+			},
+			want:    &api.FeedRes{
+				PostList:   []entity.Post{
+					{ID: "post-2", Body: "nonton endgames", User: entity.User{ID: "other-id"}},
+					{ID: "post-4", Body: "endgame", User: entity.User{ID: reqAll.UserID}},
+				},
+				Pagination: api.PaginationRes{},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "success, type follow",
+			prepare: func() {
+				mockUserModule.On("GetFollowedUserID", mock.Anything, reqFollow.UserID).
+					Return([]string{"id"}, nil)
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, reqFollow.UserID).
+					Return([]string{}, nil)
+				mockPostModule.On("FindPostListByParentIDAndAuthorIDs", mock.Anything, "", []string{"id"}, []string{}, reqFollow.UserID, reqFollow.Pagination).
+					Return([]entity.Post{}, &api.PaginationRes{}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, reqFollow.UserID).
+					Return(&entity.User{ID: reqFollow.UserID}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: reqFollow,
+			},
+			want:    &api.FeedRes{
+				PostList:   []entity.Post{},
+				Pagination: api.PaginationRes{},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "success, type all",
+			prepare: func() {
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, reqAll.UserID).
+					Return([]string{}, nil)
+				mockPostModule.On("FindPostListByParentIDAndAuthorIDs", mock.Anything, "", mock.Anything, []string{}, reqAll.UserID, reqAll.Pagination).
+					Return([]entity.Post{}, &api.PaginationRes{}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, reqAll.UserID).
+					Return(&entity.User{ID: reqAll.UserID}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: reqAll,
+			},
+			want:    &api.FeedRes{
+				PostList:   []entity.Post{},
+				Pagination: api.PaginationRes{},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.Feed(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+
+func Test_service_Trending(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.TrendingReq{
+			UserID: "user-id",
+			Window: 24,
+			First:  10,
+		}
+		trending = []entity.TrendingPost{
+			{PostID: "post-2", Score: 10},
+			{PostID: "post-1", Score: 5},
+		}
+		postList = []entity.Post{
+			{ID: "post-2"},
+			{ID: "post-1"},
+		}
+		filteredPostList = []entity.Post{
+			{ID: "post-2", Author: entity.User{ID: "blocked-id"}},
+			{ID: "post-1", QuotedPost: &entity.Post{ID: "post-0", Author: entity.User{ID: "blocked-id"}}},
+			{ID: "post-3", Body: "spoiler final", User: entity.User{ID: "other-id"}},
+			{ID: "post-4", ContentWarnings: []string{constants.ContentWarningNSFW}, User: entity.User{ID: "other-id"}},
+			{ID: "post-5", User: entity.User{ID: "other-id"}},
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.TrendingReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.TrendingRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args: args{
+				ctx: ctx,
+				req: api.TrendingReq{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get trending post list",
+			prepare: func() {
+				mockTrendingModule.On("FindTrendingPostList", mock.Anything, req.Window, req.First).
+					Return(nil, err)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "empty trending",
+			prepare: func() {
+				mockTrendingModule.On("FindTrendingPostList", mock.Anything, req.Window, req.First).
+					Return(nil, nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.TrendingRes{},
+			wantErr: false,
+		},
+		{
+			name: "error when get post list",
+			prepare: func() {
+				mockTrendingModule.On("FindTrendingPostList", mock.Anything, req.Window, req.First).
+					Return(trending, nil)
+				mockPostModule.On("FindPostListByIDs", mock.Anything, []string{"post-2", "post-1"}, req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get hidden user",
+			prepare: func() {
+				mockTrendingModule.On("FindTrendingPostList", mock.Anything, req.Window, req.First).
+					Return(trending, nil)
+				mockPostModule.On("FindPostListByIDs", mock.Anything, []string{"post-2", "post-1"}, req.UserID).
+					Return(postList, nil)
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get user",
+			prepare: func() {
+				mockTrendingModule.On("FindTrendingPostList", mock.Anything, req.Window, req.First).
+					Return(trending, nil)
+				mockPostModule.On("FindPostListByIDs", mock.Anything, []string{"post-2", "post-1"}, req.UserID).
+					Return(postList, nil)
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, req.UserID).
+					Return([]string{}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when filter muted word",
+			prepare: func() {
+				mockTrendingModule.On("FindTrendingPostList", mock.Anything, req.Window, req.First).
+					Return(trending, nil)
+				mockPostModule.On("FindPostListByIDs", mock.Anything, []string{"post-2", "post-1"}, req.UserID).
+					Return(postList, nil)
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, req.UserID).
+					Return([]string{}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockUserModule.On("FindMutedWordList", mock.Anything, req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success",
+			prepare: func() {
+				mockTrendingModule.On("FindTrendingPostList", mock.Anything, req.Window, req.First).
+					Return(trending, nil)
+				mockPostModule.On("FindPostListByIDs", mock.Anything, []string{"post-2", "post-1"}, req.UserID).
+					Return(postList, nil)
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, req.UserID).
+					Return([]string{}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockUserModule.On("FindMutedWordList", mock.Anything, req.UserID).
+					Return([]entity.MutedWord{}, nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.TrendingRes{PostList: postList},
+			wantErr: false,
+		},
+		{
+			name: "exclude hidden user, muted word and sensitive content",
+			prepare: func() {
+				mockTrendingModule.On("FindTrendingPostList", mock.Anything, req.Window, req.First).
+					Return([]entity.TrendingPost{
+						{PostID: "post-2"}, {PostID: "post-1"}, {PostID: "post-3"}, {PostID: "post-4"}, {PostID: "post-5"},
+					}, nil)
+				mockPostModule.On("FindPostListByIDs", mock.Anything, []string{"post-2", "post-1", "post-3", "post-4", "post-5"}, req.UserID).
+					Return(filteredPostList, nil)
+				mockUserModule.On("FindHiddenUserIDs", mock.Anything, req.UserID).
+					Return([]string{"blocked-id"}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{
+						ID:       req.UserID,
+						Settings: entity.Settings{SensitiveContent: constants.SensitiveContentHide},
+					}, nil)
+				mockUserModule.On("FindMutedWordList", mock.Anything, req.UserID).
+					Return([]entity.MutedWord{{Word: "spoiler"}}, nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.TrendingRes{PostList: []entity.Post{filteredPostList[4]}},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.Trending(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_TrendingTopics(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.TrendingTopicsReq{
+			Window: 24,
+			First:  10,
+		}
+		topics = []entity.Topic{
+			{Name: "kampus", PostsCount: 3, Score: 2},
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.TrendingTopicsReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.TrendingTopicsRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid window",
+			prepare: nil,
+			args: args{
+				ctx: ctx,
+				req: api.TrendingTopicsReq{Window: 5},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get trending topic list",
+			prepare: func() {
+				mockTrendingModule.On("FindTrendingTopicList", mock.Anything, req.Window, req.First).
+					Return(nil, err)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success",
+			prepare: func() {
+				mockTrendingModule.On("FindTrendingTopicList", mock.Anything, req.Window, req.First).
+					Return(topics, nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.TrendingTopicsRes{Topics: topics},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.TrendingTopics(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_RefreshTrending(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		err  = errors.New("some error")
+		now  = int64(1000000)
+		hour = int64(3600)
+		req  = api.RefreshTrendingReq{Now: now}
+		postList = []entity.Post{
+			// created in every window, all 18 engagement is growth
+			{ID: "post-1", Body: "#Kampus rame", Timestamp: now - 2*hour, LikesCount: 15, RepliesCount: 3},
+			// older than the 6 hours window which has no snapshot, skipped there
+			{ID: "post-2", Body: "#kampus #kantin", Timestamp: now - 10*hour, LikesCount: 36},
+			// gained 24 since the 24 hours snapshot
+			{ID: "post-3", Body: "lama", Timestamp: now - 48*hour, LikesCount: 144},
+			// no engagement, not trending
+			{ID: "post-4", Body: "sepi", Timestamp: now - hour},
+			// missing from the 24 hours snapshot, skipped there
+			{ID: "post-5", Body: "baru", Timestamp: now - 30*hour, LikesCount: 36},
+		}
+		snapshot = &entity.EngagementSnapshot{
+			TakenAt:    now - 24*hour,
+			Engagement: map[string]int{"post-2": 10, "post-3": 120},
+		}
+		current = entity.EngagementSnapshot{
+			TakenAt:    now,
+			Engagement: map[string]int{"post-1": 18, "post-2": 36, "post-3": 144, "post-4": 0, "post-5": 36},
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.RefreshTrendingReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.RefreshTrendingRes
+		wantErr bool
+	}{
+		{
+			name: "error when get post list",
+			prepare: func() {
+				mockPostModule.On("FindPostListCreatedAfter", mock.Anything, now-72*hour).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get engagement snapshot",
+			prepare: func() {
+				mockPostModule.On("FindPostListCreatedAfter", mock.Anything, now-72*hour).
+					Return(postList, nil)
+				mockTrendingModule.On("FindEngagementSnapshot", mock.Anything, now-6*hour).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when save trending post list",
+			prepare: func() {
+				mockPostModule.On("FindPostListCreatedAfter", mock.Anything, now-72*hour).
+					Return(postList, nil)
+				mockTrendingModule.On("FindEngagementSnapshot", mock.Anything, mock.Anything).
+					Return(nil, constants.ErrEngagementSnapshotNotFound)
+				mockTrendingModule.On("SaveTrendingPostList", mock.Anything, mock.Anything, mock.Anything).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when save trending topic list",
+			prepare: func() {
+				mockPostModule.On("FindPostListCreatedAfter", mock.Anything, now-72*hour).
+					Return(postList, nil)
+				mockTrendingModule.On("FindEngagementSnapshot", mock.Anything, mock.Anything).
+					Return(nil, constants.ErrEngagementSnapshotNotFound)
+				mockTrendingModule.On("SaveTrendingPostList", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				mockTrendingModule.On("SaveTrendingTopicList", mock.Anything, mock.Anything, mock.Anything).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when save engagement snapshot",
+			prepare: func() {
+				mockPostModule.On("FindPostListCreatedAfter", mock.Anything, now-72*hour).
+					Return(postList, nil)
+				mockTrendingModule.On("FindEngagementSnapshot", mock.Anything, mock.Anything).
+					Return(nil, constants.ErrEngagementSnapshotNotFound)
+				mockTrendingModule.On("SaveTrendingPostList", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				mockTrendingModule.On("SaveTrendingTopicList", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				mockTrendingModule.On("SaveEngagementSnapshot", mock.Anything, current).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when delete old snapshot still success",
+			prepare: func() {
+				mockPostModule.On("FindPostListCreatedAfter", mock.Anything, now-72*hour).
+					Return(postList, nil)
+				mockTrendingModule.On("FindEngagementSnapshot", mock.Anything, mock.Anything).
+					Return(nil, constants.ErrEngagementSnapshotNotFound)
+				mockTrendingModule.On("SaveTrendingPostList", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				mockTrendingModule.On("SaveTrendingTopicList", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				mockTrendingModule.On("SaveEngagementSnapshot", mock.Anything, current).
+					Return(nil)
+				mockTrendingModule.On("DeleteEngagementSnapshotBefore", mock.Anything, now-144*hour).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.RefreshTrendingRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name: "success",
+			prepare: func() {
+				mockPostModule.On("FindPostListCreatedAfter", mock.Anything, now-72*hour).
+					Return(postList, nil)
+				mockTrendingModule.On("FindEngagementSnapshot", mock.Anything, now-6*hour).
+					Return(nil, constants.ErrEngagementSnapshotNotFound)
+				mockTrendingModule.On("FindEngagementSnapshot", mock.Anything, now-24*hour).
+					Return(snapshot, nil)
+				mockTrendingModule.On("FindEngagementSnapshot", mock.Anything, now-72*hour).
+					Return(nil, constants.ErrEngagementSnapshotNotFound)
+				mockTrendingModule.On("SaveTrendingPostList", mock.Anything, 6, []entity.TrendingPost{
+					{PostID: "post-1", Score: 3},
+				}).Return(nil)
+				mockTrendingModule.On("SaveTrendingPostList", mock.Anything, 24, []entity.TrendingPost{
+					{PostID: "post-2", Score: 1.5},
+					{PostID: "post-3", Score: 1},
+					{PostID: "post-1", Score: 0.75},
+				}).Return(nil)
+				mockTrendingModule.On("SaveTrendingPostList", mock.Anything, 72, []entity.TrendingPost{
+					{PostID: "post-3", Score: 2},
+					{PostID: "post-2", Score: 0.5},
+					{PostID: "post-5", Score: 0.5},
+					{PostID: "post-1", Score: 0.25},
+				}).Return(nil)
+				mockTrendingModule.On("SaveTrendingTopicList", mock.Anything, 6, []entity.Topic{
+					{Name: "kampus", PostsCount: 1, Score: 3},
+				}).Return(nil)
+				mockTrendingModule.On("SaveTrendingTopicList", mock.Anything, 24, []entity.Topic{
+					{Name: "kampus", PostsCount: 2, Score: 2.25},
+					{Name: "kantin", PostsCount: 1, Score: 1.5},
+				}).Return(nil)
+				mockTrendingModule.On("SaveTrendingTopicList", mock.Anything, 72, []entity.Topic{
+					{Name: "kampus", PostsCount: 2, Score: 0.75},
+					{Name: "kantin", PostsCount: 1, Score: 0.5},
+				}).Return(nil)
+				mockTrendingModule.On("SaveEngagementSnapshot", mock.Anything, current).
+					Return(nil)
+				mockTrendingModule.On("DeleteEngagementSnapshotBefore", mock.Anything, now-144*hour).
+					Return(nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.RefreshTrendingRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.RefreshTrending(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+				mockTrendingModule.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+
+func Test_service_BookmarkPost(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.BookmarkPostReq{
+			PostID: "post-id",
+			UserID: "user-id",
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.BookmarkPostReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.BookmarkPostRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args: args{
+				ctx: ctx,
+				req: api.BookmarkPostReq{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "post not found",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(nil, constants.ErrPostNotFound)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get post",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when insert bookmark",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{}, nil)
+				mockBookmarkModule.On("InsertBookmark", mock.Anything, req.UserID, req.PostID).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when delete bookmark",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{IsBookmarked: true}, nil)
+				mockBookmarkModule.On("DeleteBookmark", mock.Anything, req.UserID, req.PostID).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success bookmark",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{}, nil)
+				mockBookmarkModule.On("InsertBookmark", mock.Anything, req.UserID, req.PostID).
+					Return(nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.BookmarkPostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name: "success remove bookmark",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{IsBookmarked: true}, nil)
+				mockBookmarkModule.On("DeleteBookmark", mock.Anything, req.UserID, req.PostID).
+					Return(nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.BookmarkPostRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.BookmarkPost(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_GetBookmarkList(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.GetBookmarkListReq{
+			UserID: "user-id",
+			Pagination: api.PaginationReq{
+				First: 10,
+				After: "1234",
+			},
+		}
+		paginationRes = api.PaginationRes{
+			EndCursor:   "some cursor",
+			HasNextPage: false,
+		}
+		postList = []entity.Post{
+			{ID: "post-1", IsBookmarked: true},
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.GetBookmarkListReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.GetBookmarkListRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args: args{
+				ctx: ctx,
+				req: api.GetBookmarkListReq{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get bookmarked post id",
+			prepare: func() {
+				mockBookmarkModule.On("FindBookmarkedPostIDs", mock.Anything, req.UserID, req.Pagination).
+					Return(nil, nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "empty bookmark",
+			prepare: func() {
+				mockBookmarkModule.On("FindBookmarkedPostIDs", mock.Anything, req.UserID, req.Pagination).
+					Return([]string{}, &paginationRes, nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.GetBookmarkListRes{Pagination: paginationRes},
+			wantErr: false,
+		},
+		{
+			name: "error when get post list",
+			prepare: func() {
+				mockBookmarkModule.On("FindBookmarkedPostIDs", mock.Anything, req.UserID, req.Pagination).
+					Return([]string{"post-1"}, &paginationRes, nil)
+				mockPostModule.On("FindPostListByIDs", mock.Anything, []string{"post-1"}, req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success",
+			prepare: func() {
+				mockBookmarkModule.On("FindBookmarkedPostIDs", mock.Anything, req.UserID, req.Pagination).
+					Return([]string{"post-1"}, &paginationRes, nil)
+				mockPostModule.On("FindPostListByIDs", mock.Anything, []string{"post-1"}, req.UserID).
+					Return(postList, nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want: &api.GetBookmarkListRes{
+				PostList:   postList,
+				Pagination: paginationRes,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.GetBookmarkList(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+
+func Test_service_RepostPost(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.RepostPostReq{
+			PostID: "post-id",
+			UserID: "user-id",
+		}
+		user = entity.User{ID: "user-id"}
+	)
+	type args struct {
+		ctx context.Context
+		req api.RepostPostReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.RepostPostRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args: args{
+				ctx: ctx,
+				req: api.RepostPostReq{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get user",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "post not found",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&user, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(nil, constants.ErrPostNotFound)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get repost",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&user, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{ID: req.PostID}, nil)
+				mockPostModule.On("FindRepost", mock.Anything, req.PostID, req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when insert repost",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&user, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{ID: req.PostID}, nil)
+				mockPostModule.On("FindRepost", mock.Anything, req.PostID, req.UserID).
+					Return(nil, constants.ErrPostNotFound)
+				mockPostModule.On("InsertPost", mock.Anything, mock.Anything).
+					Return("", err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success repost a repost",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&user, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{
+						ID:         req.PostID,
+						IsRepost:   true,
+						QuotedPost: &entity.Post{ID: "original-id"},
+					}, nil)
+				mockPostModule.On("FindRepost", mock.Anything, "original-id", req.UserID).
+					Return(nil, constants.ErrPostNotFound)
+				mockPostModule.On("InsertPost", mock.Anything, entity.Post{
+					IsRepost:   true,
+					QuotedPost: &entity.Post{ID: "original-id"},
+					Parent:     &entity.Post{},
+					Author:     user,
+					User:       user,
+				}).Return("repost-id", nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.RepostPostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name: "error when delete repost",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&user, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{ID: req.PostID}, nil)
+				mockPostModule.On("FindRepost", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{ID: "repost-id"}, nil)
+				mockPostModule.On("DeletePost", mock.Anything, "repost-id").
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success undo repost",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&user, nil)
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{ID: req.PostID}, nil)
+				mockPostModule.On("FindRepost", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{ID: "repost-id"}, nil)
+				mockPostModule.On("DeletePost", mock.Anything, "repost-id").
+					Return(nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.RepostPostRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.RepostPost(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+
+func Test_service_VotePoll(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.VotePollReq{
+			PostID:    "post-id",
+			UserID:    "user-id",
+			OptionIDs: []string{"1"},
+		}
+		poll = entity.Poll{
+			Options: []entity.PollOption{
+				{ID: "1", Text: "yes"},
+				{ID: "2", Text: "no"},
+			},
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.VotePollReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.VotePollRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args: args{
+				ctx: ctx,
+				req: api.VotePollReq{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get post",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "post doesn't have poll",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{}, nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "poll closed",
+			prepare: func() {
+				closed := poll
+				closed.ExpiredAt = 1
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{Poll: &closed}, nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "already voted",
+			prepare: func() {
+				voted := poll
+				voted.MyVotes = []string{"2"}
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{Poll: &voted}, nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "multiple option on single choice poll",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{Poll: &poll}, nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: api.VotePollReq{
+					PostID:    req.PostID,
+					UserID:    req.UserID,
+					OptionIDs: []string{"1", "2"},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "duplicate option on multiple choice poll",
+			prepare: func() {
+				multiple := poll
+				multiple.MultipleChoice = true
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{Poll: &multiple}, nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: api.VotePollReq{
+					PostID:    req.PostID,
+					UserID:    req.UserID,
+					OptionIDs: []string{"1", "1"},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "unknown option",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{Poll: &poll}, nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: api.VotePollReq{
+					PostID:    req.PostID,
+					UserID:    req.UserID,
+					OptionIDs: []string{"3"},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "concurrent vote already counted",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{Poll: &poll}, nil)
+				mockPostModule.On("VotePoll", mock.Anything, req.PostID, req.UserID, req.OptionIDs).
+					Return(constants.ErrAlreadyVoted)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when vote poll",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{Poll: &poll}, nil)
+				mockPostModule.On("VotePoll", mock.Anything, req.PostID, req.UserID, req.OptionIDs).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{Poll: &poll}, nil)
+				mockPostModule.On("VotePoll", mock.Anything, req.PostID, req.UserID, req.OptionIDs).
+					Return(nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.VotePollRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.VotePoll(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+
+func Test_service_GetScheduledPostList(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.GetScheduledPostListReq{
+			UserID: "user-id",
+			Pagination: api.PaginationReq{
+				First: 10,
+			},
+		}
+		paginationRes = api.PaginationRes{
+			EndCursor:   "some cursor",
+			HasNextPage: false,
+		}
+		postList = []entity.Post{
+			{ID: "post-1", IsScheduled: true},
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.GetScheduledPostListReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.GetScheduledPostListRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args: args{
+				ctx: ctx,
+				req: api.GetScheduledPostListReq{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get scheduled post list",
+			prepare: func() {
+				mockPostModule.On("FindScheduledPostListByUserID", mock.Anything, req.UserID, req.Pagination).
+					Return(nil, nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success",
+			prepare: func() {
+				mockPostModule.On("FindScheduledPostListByUserID", mock.Anything, req.UserID, req.Pagination).
+					Return(postList, &paginationRes, nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want: &api.GetScheduledPostListRes{
+				PostList:   postList,
+				Pagination: paginationRes,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.GetScheduledPostList(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_CancelScheduledPost(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.CancelScheduledPostReq{
+			PostID: "post-id",
+			UserID: "user-id",
+		}
+		post = entity.Post{
+			ID:          req.PostID,
+			IsScheduled: true,
+			User:        entity.User{ID: req.UserID},
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.CancelScheduledPostReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.CancelScheduledPostRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args: args{
+				ctx: ctx,
+				req: api.CancelScheduledPostReq{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "scheduled post not found",
+			prepare: func() {
+				mockPostModule.On("FindScheduledPostByID", mock.Anything, req.PostID).
+					Return(nil, constants.ErrPostNotFound)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get scheduled post",
+			prepare: func() {
+				mockPostModule.On("FindScheduledPostByID", mock.Anything, req.PostID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "scheduled post owned by other user",
+			prepare: func() {
+				mockPostModule.On("FindScheduledPostByID", mock.Anything, req.PostID).
+					Return(&entity.Post{ID: req.PostID, User: entity.User{ID: "other-user"}}, nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "published before deleted",
+			prepare: func() {
+				mockPostModule.On("FindScheduledPostByID", mock.Anything, req.PostID).
+					Return(&post, nil)
+				mockPostModule.On("DeleteScheduledPost", mock.Anything, req.PostID).
+					Return(constants.ErrPostNotFound)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when delete post",
+			prepare: func() {
+				mockPostModule.On("FindScheduledPostByID", mock.Anything, req.PostID).
+					Return(&post, nil)
+				mockPostModule.On("DeleteScheduledPost", mock.Anything, req.PostID).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success",
+			prepare: func() {
+				mockPostModule.On("FindScheduledPostByID", mock.Anything, req.PostID).
+					Return(&post, nil)
+				mockPostModule.On("DeleteScheduledPost", mock.Anything, req.PostID).
+					Return(nil)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.CancelScheduledPostRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.CancelScheduledPost(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_PublishScheduledPost(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.PublishScheduledPostReq{Now: 1000000}
+		postList = []entity.Post{
+			{ID: "post-1", IsScheduled: true},
+			{ID: "post-2", IsScheduled: true},
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.PublishScheduledPostReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.PublishScheduledPostRes
+		wantErr bool
+	}{
+		{
+			name: "error when get due scheduled post list",
+			prepare: func() {
+				mockPostModule.On("FindDueScheduledPostList", mock.Anything, req.Now).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "failed publish one post keep publishing the rest",
+			prepare: func() {
+				mockPostModule.On("FindDueScheduledPostList", mock.Anything, req.Now).
+					Return(postList, nil)
+				mockPostModule.On("PublishPost", mock.Anything, "post-1").
+					Return(false, err)
+				mockLogModule.On("Log", err, postList[0], mock.Anything)
+				mockPostModule.On("PublishPost", mock.Anything, "post-2").
+					Return(true, nil)
+				mockNotificationModule.On("BroadcastNewPostNotification", mock.Anything, postList[1]).
+					Return(nil).Once()
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.PublishScheduledPostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name: "post already published by other worker",
+			prepare: func() {
+				mockPostModule.On("FindDueScheduledPostList", mock.Anything, req.Now).
+					Return(postList[:1], nil)
+				mockPostModule.On("PublishPost", mock.Anything, "post-1").
+					Return(false, nil).Once()
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.PublishScheduledPostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name: "flagged post published without notification",
+			prepare: func() {
+				post := entity.Post{ID: "post-1", IsScheduled: true, IsHidden: true, MentionIDs: []string{"friend-id"}}
+				mockPostModule.On("FindDueScheduledPostList", mock.Anything, req.Now).
+					Return([]entity.Post{post}, nil)
+				mockPostModule.On("PublishPost", mock.Anything, "post-1").
+					Return(true, nil).Once()
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.PublishScheduledPostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name: "send mention notification when published",
+			prepare: func() {
+				post := entity.Post{ID: "post-1", IsScheduled: true, MentionIDs: []string{"friend-id"}}
+				mockPostModule.On("FindDueScheduledPostList", mock.Anything, req.Now).
+					Return([]entity.Post{post}, nil)
+				mockPostModule.On("PublishPost", mock.Anything, "post-1").
+					Return(true, nil)
+				mockNotificationModule.On("BroadcastNewPostNotification", mock.Anything, post).
+					Return(nil)
+				mockUserModule.On("IsBlocked", mock.Anything, "friend-id", mock.Anything).
+					Return(false, nil)
+				mockUserModule.On("FindMutedWordList", mock.Anything, "friend-id").
+					Return([]entity.MutedWord{}, nil)
+				mockNotificationModule.On("SendMentionNotification", mock.Anything, post, []string{"friend-id"}).
+					Return(nil).Once()
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.PublishScheduledPostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name: "error when send notification",
+			prepare: func() {
+				mockPostModule.On("FindDueScheduledPostList", mock.Anything, req.Now).
+					Return(postList[:1], nil)
+				mockPostModule.On("PublishPost", mock.Anything, "post-1").
+					Return(true, nil)
+				mockNotificationModule.On("BroadcastNewPostNotification", mock.Anything, postList[0]).
+					Return(err)
+				mockLogModule.On("Log", err, postList[0], mock.Anything)
+			},
+			args: args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.PublishScheduledPostRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.PublishScheduledPost(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+				mockNotificationModule.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func Test_service_DeleteExpiredPost(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.DeleteExpiredPostReq{Now: 1000}
+	)
+	type args struct {
+		ctx context.Context
+		req api.DeleteExpiredPostReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.DeleteExpiredPostRes
+		wantErr bool
+	}{
+		{
+			name:    "error when delete expired post",
+			prepare: func() {
+				mockPostModule.On("DeleteExpiredPost", mock.Anything, req.Now).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockPostModule.On("DeleteExpiredPost", mock.Anything, req.Now).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.DeleteExpiredPostRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.DeleteExpiredPost(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_UploadImage(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.UploadImageReq{
+			UserID: "user-id",
+			Data:   []byte("\x89PNG\r\n\x1a\n"),
+		}
+		processed = func() *entity.ProcessedImage {
+			return &entity.ProcessedImage{
+				BlurHash: "blur-hash",
+				Variants: []entity.ImageVariant{
+					{Name: "original", ContentType: "image/jpeg", Width: 2000, Height: 1000, Data: []byte("original")},
+					{Name: "thumbnail", ContentType: "image/jpeg", Width: 320, Height: 160, Data: []byte("thumbnail")},
+				},
+			}
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.UploadImageReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.UploadImageRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.UploadImageReq{UserID: "user-id"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when process image",
+			prepare: func() {
+				mockMediaModule.On("ProcessImage", mock.Anything, req.Data, "image/png").
+					Return(nil, err)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when store image",
+			prepare: func() {
+				mockMediaModule.On("ProcessImage", mock.Anything, req.Data, "image/png").
+					Return(processed(), nil)
+				mockStorageModule.On("Put", mock.Anything, mock.Anything, mock.Anything, "image/jpeg").
+					Return("", err)
+				mockLogModule.On("Log", err, req.UserID, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when save attachment",
+			prepare: func() {
+				mockMediaModule.On("ProcessImage", mock.Anything, req.Data, "image/png").
+					Return(processed(), nil)
+				mockStorageModule.On("Put", mock.Anything, mock.Anything, mock.Anything, "image/jpeg").
+					Return("url", nil)
+				mockAttachmentModule.On("InsertAttachment", mock.Anything, mock.Anything).
+					Return("", err)
+				mockLogModule.On("Log", err, req.UserID, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockMediaModule.On("ProcessImage", mock.Anything, req.Data, "image/png").
+					Return(processed(), nil)
+				mockStorageModule.On("Put", mock.Anything, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, "attachments/") && strings.HasSuffix(key, "/original.jpg")
+				}), []byte("original"), "image/jpeg").
+					Return("original-url", nil)
+				mockStorageModule.On("Put", mock.Anything, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, "attachments/") && strings.HasSuffix(key, "/thumbnail.jpg")
+				}), []byte("thumbnail"), "image/jpeg").
+					Return("thumbnail-url", nil)
+				mockAttachmentModule.On("InsertAttachment", mock.Anything, mock.MatchedBy(func(a entity.Attachment) bool {
+					return a.URL == "original-url" && a.OwnerID == req.UserID
+				})).Return("attachment-id", nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.UploadImageRes{Attachment: entity.Attachment{
+				ID:          "attachment-id",
+				URL:         "original-url",
+				ContentType: "image/jpeg",
+				Size:        len("original"),
+				Width:       2000,
+				Height:      1000,
+				BlurHash:    "blur-hash",
+				Variants:    []entity.ImageVariant{
+					{Name: "original", URL: "original-url", ContentType: "image/jpeg", Width: 2000, Height: 1000},
+					{Name: "thumbnail", URL: "thumbnail-url", ContentType: "image/jpeg", Width: 320, Height: 160},
+				},
+				OwnerID:     req.UserID,
+			}},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.UploadImage(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_GetAvatarList(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.GetAvatarListReq{}
+		avatars = []entity.Avatar{{ID: "avatar-id", URL: "url"}}
+	)
+	type args struct {
+		ctx context.Context
+		req api.GetAvatarListReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.GetAvatarListRes
+		wantErr bool
+	}{
+		{
+			name:    "error when get avatar list",
+			prepare: func() {
+				mockAvatarModule.On("FindAvatarList", mock.Anything).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockAvatarModule.On("FindAvatarList", mock.Anything).
+					Return(avatars, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.GetAvatarListRes{AvatarList: avatars},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.GetAvatarList(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_AddAvatar(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.AddAvatarReq{
+			UserID: "user-id",
+			URL:    "https://example.com/avatar.jpg",
+		}
+		admin = &entity.User{ID: "user-id", Account: entity.Account{Role: constants.RoleAdmin}}
+	)
+	type args struct {
+		ctx context.Context
+		req api.AddAvatarReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.AddAvatarRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.AddAvatarReq{UserID: "user-id", URL: "avatar"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "not admin",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when get user",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "already in catalog",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(admin, nil)
+				mockAvatarModule.On("FindAvatarByURL", mock.Anything, req.URL).
+					Return(&entity.Avatar{URL: req.URL}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.AddAvatarRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "error when save avatar",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(admin, nil)
+				mockAvatarModule.On("FindAvatarByURL", mock.Anything, req.URL).
+					Return(nil, constants.ErrAvatarNotFound)
+				mockAvatarModule.On("InsertAvatar", mock.Anything, entity.Avatar{URL: req.URL}).
+					Return("", err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when insert audit log remove the avatar",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(admin, nil)
+				mockAvatarModule.On("FindAvatarByURL", mock.Anything, req.URL).
+					Return(nil, constants.ErrAvatarNotFound)
+				mockAvatarModule.On("InsertAvatar", mock.Anything, entity.Avatar{URL: req.URL}).
+					Return("avatar-id", nil)
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.Anything).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+				mockAvatarModule.On("DeleteAvatar", mock.Anything, req.URL).
+					Return(nil).Once()
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(admin, nil)
+				mockAvatarModule.On("FindAvatarByURL", mock.Anything, req.URL).
+					Return(nil, constants.ErrAvatarNotFound)
+				mockAvatarModule.On("InsertAvatar", mock.Anything, entity.Avatar{URL: req.URL}).
+					Return("avatar-id", nil)
+				mockAuditModule.On("InsertAuditLog", mock.Anything, entity.AuditLog{
+					ActorID:    req.UserID,
+					Action:     constants.AuditActionAddAvatar,
+					TargetType: constants.AuditTargetAvatar,
+					TargetID:   "avatar-id",
+					After:      entity.Snapshot{"url": req.URL},
+				}).Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.AddAvatarRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.AddAvatar(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_RemoveAvatar(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.RemoveAvatarReq{
+			UserID: "user-id",
+			URL:    "https://example.com/avatar.jpg",
+		}
+		admin = &entity.User{ID: "user-id", Account: entity.Account{Role: constants.RoleAdmin}}
+	)
+	type args struct {
+		ctx context.Context
+		req api.RemoveAvatarReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.RemoveAvatarRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.RemoveAvatarReq{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "not admin",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "avatar not in catalog",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(admin, nil)
+				mockAvatarModule.On("FindAvatarByURL", mock.Anything, req.URL).
+					Return(nil, constants.ErrAvatarNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.RemoveAvatarRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "error when insert audit log",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(admin, nil)
+				mockAvatarModule.On("FindAvatarByURL", mock.Anything, req.URL).
+					Return(&entity.Avatar{ID: "avatar-id", URL: req.URL}, nil)
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.Anything).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when delete avatar",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(admin, nil)
+				mockAvatarModule.On("FindAvatarByURL", mock.Anything, req.URL).
+					Return(&entity.Avatar{ID: "avatar-id", URL: req.URL}, nil)
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.Anything).
+					Return(nil)
+				mockAvatarModule.On("DeleteAvatar", mock.Anything, req.URL).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(admin, nil)
+				mockAvatarModule.On("FindAvatarByURL", mock.Anything, req.URL).
+					Return(&entity.Avatar{ID: "avatar-id", URL: req.URL}, nil)
+				mockAvatarModule.On("DeleteAvatar", mock.Anything, req.URL).
+					Return(nil)
+				mockAuditModule.On("InsertAuditLog", mock.Anything, entity.AuditLog{
+					ActorID:    req.UserID,
+					Action:     constants.AuditActionRemoveAvatar,
+					TargetType: constants.AuditTargetAvatar,
+					TargetID:   "avatar-id",
+					Before:     entity.Snapshot{"url": req.URL},
+				}).Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.RemoveAvatarRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.RemoveAvatar(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_UploadAvatar(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.UploadAvatarReq{
+			UserID: "user-id",
+			Data:   []byte("\x89PNG\r\n\x1a\n"),
+		}
+		processed = func() *entity.ProcessedImage {
+			return &entity.ProcessedImage{
+				Variants: []entity.ImageVariant{
+					{Name: "original", ContentType: "image/jpeg", Data: []byte("original")},
+					{Name: "thumbnail", ContentType: "image/jpeg", Data: []byte("thumbnail")},
+				},
+			}
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.UploadAvatarReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.UploadAvatarRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.UploadAvatarReq{UserID: "user-id", Data: []byte("text")},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "user not found",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(nil, constants.ErrUserNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when store image",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockMediaModule.On("ProcessImage", mock.Anything, req.Data, "image/png").
+					Return(processed(), nil)
+				mockStorageModule.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return("", err)
+				mockLogModule.On("Log", err, req.UserID, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when save profile",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockMediaModule.On("ProcessImage", mock.Anything, req.Data, "image/png").
+					Return(processed(), nil)
+				mockStorageModule.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return("url", nil)
+				mockAttachmentModule.On("InsertAttachment", mock.Anything, mock.Anything).
+					Return("attachment-id", nil)
+				mockUserModule.On("SaveProfile", mock.Anything, mock.Anything).
+					Return(err)
+				mockLogModule.On("Log", err, req.UserID, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockMediaModule.On("ProcessImage", mock.Anything, req.Data, "image/png").
+					Return(processed(), nil)
+				mockStorageModule.On("Put", mock.Anything, mock.Anything, []byte("original"), mock.Anything).
+					Return("original-url", nil)
+				mockStorageModule.On("Put", mock.Anything, mock.Anything, []byte("thumbnail"), mock.Anything).
+					Return("thumbnail-url", nil)
+				mockAttachmentModule.On("InsertAttachment", mock.Anything, mock.Anything).
+					Return("attachment-id", nil)
+				mockUserModule.On("SaveProfile", mock.Anything, mock.MatchedBy(func(u entity.User) bool {
+					return u.Profile.Avatar == "thumbnail-url"
+				})).Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.UploadAvatarRes{URL: "thumbnail-url"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.UploadAvatar(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_UpdateSettings(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.UpdateSettingsReq{
+			UserID:           "user-id",
+			SensitiveContent: constants.SensitiveContentHide,
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.UpdateSettingsReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.UpdateSettingsRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.UpdateSettingsReq{UserID: "user-id", SensitiveContent: "none"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "user not found",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(nil, constants.ErrUserNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when save settings",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockUserModule.On("SaveSettings", mock.Anything, mock.Anything).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockUserModule.On("SaveSettings", mock.Anything, entity.User{
+					ID:       req.UserID,
+					Settings: entity.Settings{SensitiveContent: constants.SensitiveContentHide},
+				}).Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.UpdateSettingsRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.UpdateSettings(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_SetContentWarnings(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.SetContentWarningsReq{
+			PostID:          "post-id",
+			UserID:          "user-id",
+			ContentWarnings: []string{constants.ContentWarningSelfHarm},
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.SetContentWarningsReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.SetContentWarningsRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.SetContentWarningsReq{PostID: "post-id", UserID: "user-id", ContentWarnings: []string{"gore"}},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "post not found",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(nil, constants.ErrPostNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "not author nor moderator",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{ID: req.PostID, User: entity.User{ID: "other-id"}}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when update content warnings",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{ID: req.PostID, User: entity.User{ID: req.UserID}}, nil)
+				mockPostModule.On("UpdateContentWarnings", mock.Anything, req.PostID, req.ContentWarnings).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when insert audit log",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{ID: req.PostID, User: entity.User{ID: "other-id"}}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID, Account: entity.Account{Role: constants.RoleModerator}}, nil)
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.Anything).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success by admin",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{ID: req.PostID, User: entity.User{ID: "other-id"}}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID, Account: entity.Account{Role: constants.RoleAdmin}}, nil)
+				mockPostModule.On("UpdateContentWarnings", mock.Anything, req.PostID, req.ContentWarnings).
+					Return(nil)
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.MatchedBy(func(l entity.AuditLog) bool {
+					return l.Action == constants.AuditActionSetContentWarnings &&
+						l.ActorID == req.UserID &&
+						l.TargetType == constants.AuditTargetPost &&
+						l.TargetID == req.PostID &&
+						len(l.Before["content_warnings"].([]string)) == 0 &&
+						len(l.After["content_warnings"].([]string)) == 1
+				})).Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.SetContentWarningsRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success by moderator",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{ID: req.PostID, User: entity.User{ID: "other-id"}}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID, Account: entity.Account{Role: constants.RoleModerator}}, nil)
+				mockPostModule.On("UpdateContentWarnings", mock.Anything, req.PostID, req.ContentWarnings).
+					Return(nil)
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.MatchedBy(func(l entity.AuditLog) bool {
+					return l.Action == constants.AuditActionSetContentWarnings && l.ActorID == req.UserID
+				})).Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.SetContentWarningsRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success by author",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{ID: req.PostID, User: entity.User{ID: req.UserID}}, nil)
+				mockPostModule.On("UpdateContentWarnings", mock.Anything, req.PostID, req.ContentWarnings).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.SetContentWarningsRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.SetContentWarnings(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_FetchLinkPreviews(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.FetchLinkPreviewsReq{Limit: 10, Now: 1000000}
+		post = entity.Post{ID: "post-id", Links: []string{"https://a.com", "https://b.com"}}
+		preview = &entity.LinkPreview{URL: "https://a.com", Title: "A"}
+	)
+	type args struct {
+		ctx context.Context
+		req api.FetchLinkPreviewsReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.FetchLinkPreviewsRes
+		wantErr bool
+	}{
+		{
+			name:    "error when get pending post list",
+			prepare: func() {
+				mockPostModule.On("FindPendingLinkPreviewPostList", mock.Anything, req.Now, req.Limit).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when fetch link preview delay the post",
+			prepare: func() {
+				mockPostModule.On("FindPendingLinkPreviewPostList", mock.Anything, req.Now, req.Limit).
+					Return([]entity.Post{post}, nil)
+				mockLinkPreviewModule.On("FetchLinkPreview", mock.Anything, "https://a.com").
+					Return(nil, err)
+				mockLogModule.On("Log", err, post, mock.Anything)
+				mockPostModule.On("DelayLinkPreview", mock.Anything, post.ID, req.Now+constants.LinkPreviewRetryDelay).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.FetchLinkPreviewsRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "error when fetch link preview again double the delay",
+			prepare: func() {
+				retried := post
+				retried.LinkPreviewAttempts = 2
+				mockPostModule.On("FindPendingLinkPreviewPostList", mock.Anything, req.Now, req.Limit).
+					Return([]entity.Post{retried}, nil)
+				mockLinkPreviewModule.On("FetchLinkPreview", mock.Anything, "https://a.com").
+					Return(nil, err)
+				mockLogModule.On("Log", err, retried, mock.Anything)
+				mockPostModule.On("DelayLinkPreview", mock.Anything, post.ID, req.Now+4*constants.LinkPreviewRetryDelay).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.FetchLinkPreviewsRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "error when delay link preview",
+			prepare: func() {
+				mockPostModule.On("FindPendingLinkPreviewPostList", mock.Anything, req.Now, req.Limit).
+					Return([]entity.Post{post}, nil)
+				mockLinkPreviewModule.On("FetchLinkPreview", mock.Anything, "https://a.com").
+					Return(nil, err)
+				mockPostModule.On("DelayLinkPreview", mock.Anything, post.ID, mock.Anything).
+					Return(err)
+				mockLogModule.On("Log", err, post, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.FetchLinkPreviewsRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "error on the last attempt save without preview",
+			prepare: func() {
+				exhausted := post
+				exhausted.LinkPreviewAttempts = constants.LinkPreviewMaxAttempts - 1
+				mockPostModule.On("FindPendingLinkPreviewPostList", mock.Anything, req.Now, req.Limit).
+					Return([]entity.Post{exhausted}, nil)
+				mockLinkPreviewModule.On("FetchLinkPreview", mock.Anything, "https://a.com").
+					Return(nil, err)
+				mockLogModule.On("Log", err, exhausted, mock.Anything)
+				mockPostModule.On("SaveLinkPreviews", mock.Anything, post.ID, []entity.LinkPreview{}).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.FetchLinkPreviewsRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "error when save link previews",
+			prepare: func() {
+				mockPostModule.On("FindPendingLinkPreviewPostList", mock.Anything, req.Now, req.Limit).
+					Return([]entity.Post{post}, nil)
+				mockLinkPreviewModule.On("FetchLinkPreview", mock.Anything, "https://a.com").
+					Return(preview, nil)
+				mockLinkPreviewModule.On("FetchLinkPreview", mock.Anything, "https://b.com").
+					Return(preview, nil)
+				mockPostModule.On("SaveLinkPreviews", mock.Anything, post.ID, mock.Anything).
+					Return(err)
+				mockLogModule.On("Log", err, post, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.FetchLinkPreviewsRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success skipping unavailable preview",
+			prepare: func() {
+				mockPostModule.On("FindPendingLinkPreviewPostList", mock.Anything, req.Now, req.Limit).
+					Return([]entity.Post{post}, nil)
+				mockLinkPreviewModule.On("FetchLinkPreview", mock.Anything, "https://a.com").
+					Return(preview, nil)
+				mockLinkPreviewModule.On("FetchLinkPreview", mock.Anything, "https://b.com").
+					Return(nil, constants.ErrLinkPreviewUnavailable)
+				mockPostModule.On("SaveLinkPreviews", mock.Anything, post.ID, []entity.LinkPreview{*preview}).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.FetchLinkPreviewsRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.FetchLinkPreviews(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+				mockPostModule.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func Test_service_SetHandle(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.SetHandleReq{
+			UserID: "user-id",
+			Handle: "handle",
+		}
+		recently = time.Now().Unix() - 3600
+		longAgo  = time.Now().Unix() - constants.HandleChangeCooldown - 3600
+	)
+	type args struct {
+		ctx context.Context
+		req api.SetHandleReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.SetHandleRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.SetHandleReq{UserID: "user-id", Handle: "admin"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "user not found",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(nil, constants.ErrUserNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "same handle",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID, Profile: entity.Profile{Handle: req.Handle}}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.SetHandleRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "changed too soon",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{
+						ID:      req.UserID,
+						Account: entity.Account{HandleChangedAt: recently},
+						Profile: entity.Profile{Handle: "old_handle"},
+					}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "taken by other user",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockUserModule.On("FindUserByHandle", mock.Anything, req.Handle).
+					Return(&entity.User{ID: "other-id"}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when check handle",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockUserModule.On("FindUserByHandle", mock.Anything, req.Handle).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "taken concurrently",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockUserModule.On("FindUserByHandle", mock.Anything, req.Handle).
+					Return(nil, constants.ErrUserNotFound)
+				mockUserModule.On("SaveHandle", mock.Anything, mock.Anything).
+					Return(constants.ErrHandleTaken)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success first handle",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+				mockUserModule.On("FindUserByHandle", mock.Anything, req.Handle).
+					Return(nil, constants.ErrUserNotFound)
+				mockUserModule.On("SaveHandle", mock.Anything, mock.MatchedBy(func(u entity.User) bool {
+					return u.Profile.Handle == req.Handle && u.Account.HandleChangedAt != 0
+				})).Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.SetHandleRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success change after cooldown",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{
+						ID:      req.UserID,
+						Account: entity.Account{HandleChangedAt: longAgo},
+						Profile: entity.Profile{Handle: "old_handle"},
+					}, nil)
+				mockUserModule.On("FindUserByHandle", mock.Anything, req.Handle).
+					Return(nil, constants.ErrUserNotFound)
+				mockUserModule.On("SaveHandle", mock.Anything, mock.MatchedBy(func(u entity.User) bool {
+					return u.Profile.Handle == req.Handle && u.Account.HandleChangedAt > longAgo
+				})).Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.SetHandleRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.SetHandle(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_CheckHandle(t *testing.T) {
+	var (
+		ctx = context.Background()
+		req = api.CheckHandleReq{
+			UserID: "user-id",
+			Handle: "handle",
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.CheckHandleReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.CheckHandleRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.CheckHandleReq{UserID: "user-id", Handle: "a"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "taken by other user",
+			prepare: func() {
+				mockUserModule.On("FindUserByHandle", mock.Anything, req.Handle).
+					Return(&entity.User{ID: "other-id"}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.CheckHandleRes{Available: false},
+			wantErr: false,
+		},
+		{
+			name:    "own handle",
+			prepare: func() {
+				mockUserModule.On("FindUserByHandle", mock.Anything, req.Handle).
+					Return(&entity.User{ID: req.UserID}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.CheckHandleRes{Available: true},
+			wantErr: false,
+		},
+		{
+			name:    "available",
+			prepare: func() {
+				mockUserModule.On("FindUserByHandle", mock.Anything, req.Handle).
+					Return(nil, constants.ErrUserNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.CheckHandleRes{Available: true},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.CheckHandle(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_GetUserByHandle(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.GetUserByHandleReq{
+			Handle: "handle",
+			UserID: "user-id",
+		}
+		user = &entity.User{ID: "other-id", Profile: entity.Profile{Handle: "handle"}}
+	)
+	type args struct {
+		ctx context.Context
+		req api.GetUserByHandleReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.GetUserByHandleRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.GetUserByHandleReq{UserID: "user-id"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "user not found",
+			prepare: func() {
+				mockUserModule.On("FindUserByHandle", mock.Anything, req.Handle).
+					Return(nil, constants.ErrUserNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when get followed user",
+			prepare: func() {
+				mockUserModule.On("FindUserByHandle", mock.Anything, req.Handle).
+					Return(user, nil)
+				mockUserModule.On("GetFollowedUserID", mock.Anything, req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockUserModule.On("FindUserByHandle", mock.Anything, req.Handle).
+					Return(user, nil)
+				mockUserModule.On("GetFollowedUserID", mock.Anything, req.UserID).
+					Return([]string{"other-id"}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.GetUserByHandleReq{Handle: "@Handle", UserID: req.UserID},
+			},
+			want:    &api.GetUserByHandleRes{User: *user, IsFollowed: true},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.GetUserByHandle(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_GetUserProfile(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.GetUserProfileReq{
+			ID:     "other-id",
+			UserID: "user-id",
+		}
+		user = &entity.User{ID: "other-id"}
+	)
+	type args struct {
+		ctx context.Context
+		req api.GetUserProfileReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.GetUserProfileRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.GetUserProfileReq{UserID: "user-id"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "user not found",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.ID).
+					Return(nil, constants.ErrUserNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when count followers",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.ID).
+					Return(user, nil)
+				mockUserModule.On("CountFollowers", mock.Anything, req.ID).
+					Return(0, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when count post",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.ID).
+					Return(user, nil)
+				mockUserModule.On("CountFollowers", mock.Anything, req.ID).
+					Return(3, nil)
+				mockUserModule.On("CountFollowing", mock.Anything, req.ID).
+					Return(2, nil)
+				mockPostModule.On("CountPostByAuthorID", mock.Anything, req.ID, req.UserID).
+					Return(0, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when get follow status",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.ID).
+					Return(user, nil)
+				mockUserModule.On("CountFollowers", mock.Anything, req.ID).
+					Return(3, nil)
+				mockUserModule.On("CountFollowing", mock.Anything, req.ID).
+					Return(2, nil)
+				mockPostModule.On("CountPostByAuthorID", mock.Anything, req.ID, req.UserID).
+					Return(5, nil)
+				mockUserModule.On("IsFollowing", mock.Anything, req.UserID, req.ID).
+					Return(false, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.ID).
+					Return(user, nil)
+				mockUserModule.On("CountFollowers", mock.Anything, req.ID).
+					Return(3, nil)
+				mockUserModule.On("CountFollowing", mock.Anything, req.ID).
+					Return(2, nil)
+				mockPostModule.On("CountPostByAuthorID", mock.Anything, req.ID, req.UserID).
+					Return(5, nil)
+				mockUserModule.On("IsFollowing", mock.Anything, req.UserID, req.ID).
+					Return(false, nil)
+				mockUserModule.On("IsFollowing", mock.Anything, req.ID, req.UserID).
+					Return(true, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.GetUserProfileRes{
+				User:           *user,
+				FollowersCount: 3,
+				FollowingCount: 2,
+				PostsCount:     5,
+				IsFollowed:     false,
+				FollowsYou:     true,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "success own profile",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.ID).
+					Return(user, nil)
+				mockUserModule.On("CountFollowers", mock.Anything, req.ID).
+					Return(3, nil)
+				mockUserModule.On("CountFollowing", mock.Anything, req.ID).
+					Return(2, nil)
+				mockPostModule.On("CountPostByAuthorID", mock.Anything, req.ID, req.ID).
+					Return(5, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.GetUserProfileReq{ID: req.ID, UserID: req.ID},
+			},
+			want:    &api.GetUserProfileRes{
+				User:           *user,
+				FollowersCount: 3,
+				FollowingCount: 2,
+				PostsCount:     5,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.GetUserProfile(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_GetFollowerList(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.GetFollowerListReq{
+			ID:         "other-id",
+			UserID:     "user-id",
+			Pagination: api.PaginationReq{First: 2},
+		}
+		pagination = &api.PaginationRes{EndCursor: "follow-id", HasNextPage: true}
+		userList = []entity.User{{ID: "user-1"}, {ID: "user-2"}}
+	)
+	type args struct {
+		ctx context.Context
+		req api.GetFollowerListReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.GetFollowerListRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.GetFollowerListReq{UserID: "user-id"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when get follower id",
+			prepare: func() {
+				mockUserModule.On("FindFollowerIDs", mock.Anything, req.ID, req.Pagination).
+					Return(nil, nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			prepare: func() {
+				mockUserModule.On("FindFollowerIDs", mock.Anything, req.ID, req.Pagination).
+					Return([]string{}, &api.PaginationRes{}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.GetFollowerListRes{Pagination: api.PaginationRes{}},
+			wantErr: false,
+		},
+		{
+			name:    "error when get user list",
+			prepare: func() {
+				mockUserModule.On("FindFollowerIDs", mock.Anything, req.ID, req.Pagination).
+					Return([]string{"user-1", "user-2"}, pagination, nil)
+				mockUserModule.On("FindUserListByIDs", mock.Anything, []string{"user-1", "user-2"}).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockUserModule.On("FindFollowerIDs", mock.Anything, req.ID, req.Pagination).
+					Return([]string{"user-1", "user-2"}, pagination, nil)
+				mockUserModule.On("FindUserListByIDs", mock.Anything, []string{"user-1", "user-2"}).
+					Return(userList, nil)
+				mockUserModule.On("FindFollowedUserIDsIn", mock.Anything, req.UserID, []string{"user-1", "user-2"}).
+					Return([]string{"user-2"}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.GetFollowerListRes{
+				UserList:    userList,
+				FollowedIDs: []string{"user-2"},
+				Pagination:  *pagination,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.GetFollowerList(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_GetFollowingList(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.GetFollowingListReq{
+			ID:         "other-id",
+			UserID:     "user-id",
+			Pagination: api.PaginationReq{First: 2},
+		}
+		pagination = &api.PaginationRes{EndCursor: "follow-id", HasNextPage: true}
+		userList = []entity.User{{ID: "user-1"}, {ID: "user-2"}}
+	)
+	type args struct {
+		ctx context.Context
+		req api.GetFollowingListReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.GetFollowingListRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.GetFollowingListReq{UserID: "user-id"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when get following id",
+			prepare: func() {
+				mockUserModule.On("FindFollowingIDs", mock.Anything, req.ID, req.Pagination).
+					Return(nil, nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			prepare: func() {
+				mockUserModule.On("FindFollowingIDs", mock.Anything, req.ID, req.Pagination).
+					Return([]string{}, &api.PaginationRes{}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.GetFollowingListRes{Pagination: api.PaginationRes{}},
+			wantErr: false,
+		},
+		{
+			name:    "error when get user list",
+			prepare: func() {
+				mockUserModule.On("FindFollowingIDs", mock.Anything, req.ID, req.Pagination).
+					Return([]string{"user-1", "user-2"}, pagination, nil)
+				mockUserModule.On("FindUserListByIDs", mock.Anything, []string{"user-1", "user-2"}).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockUserModule.On("FindFollowingIDs", mock.Anything, req.ID, req.Pagination).
+					Return([]string{"user-1", "user-2"}, pagination, nil)
+				mockUserModule.On("FindUserListByIDs", mock.Anything, []string{"user-1", "user-2"}).
+					Return(userList, nil)
+				mockUserModule.On("FindFollowedUserIDsIn", mock.Anything, req.UserID, []string{"user-1", "user-2"}).
+					Return([]string{"user-2"}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.GetFollowingListRes{
+				UserList:    userList,
+				FollowedIDs: []string{"user-2"},
+				Pagination:  *pagination,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.GetFollowingList(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_Follow(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.FollowReq{
+			UserID:     "user-id",
+			FollowedID: "followed-id",
+		}
+		user = &entity.User{ID: "user-id", Profile: entity.Profile{Name: "name"}}
+	)
+	type args struct {
+		ctx context.Context
+		req api.FollowReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.FollowRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.FollowReq{UserID: "user-id"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "follow self",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.FollowReq{UserID: "user-id", FollowedID: "user-id"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "rate limited ip",
+			prepare: func() {
+				mockRateLimitModule.On("Hit", mock.Anything, "follow:user:"+req.UserID, mock.Anything).
+					Return(1, time.Now().Unix()+300, nil)
+				mockRateLimitModule.On("Hit", mock.Anything, "follow:ip:10.1.2.3/32", constants.RateLimitFollowIP.Window).
+					Return(constants.RateLimitFollowIP.Limit+1, time.Now().Unix()+300, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.FollowReq{UserID: req.UserID, FollowedID: req.FollowedID, ClientIP: "10.1.2.3"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "followed user not found",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.FollowedID).
+					Return(nil, constants.ErrUserNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "blocked by followed user",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.FollowedID).
+					Return(&entity.User{ID: req.FollowedID}, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, req.FollowedID, req.UserID).
+					Return(true, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "already followed or followed by concurrent request",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.FollowedID).
+					Return(&entity.User{ID: req.FollowedID}, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, req.FollowedID, req.UserID).
+					Return(false, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(user, nil)
+				mockUserModule.On("UpdateFollowStatus", mock.Anything, req.UserID, req.FollowedID, constants.FollowStatusActive).
+					Return(false, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.FollowRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success even if failed send notification",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.FollowedID).
+					Return(&entity.User{ID: req.FollowedID}, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, req.FollowedID, req.UserID).
+					Return(false, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(user, nil)
+				mockUserModule.On("UpdateFollowStatus", mock.Anything, req.UserID, req.FollowedID, constants.FollowStatusActive).
+					Return(true, nil)
+				mockNotificationModule.On("SendFollowNotification", mock.Anything, *user, req.FollowedID).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.FollowRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.FollowedID).
+					Return(&entity.User{ID: req.FollowedID}, nil)
+				mockUserModule.On("IsBlocked", mock.Anything, req.FollowedID, req.UserID).
+					Return(false, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(user, nil)
+				mockUserModule.On("UpdateFollowStatus", mock.Anything, req.UserID, req.FollowedID, constants.FollowStatusActive).
+					Return(true, nil)
+				mockNotificationModule.On("SendFollowNotification", mock.Anything, *user, req.FollowedID).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.FollowRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			allowRateLimit()
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.Follow(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_Unfollow(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.UnfollowReq{
+			UserID:     "user-id",
+			FollowedID: "followed-id",
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.UnfollowReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.UnfollowRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid request",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.UnfollowReq{UserID: "user-id"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when update follow status",
+			prepare: func() {
+				mockUserModule.On("UpdateFollowStatus", mock.Anything, req.UserID, req.FollowedID, constants.FollowStatusInactive).
+					Return(false, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockUserModule.On("UpdateFollowStatus", mock.Anything, req.UserID, req.FollowedID, constants.FollowStatusInactive).
+					Return(true, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.UnfollowRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.Unfollow(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_Block(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.BlockReq{
+			UserID:    "user-id",
+			BlockedID: "blocked-id",
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.BlockReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.BlockRes
+		wantErr bool
+	}{
+		{
+			name:    "block self",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.BlockReq{UserID: "user-id", BlockedID: "user-id"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "blocked user not found",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.BlockedID).
+					Return(nil, constants.ErrUserNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when update block status",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.BlockedID).
+					Return(&entity.User{ID: req.BlockedID}, nil)
+				mockUserModule.On("UpdateBlockStatus", mock.Anything, req.UserID, req.BlockedID, constants.BlockStatusActive).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success unfollow both ways",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.BlockedID).
+					Return(&entity.User{ID: req.BlockedID}, nil)
+				mockUserModule.On("UpdateBlockStatus", mock.Anything, req.UserID, req.BlockedID, constants.BlockStatusActive).
+					Return(nil)
+				mockUserModule.On("UpdateFollowStatus", mock.Anything, req.UserID, req.BlockedID, constants.FollowStatusInactive).
+					Return(true, nil).Once()
+				mockUserModule.On("UpdateFollowStatus", mock.Anything, req.BlockedID, req.UserID, constants.FollowStatusInactive).
+					Return(true, nil).Once()
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.BlockRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.Block(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+				mockUserModule.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func Test_service_Mute(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.MuteReq{
+			UserID:  "user-id",
+			MutedID: "muted-id",
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.MuteReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.MuteRes
+		wantErr bool
+	}{
+		{
+			name:    "mute self",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.MuteReq{UserID: "user-id", MutedID: "user-id"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "muted user not found",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.MutedID).
+					Return(nil, constants.ErrUserNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when update mute status",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.MutedID).
+					Return(&entity.User{ID: req.MutedID}, nil)
+				mockUserModule.On("UpdateMuteStatus", mock.Anything, req.UserID, req.MutedID, constants.MuteStatusActive).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.MutedID).
+					Return(&entity.User{ID: req.MutedID}, nil)
+				mockUserModule.On("UpdateMuteStatus", mock.Anything, req.UserID, req.MutedID, constants.MuteStatusActive).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.MuteRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.Mute(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_MuteWord(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.MuteWordReq{
+			UserID: "user-id",
+			Word:   "endgame",
+		}
+		fullList = make([]entity.MutedWord, constants.MutedWordMaxCount)
+	)
+	for i := range fullList {
+		fullList[i] = entity.MutedWord{Word: fmt.Sprintf("word %d", i)}
+	}
+	fullList[0].Word = "EndGame"
+	type args struct {
+		ctx context.Context
+		req api.MuteWordReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.MuteWordRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid word",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.MuteWordReq{UserID: "user-id", Word: " !!! "},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when get muted word list",
+			prepare: func() {
+				mockUserModule.On("FindMutedWordList", mock.Anything, req.UserID).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "too many muted words",
+			prepare: func() {
+				mockUserModule.On("FindMutedWordList", mock.Anything, req.UserID).
+					Return(fullList, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.MuteWordReq{UserID: "user-id", Word: "spoiler"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "mute existing word again when full",
+			prepare: func() {
+				mockUserModule.On("FindMutedWordList", mock.Anything, req.UserID).
+					Return(fullList, nil)
+				mockUserModule.On("SaveMutedWord", mock.Anything, req.UserID, entity.MutedWord{Word: req.Word}).
+					Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.MuteWordRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "success with expiry",
+			prepare: func() {
+				mockUserModule.On("FindMutedWordList", mock.Anything, req.UserID).
+					Return([]entity.MutedWord{}, nil)
+				mockUserModule.On("SaveMutedWord", mock.Anything, req.UserID, mock.MatchedBy(func(w entity.MutedWord) bool {
+					return w.Word == req.Word && w.ExpiredAt > time.Now().Unix()
+				})).Return(nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.MuteWordReq{UserID: "user-id", Word: "  endgame ", ExpiresIn: 3600},
+			},
+			want:    &api.MuteWordRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.MuteWord(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_service_ReportPost(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.ReportPostReq{
+			UserID: "user-id",
+			PostID: "post-id",
+			Reason: constants.ReportReasonSpam,
+		}
+		post = &entity.Post{ID: "post-id", User: entity.User{ID: "poster-id"}}
+		report = entity.Report{
+			PostID:         "post-id",
+			ReportedUserID: "poster-id",
+			ReporterID:     "user-id",
+			Reason:         constants.ReportReasonSpam,
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.ReportPostReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.ReportPostRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid reason",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: api.ReportPostReq{UserID: "user-id", PostID: "post-id", Reason: "boring"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "post not found",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(nil, constants.ErrPostNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "report own post",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(&entity.Post{ID: "post-id", User: entity.User{ID: req.UserID}}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when insert report",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(post, nil)
+				mockReportModule.On("InsertReport", mock.Anything, report).
+					Return("", err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "already reported",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(post, nil)
+				mockReportModule.On("InsertReport", mock.Anything, report).
+					Return("", constants.ErrAlreadyReported)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.ReportPostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "below threshold",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(post, nil)
+				mockReportModule.On("InsertReport", mock.Anything, report).
+					Return("report-id", nil)
+				mockReportModule.On("CountPendingReportByPostID", mock.Anything, req.PostID).
+					Return(constants.ReportHideThresholdDefault-1, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.ReportPostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "hide post when reach threshold",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(post, nil)
+				mockReportModule.On("InsertReport", mock.Anything, report).
+					Return("report-id", nil)
+				mockReportModule.On("CountPendingReportByPostID", mock.Anything, req.PostID).
+					Return(constants.ReportHideThresholdDefault, nil)
+				mockPostModule.On("UpdateHiddenStatus", mock.Anything, req.PostID, true).
+					Return(nil).Once()
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.ReportPostRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "error when hide post still success",
+			prepare: func() {
+				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+					Return(post, nil)
+				mockReportModule.On("InsertReport", mock.Anything, report).
+					Return("report-id", nil)
+				mockReportModule.On("CountPendingReportByPostID", mock.Anything, req.PostID).
+					Return(constants.ReportHideThresholdDefault+1, nil)
+				mockPostModule.On("UpdateHiddenStatus", mock.Anything, req.PostID, true).
+					Return(err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.ReportPostRes{Message: "success"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.ReportPost(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			mockPostModule.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_service_ReportPost_customThreshold(t *testing.T) {
+	initTest()
+	req := api.ReportPostReq{UserID: "user-id", PostID: "post-id", Reason: constants.ReportReasonOther}
+	mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
+		Return(&entity.Post{ID: "post-id", User: entity.User{ID: "poster-id"}}, nil)
+	mockReportModule.On("InsertReport", mock.Anything, mock.Anything).
+		Return("report-id", nil)
+	mockReportModule.On("CountPendingReportByPostID", mock.Anything, req.PostID).
+		Return(2, nil)
+	mockPostModule.On("UpdateHiddenStatus", mock.Anything, req.PostID, true).
+		Return(nil).Once()
+
+	s := NewService(adapter, Config{ReportHideThreshold: 2})
+	got, err := s.ReportPost(context.Background(), req)
+	assert.Nil(t, err)
+	assert.Equal(t, &api.ReportPostRes{Message: "success"}, got)
+	mockPostModule.AssertExpectations(t)
+}
+
+func Test_service_GetModerationQueue(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		req = api.GetModerationQueueReq{
+			UserID:     "moderator-id",
+			Pagination: api.PaginationReq{First: 20},
+		}
+		reports = []entity.Report{
+			{ID: "report-1", PostID: "post-1"},
+			{ID: "report-2", PostID: "post-2"},
+			{ID: "report-3", PostID: "post-1"},
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.GetModerationQueueReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.GetModerationQueueRes
+		wantErr bool
+	}{
+		{
+			name:    "not moderator",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when get report list",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID, Account: entity.Account{Role: constants.RoleModerator}}, nil)
+				mockReportModule.On("FindPendingReportList", mock.Anything, req.Pagination).
+					Return(nil, nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when count report",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID, Account: entity.Account{Role: constants.RoleModerator}}, nil)
+				mockReportModule.On("FindPendingReportList", mock.Anything, req.Pagination).
+					Return([]entity.Report{reports[0]}, &api.PaginationRes{EndCursor: "report-1"}, nil)
+				mockReportModule.On("CountPendingReportByPostIDs", mock.Anything, []string{"post-1"}).
+					Return(nil, err)
+				mockLogModule.On("Log", err, req, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID, Account: entity.Account{Role: constants.RoleAdmin}}, nil)
+				mockReportModule.On("FindPendingReportList", mock.Anything, req.Pagination).
+					Return([]entity.Report{reports[0], reports[1], reports[2]}, &api.PaginationRes{EndCursor: "report-3"}, nil)
+				mockReportModule.On("CountPendingReportByPostIDs", mock.Anything, []string{"post-1", "post-2"}).
+					Return(map[string]int{"post-1": 2, "post-2": 1}, nil).Once()
+				// post-2 already deleted
+				mockPostModule.On("FindPostListByIDsIncludeHidden", mock.Anything, []string{"post-1", "post-2"}).
+					Return([]entity.Post{{ID: "post-1", IsHidden: true}}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: req,
+			},
+			want:    &api.GetModerationQueueRes{
+				ReportList: []entity.Report{
+					{ID: "report-1", PostID: "post-1", Post: &entity.Post{ID: "post-1", IsHidden: true}},
+					{ID: "report-2", PostID: "post-2"},
+					{ID: "report-3", PostID: "post-1", Post: &entity.Post{ID: "post-1", IsHidden: true}},
+				},
+				ReportsCount: map[string]int{"post-1": 2, "post-2": 1},
+				Pagination:   api.PaginationRes{EndCursor: "report-3"},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -940,7 +7075,7 @@ func Test_service_CreatePost(t *testing.T)  {
 			s := &service{
 				adapter: adapter,
 			}
-			got, err := s.CreatePost(tt.args.ctx, tt.args.req)
+			got, err := s.GetModerationQueue(tt.args.ctx, tt.args.req)
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Nil(t, got)
@@ -952,262 +7087,729 @@ func Test_service_CreatePost(t *testing.T)  {
 	}
 }
 
-func Test_service_LikePost(t *testing.T)  {
+func Test_service_ResolveReport(t *testing.T) {
 	var (
 		ctx = context.Background()
 		err = errors.New("some error")
-		req = api.LikePostReq{
-			PostID: "post-id",
-			UserID: "user-id",
+		moderator = &entity.User{ID: "moderator-id", Account: entity.Account{Role: constants.RoleModerator}}
+		report = &entity.Report{
+			ID:             "report-id",
+			PostID:         "post-id",
+			ReportedUserID: "poster-id",
+			Reason:         constants.ReportReasonHarassment,
+			Status:         constants.ReportStatusPending,
 		}
 		post = entity.Post{
-			ID:      "post-id",
-			IsLiked: false,
-			User:    entity.User{ID: "user-post-id"},
+			ID:       "post-id",
+			Body:     "body",
+			User:     entity.User{ID: "poster-id"},
+			Author:   entity.User{ID: "poster-id"},
+			IsHidden: true,
 		}
-		user = entity.User{
-			ID:      "id",
-			Profile: entity.Profile{
-				Name: "John",
+		poster = &entity.User{ID: "poster-id", Account: entity.Account{BanReason: "old"}}
+		newReq = func(action string) api.ResolveReportReq {
+			return api.ResolveReportReq{
+				UserID:   "moderator-id",
+				ReportID: "report-id",
+				Action:   action,
+			}
+		}
+	)
+	type args struct {
+		ctx context.Context
+		req api.ResolveReportReq
+	}
+	tests := []struct {
+		name    string
+		prepare func()
+		args    args
+		want    *api.ResolveReportRes
+		wantErr bool
+	}{
+		{
+			name:    "invalid action",
+			prepare: nil,
+			args:    args{
+				ctx: ctx,
+				req: newReq("ban_forever"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "not moderator",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, "moderator-id").
+					Return(&entity.User{ID: "moderator-id"}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: newReq(constants.ReportActionDismiss),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "report not found",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, "moderator-id").
+					Return(moderator, nil)
+				mockReportModule.On("FindReportByID", mock.Anything, "report-id").
+					Return(nil, constants.ErrReportNotFound)
+			},
+			args:    args{
+				ctx: ctx,
+				req: newReq(constants.ReportActionDismiss),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "already resolved",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, "moderator-id").
+					Return(moderator, nil)
+				mockReportModule.On("FindReportByID", mock.Anything, "report-id").
+					Return(&entity.Report{ID: "report-id", Status: constants.ReportStatusResolved}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: newReq(constants.ReportActionDismiss),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when get post",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, "moderator-id").
+					Return(moderator, nil)
+				mockReportModule.On("FindReportByID", mock.Anything, "report-id").
+					Return(report, nil)
+				mockPostModule.On("FindPostListByIDsIncludeHidden", mock.Anything, []string{"post-id"}).
+					Return(nil, err)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: newReq(constants.ReportActionHidePost),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "suspend admin",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, "moderator-id").
+					Return(moderator, nil)
+				mockReportModule.On("FindReportByID", mock.Anything, "report-id").
+					Return(report, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, "poster-id").
+					Return(&entity.User{ID: "poster-id", Account: entity.Account{Role: constants.RoleAdmin}}, nil)
+			},
+			args:    args{
+				ctx: ctx,
+				req: newReq(constants.ReportActionSuspendUser),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "dismiss unhide post",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, "moderator-id").
+					Return(moderator, nil)
+				mockReportModule.On("FindReportByID", mock.Anything, "report-id").
+					Return(report, nil)
+				mockPostModule.On("FindPostListByIDsIncludeHidden", mock.Anything, []string{"post-id"}).
+					Return([]entity.Post{post}, nil)
+				mockPostModule.On("UpdateHiddenStatus", mock.Anything, "post-id", false).
+					Return(nil).Once()
+				mockAuditModule.On("InsertAuditLog", mock.Anything, entity.AuditLog{
+					ActorID:    "moderator-id",
+					Action:     constants.AuditActionDismissReport,
+					TargetType: constants.AuditTargetPost,
+					TargetID:   "post-id",
+					Before:     postSnapshot(post),
+					After:      changeSnapshot(postSnapshot(post), "hidden", false),
+					Reason:     "not harassment",
+				}).Return(nil).Once()
+				mockReportModule.On("ResolveReportsByPostID", mock.Anything, "post-id", constants.ReportActionDismiss, "moderator-id").
+					Return(true, nil).Once()
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.ResolveReportReq{
+					UserID:   "moderator-id",
+					ReportID: "report-id",
+					Action:   constants.ReportActionDismiss,
+					Reason:   "not harassment",
+				},
+			},
+			want:    &api.ResolveReportRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "delete already deleted post",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, "moderator-id").
+					Return(moderator, nil)
+				mockReportModule.On("FindReportByID", mock.Anything, "report-id").
+					Return(report, nil)
+				mockPostModule.On("FindPostListByIDsIncludeHidden", mock.Anything, []string{"post-id"}).
+					Return([]entity.Post{}, nil)
+				mockPostModule.On("DeletePostWithReplies", mock.Anything, "post-id").
+					Return(nil).Once()
+				mockAuditModule.On("InsertAuditLog", mock.Anything, entity.AuditLog{
+					ActorID:    "moderator-id",
+					Action:     constants.AuditActionDeletePost,
+					TargetType: constants.AuditTargetPost,
+					TargetID:   "post-id",
+					Reason:     constants.ReportReasonHarassment,
+				}).Return(nil).Once()
+				mockReportModule.On("ResolveReportsByPostID", mock.Anything, "post-id", constants.ReportActionDeletePost, "moderator-id").
+					Return(true, nil).Once()
+			},
+			args:    args{
+				ctx: ctx,
+				req: newReq(constants.ReportActionDeletePost),
+			},
+			want:    &api.ResolveReportRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "warn user, error when send notification still success",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, "moderator-id").
+					Return(moderator, nil)
+				mockReportModule.On("FindReportByID", mock.Anything, "report-id").
+					Return(report, nil)
+				mockPostModule.On("FindPostListByIDsIncludeHidden", mock.Anything, []string{"post-id"}).
+					Return([]entity.Post{post}, nil)
+				mockPostModule.On("UpdateHiddenStatus", mock.Anything, "post-id", true).
+					Return(nil).Once()
+				mockAuditModule.On("InsertAuditLog", mock.Anything, entity.AuditLog{
+					ActorID:    "moderator-id",
+					Action:     constants.AuditActionHidePost,
+					TargetType: constants.AuditTargetPost,
+					TargetID:   "post-id",
+					Before:     postSnapshot(post),
+					After:      postSnapshot(post),
+					Reason:     "threats",
+				}).Return(nil).Once()
+				mockNotificationModule.On("SendWarningNotification", mock.Anything, "poster-id", "threats").
+					Return(err).Once()
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+				mockAuditModule.On("InsertAuditLog", mock.Anything, entity.AuditLog{
+					ActorID:    "moderator-id",
+					Action:     constants.AuditActionWarnUser,
+					TargetType: constants.AuditTargetUser,
+					TargetID:   "poster-id",
+					Reason:     "threats",
+				}).Return(nil).Once()
+				mockReportModule.On("ResolveReportsByPostID", mock.Anything, "post-id", constants.ReportActionWarnUser, "moderator-id").
+					Return(true, nil).Once()
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.ResolveReportReq{
+					UserID:   "moderator-id",
+					ReportID: "report-id",
+					Action:   constants.ReportActionWarnUser,
+					Reason:   "threats",
+				},
+			},
+			want:    &api.ResolveReportRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "suspend user with default duration",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, "moderator-id").
+					Return(moderator, nil)
+				mockReportModule.On("FindReportByID", mock.Anything, "report-id").
+					Return(report, nil)
+				mockPostModule.On("FindPostListByIDsIncludeHidden", mock.Anything, []string{"post-id"}).
+					Return([]entity.Post{post}, nil)
+				mockPostModule.On("UpdateHiddenStatus", mock.Anything, "post-id", true).
+					Return(nil).Once()
+				mockAuditModule.On("InsertAuditLog", mock.Anything, entity.AuditLog{
+					ActorID:    "moderator-id",
+					Action:     constants.AuditActionHidePost,
+					TargetType: constants.AuditTargetPost,
+					TargetID:   "post-id",
+					Before:     postSnapshot(post),
+					After:      postSnapshot(post),
+					Reason:     constants.ReportReasonHarassment,
+				}).Return(nil).Once()
+				mockUserModule.On("FindUserByID", mock.Anything, "poster-id").
+					Return(poster, nil)
+				mockUserModule.On("SaveSuspension", mock.Anything, mock.MatchedBy(func(u entity.User) bool {
+					until := time.Now().Unix() + constants.SuspendDurationDefault
+					return u.ID == "poster-id" &&
+						u.Account.SuspensionReason == constants.ReportReasonHarassment &&
+						u.Account.SuspendedUntil > until-60 && u.Account.SuspendedUntil <= until
+				})).Return(nil).Once()
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.MatchedBy(func(l entity.AuditLog) bool {
+					return l.Action == constants.AuditActionSuspendUser &&
+						l.ActorID == "moderator-id" &&
+						l.TargetType == constants.AuditTargetUser &&
+						l.TargetID == "poster-id" &&
+						l.Reason == constants.ReportReasonHarassment &&
+						assert.ObjectsAreEqual(accountSnapshot(poster.Account), l.Before) &&
+						l.After["suspended_until"].(int64) > time.Now().Unix() &&
+						l.After["ban_reason"] == "old"
+				})).Return(nil).Once()
+				mockReportModule.On("ResolveReportsByPostID", mock.Anything, "post-id", constants.ReportActionSuspendUser, "moderator-id").
+					Return(true, nil).Once()
+			},
+			args:    args{
+				ctx: ctx,
+				req: newReq(constants.ReportActionSuspendUser),
+			},
+			want:    &api.ResolveReportRes{Message: "success"},
+			wantErr: false,
+		},
+		{
+			name:    "error when resolve report",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, "moderator-id").
+					Return(moderator, nil)
+				mockReportModule.On("FindReportByID", mock.Anything, "report-id").
+					Return(report, nil)
+				mockPostModule.On("FindPostListByIDsIncludeHidden", mock.Anything, []string{"post-id"}).
+					Return([]entity.Post{post}, nil)
+				mockReportModule.On("ResolveReportsByPostID", mock.Anything, "post-id", constants.ReportActionHidePost, "moderator-id").
+					Return(false, err)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+			},
+			args:    args{
+				ctx: ctx,
+				req: newReq(constants.ReportActionHidePost),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "resolved concurrently by other moderator",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, "moderator-id").
+					Return(moderator, nil)
+				mockReportModule.On("FindReportByID", mock.Anything, "report-id").
+					Return(report, nil)
+				mockPostModule.On("FindPostListByIDsIncludeHidden", mock.Anything, []string{"post-id"}).
+					Return([]entity.Post{post}, nil).Once()
+				mockReportModule.On("ResolveReportsByPostID", mock.Anything, "post-id", constants.ReportActionDeletePost, "moderator-id").
+					Return(false, nil).Once()
+			},
+			args:    args{
+				ctx: ctx,
+				req: newReq(constants.ReportActionDeletePost),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when suspend user record the failure",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, "moderator-id").
+					Return(moderator, nil)
+				mockReportModule.On("FindReportByID", mock.Anything, "report-id").
+					Return(report, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, "poster-id").
+					Return(poster, nil)
+				mockPostModule.On("FindPostListByIDsIncludeHidden", mock.Anything, []string{"post-id"}).
+					Return([]entity.Post{post}, nil).Once()
+				mockReportModule.On("ResolveReportsByPostID", mock.Anything, "post-id", constants.ReportActionSuspendUser, "moderator-id").
+					Return(true, nil).Once()
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.MatchedBy(func(l entity.AuditLog) bool {
+					return l.Action == constants.AuditActionHidePost && l.Reason == "threats"
+				})).Return(nil).Once()
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.MatchedBy(func(l entity.AuditLog) bool {
+					return l.Action == constants.AuditActionSuspendUser && l.Reason == "threats" &&
+						l.After["suspension_reason"] == "threats"
+				})).Return(nil).Once()
+				mockPostModule.On("UpdateHiddenStatus", mock.Anything, "post-id", true).
+					Return(nil).Once()
+				mockUserModule.On("SaveSuspension", mock.Anything, mock.MatchedBy(func(u entity.User) bool {
+					return u.ID == "poster-id" && u.Account.SuspensionReason == "threats"
+				})).Return(err).Once()
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+				// the post is hidden, only the suspension is recorded as failed
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.MatchedBy(func(l entity.AuditLog) bool {
+					return l.Action == constants.AuditActionFailed &&
+						l.Reason == constants.AuditActionSuspendUser &&
+						l.TargetID == "poster-id" &&
+						l.Before["suspension_reason"] == "threats"
+				})).Return(nil).Once()
+				mockReportModule.On("ReopenReportsByPostID", mock.Anything, "post-id", constants.ReportActionSuspendUser, "moderator-id").
+					Return(nil).Once()
+			},
+			args:    args{
+				ctx: ctx,
+				req: api.ResolveReportReq{
+					UserID:   "moderator-id",
+					ReportID: "report-id",
+					Action:   constants.ReportActionSuspendUser,
+					Reason:   "threats",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "error when insert audit log reopen the reports",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, "moderator-id").
+					Return(moderator, nil)
+				mockReportModule.On("FindReportByID", mock.Anything, "report-id").
+					Return(report, nil)
+				mockPostModule.On("FindPostListByIDsIncludeHidden", mock.Anything, []string{"post-id"}).
+					Return([]entity.Post{post}, nil)
+				mockReportModule.On("ResolveReportsByPostID", mock.Anything, "post-id", constants.ReportActionDeletePost, "moderator-id").
+					Return(true, nil).Once()
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.Anything).
+					Return(err).Once()
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+				mockReportModule.On("ReopenReportsByPostID", mock.Anything, "post-id", constants.ReportActionDeletePost, "moderator-id").
+					Return(nil).Once()
+			},
+			args:    args{
+				ctx: ctx,
+				req: newReq(constants.ReportActionDeletePost),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.ResolveReport(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			mockPostModule.AssertExpectations(t)
+			mockReportModule.AssertExpectations(t)
+			mockAuditModule.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_service_CheckAccountStatus(t *testing.T) {
+	var (
+		ctx = context.Background()
+		req = api.CheckAccountStatusReq{UserID: "user-id"}
+	)
+	tests := []struct {
+		name    string
+		prepare func()
+		wantErr error
+	}{
+		{
+			name:    "user not found",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(nil, constants.ErrUserNotFound)
+			},
+			wantErr: constants.ErrUserNotFound,
+		},
+		{
+			name:    "banned",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID, Account: entity.Account{BannedAt: 1, BanReason: "spam"}}, nil)
+			},
+			wantErr: constants.ErrAccountBanned,
+		},
+		{
+			name:    "suspended",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID, Account: entity.Account{SuspendedUntil: time.Now().Unix() + 3600}}, nil)
+			},
+			wantErr: constants.ErrAccountSuspended,
+		},
+		{
+			name:    "suspension ended",
+			prepare: func() {
+				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
+					Return(&entity.User{ID: req.UserID, Account: entity.Account{SuspendedUntil: time.Now().Unix() - 1}}, nil)
 			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initTest()
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			s := &service{
+				adapter: adapter,
+			}
+			got, err := s.CheckAccountStatus(ctx, req)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error %v", err)
+				assert.Nil(t, got)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, &api.CheckAccountStatusRes{Message: "success"}, got)
+			}
+		})
+	}
+}
+
+func Test_service_ModerateUser(t *testing.T) {
+	var (
+		ctx = context.Background()
+		err = errors.New("some error")
+		admin = &entity.User{ID: "admin-id", Account: entity.Account{Role: constants.RoleAdmin}}
+		target = &entity.User{ID: "target-id"}
+		newReq = func(action string) api.ModerateUserReq {
+			return api.ModerateUserReq{
+				UserID:   "admin-id",
+				TargetID: "target-id",
+				Action:   action,
+				Reason:   "doxxing",
+			}
 		}
 	)
 	type args struct {
 		ctx context.Context
-		req api.LikePostReq
+		req api.ModerateUserReq
 	}
 	tests := []struct {
 		name    string
 		prepare func()
 		args    args
-		want    *api.LikePostRes
+		want    *api.ModerateUserRes
 		wantErr bool
 	}{
 		{
-			name:    "invalid request",
+			name:    "empty reason",
 			prepare: nil,
 			args:    args{
 				ctx: ctx,
-				req: api.LikePostReq{},
-			},
-			want:    nil,
-			wantErr: true,
-		},
-		{
-			name:    "error when get user",
-			prepare: func() {
-				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
-					Return(nil, err)
-				mockLogModule.On("Log", err, req, mock.Anything)
-			},
-			args:    args{
-				ctx: ctx,
-				req: req,
+				req: api.ModerateUserReq{UserID: "admin-id", TargetID: "target-id", Action: constants.ModerateActionBan},
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name:    "error user not found",
+			name:    "not admin",
 			prepare: func() {
-				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
-					Return(nil, constants.ErrUserNotFound)
+				mockUserModule.On("FindUserByID", mock.Anything, "admin-id").
+					Return(&entity.User{ID: "admin-id", Account: entity.Account{Role: constants.RoleModerator}}, nil)
 			},
 			args:    args{
 				ctx: ctx,
-				req: req,
+				req: newReq(constants.ModerateActionBan),
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name:    "error when get post",
+			name:    "target is admin",
 			prepare: func() {
-				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
-					Return(&entity.User{}, nil)
-				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
-					Return(nil, err)
-				mockLogModule.On("Log", err, req, mock.Anything)
+				mockUserModule.On("FindUserByID", mock.Anything, "admin-id").
+					Return(admin, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, "target-id").
+					Return(&entity.User{ID: "target-id", Account: entity.Account{Role: constants.RoleAdmin}}, nil)
 			},
 			args:    args{
 				ctx: ctx,
-				req: req,
+				req: newReq(constants.ModerateActionBan),
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name:    "error post not found",
+			name:    "suspend",
 			prepare: func() {
-				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
-					Return(&entity.User{}, nil)
-				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
-					Return(nil, constants.ErrPostNotFound)
+				mockUserModule.On("FindUserByID", mock.Anything, "admin-id").
+					Return(admin, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, "target-id").
+					Return(target, nil)
+				mockUserModule.On("SaveSuspension", mock.Anything, mock.MatchedBy(func(u entity.User) bool {
+					return u.ID == "target-id" && u.Account.SuspensionReason == "doxxing" &&
+						u.Account.SuspendedUntil > time.Now().Unix()+constants.SuspendDurationDefault-60
+				})).Return(nil).Once()
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.MatchedBy(func(l entity.AuditLog) bool {
+					return l.Action == constants.AuditActionSuspendUser &&
+						l.ActorID == "admin-id" &&
+						l.TargetType == constants.AuditTargetUser &&
+						l.TargetID == "target-id" &&
+						l.Reason == "doxxing" &&
+						l.Before["suspended_until"] == int64(0) &&
+						l.After["suspended_until"].(int64) > time.Now().Unix() &&
+						l.After["suspension_reason"] == "doxxing"
+				})).Return(nil).Once()
 			},
 			args:    args{
 				ctx: ctx,
-				req: req,
+				req: newReq(constants.ModerateActionSuspend),
 			},
-			want:    nil,
-			wantErr: true,
+			want:    &api.ModerateUserRes{Message: "success"},
+			wantErr: false,
 		},
 		{
-			name:    "error when like post",
+			name:    "ban with devices",
 			prepare: func() {
-				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
-					Return(&entity.User{}, nil)
-				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
-					Return(&post, nil)
-				mockPostModule.On("LikePost", mock.Anything, req.PostID, req.UserID).
-					Return(err)
-				mockLogModule.On("Log", err, req, mock.Anything)
+				mockUserModule.On("FindUserByID", mock.Anything, "admin-id").
+					Return(admin, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, "target-id").
+					Return(target, nil)
+				mockUserModule.On("SaveBan", mock.Anything, mock.MatchedBy(func(u entity.User) bool {
+					return u.ID == "target-id" && u.Account.BanReason == "doxxing" && u.Account.BannedAt > 0
+				})).Return(nil).Once()
+				mockNotificationModule.On("FindPushTokenList", mock.Anything, "target-id").
+					Return([]string{"token-1", "token-2"}, nil)
+				mockUserModule.On("BanDevices", mock.Anything, "target-id", []string{"token-1", "token-2"}, "doxxing").
+					Return(nil).Once()
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.MatchedBy(func(l entity.AuditLog) bool {
+					return l.Action == constants.AuditActionBanUser
+				})).Return(nil).Once()
 			},
 			args:    args{
 				ctx: ctx,
-				req: req,
+				req: newReq(constants.ModerateActionBan),
 			},
-			want:    nil,
-			wantErr: true,
+			want:    &api.ModerateUserRes{Message: "success"},
+			wantErr: false,
 		},
 		{
-			name:    "error when unlike post",
+			name:    "error when ban devices",
 			prepare: func() {
-				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
-					Return(&entity.User{}, nil)
-				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
-					Return(&entity.Post{IsLiked: true}, nil)
-				mockPostModule.On("UnlikePost", mock.Anything, req.PostID, req.UserID).
+				mockUserModule.On("FindUserByID", mock.Anything, "admin-id").
+					Return(admin, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, "target-id").
+					Return(target, nil)
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.Anything).
+					Return(nil)
+				mockUserModule.On("SaveBan", mock.Anything, mock.Anything).
+					Return(nil)
+				mockNotificationModule.On("FindPushTokenList", mock.Anything, "target-id").
+					Return([]string{"token-1"}, nil)
+				mockUserModule.On("BanDevices", mock.Anything, "target-id", []string{"token-1"}, "doxxing").
 					Return(err)
-				mockLogModule.On("Log", err, req, mock.Anything)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
 			},
 			args:    args{
 				ctx: ctx,
-				req: req,
+				req: newReq(constants.ModerateActionBan),
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name:    "error when send notification",
+			name:    "shadowban",
 			prepare: func() {
-				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
-					Return(&user, nil)
-				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
-					Return(&post, nil)
-				mockPostModule.On("LikePost", mock.Anything, req.PostID, req.UserID).
-					Return(nil)
-				mockNotificationModule.On("SendLikeNotification", mock.Anything,
-					user,
-					post,
-				).Return(err)
-				mockLogModule.On("Log", err, req, mock.Anything)
+				mockUserModule.On("FindUserByID", mock.Anything, "admin-id").
+					Return(admin, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, "target-id").
+					Return(target, nil)
+				mockUserModule.On("SaveShadowban", mock.Anything, mock.MatchedBy(func(u entity.User) bool {
+					return u.ID == "target-id" && u.Account.ShadowbannedAt > 0
+				})).Return(nil).Once()
+				mockPostModule.On("UpdateShadowbanStatus", mock.Anything, "target-id", true).
+					Return(nil).Once()
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.MatchedBy(func(l entity.AuditLog) bool {
+					return l.Action == constants.AuditActionShadowbanUser
+				})).Return(nil).Once()
 			},
 			args:    args{
 				ctx: ctx,
-				req: req,
+				req: newReq(constants.ModerateActionShadowban),
 			},
-			want:    &api.LikePostRes{Message: "success"},
+			want:    &api.ModerateUserRes{Message: "success"},
 			wantErr: false,
 		},
 		{
-			name:    "success like post",
+			name:    "error when shadowban post record the failure",
 			prepare: func() {
-				mockUserModule.On("FindUserByID", mock.Anything, req.UserID).
-					Return(&user, nil)
-				mockPostModule.On("FindPostByID", mock.Anything, req.PostID, req.UserID).
-					Return(&post, nil)
-				mockPostModule.On("LikePost", mock.Anything, req.PostID, req.UserID).
+				mockUserModule.On("FindUserByID", mock.Anything, "admin-id").
+					Return(admin, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, "target-id").
+					Return(target, nil)
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.MatchedBy(func(l entity.AuditLog) bool {
+					return l.Action == constants.AuditActionShadowbanUser
+				})).Return(nil).Once()
+				mockUserModule.On("SaveShadowban", mock.Anything, mock.Anything).
 					Return(nil)
-				mockNotificationModule.On("SendLikeNotification", mock.Anything,
-					user,
-					post,
-				).Return(nil)
-			},
-			args:    args{
-				ctx: ctx,
-				req: req,
+				mockPostModule.On("UpdateShadowbanStatus", mock.Anything, "target-id", true).
+					Return(err)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.MatchedBy(func(l entity.AuditLog) bool {
+					return l.Action == constants.AuditActionFailed &&
+						l.Reason == constants.AuditActionShadowbanUser &&
+						l.ActorID == "admin-id" &&
+						l.TargetID == "target-id" &&
+						l.Before["shadowbanned_at"].(int64) > 0
+				})).Return(nil).Once()
 			},
-			want:    &api.LikePostRes{Message: "success"},
-			wantErr: false,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			initTest()
-			if tt.prepare != nil {
-				tt.prepare()
-			}
-			s := &service{
-				adapter: adapter,
-			}
-			got, err := s.LikePost(tt.args.ctx, tt.args.req)
-			if tt.wantErr {
-				assert.Error(t, err)
-				assert.Nil(t, got)
-			} else {
-				assert.Nil(t, err)
-				assert.Equal(t, tt.want, got)
-			}
-		})
-	}
-}
-
-func Test_service_Logout(t *testing.T)  {
-	var (
-		ctx = context.Background()
-		err = errors.New("some error")
-		req = api.LogoutReq{
-			UserID:    "user-id",
-			PushToken: "token",
-		}
-	)
-	type args struct {
-		ctx context.Context
-		req api.LogoutReq
-	}
-	tests := []struct {
-		name    string
-		prepare func()
-		args    args
-		want    *api.LogoutRes
-		wantErr bool
-	}{
-		{
-			name:    "invalid argument",
-			prepare: nil,
 			args:    args{
 				ctx: ctx,
-				req : api.LogoutReq{},
+				req: newReq(constants.ModerateActionShadowban),
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name:    "error when remove push token",
+			name:    "lift shadowban",
 			prepare: func() {
-				mockNotificationModule.On("RemovePushToken", mock.Anything, req.UserID, req.PushToken).
-					Return(err)
-				mockLogModule.On("Log", err, req, mock.Anything)
+				mockUserModule.On("FindUserByID", mock.Anything, "admin-id").
+					Return(admin, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, "target-id").
+					Return(&entity.User{ID: "target-id", Account: entity.Account{ShadowbannedAt: 1}}, nil)
+				mockUserModule.On("SaveSuspension", mock.Anything, entity.User{ID: "target-id"}).
+					Return(nil).Once()
+				mockUserModule.On("SaveBan", mock.Anything, entity.User{ID: "target-id"}).
+					Return(nil).Once()
+				mockUserModule.On("UnbanDevices", mock.Anything, "target-id").
+					Return(nil).Once()
+				mockPostModule.On("UpdateShadowbanStatus", mock.Anything, "target-id", false).
+					Return(nil).Once()
+				mockUserModule.On("SaveShadowban", mock.Anything, entity.User{ID: "target-id"}).
+					Return(nil).Once()
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.Anything).
+					Return(nil).Once()
 			},
 			args:    args{
 				ctx: ctx,
-				req: req,
+				req: newReq(constants.ModerateActionLift),
 			},
-			want:    nil,
-			wantErr: true,
+			want:    &api.ModerateUserRes{Message: "success"},
+			wantErr: false,
 		},
 		{
-			name:    "success",
+			name:    "error when insert audit log nothing is changed",
 			prepare: func() {
-				mockNotificationModule.On("RemovePushToken", mock.Anything, req.UserID, req.PushToken).
-					Return(nil)
+				mockUserModule.On("FindUserByID", mock.Anything, "admin-id").
+					Return(admin, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, "target-id").
+					Return(target, nil)
+				mockAuditModule.On("InsertAuditLog", mock.Anything, mock.MatchedBy(func(l entity.AuditLog) bool {
+					return l.Action == constants.AuditActionLiftRestriction
+				})).Return(err).Once()
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
 			},
 			args:    args{
 				ctx: ctx,
-				req: req,
+				req: newReq(constants.ModerateActionLift),
 			},
-			want:    &api.LogoutRes{Message: "Success"},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
@@ -1219,7 +7821,7 @@ func Test_service_Logout(t *testing.T)  {
 			s := &service{
 				adapter: adapter,
 			}
-			got, err := s.Logout(tt.args.ctx, tt.args.req)
+			got, err := s.ModerateUser(tt.args.ctx, tt.args.req)
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Nil(t, got)
@@ -1227,107 +7829,90 @@ func Test_service_Logout(t *testing.T)  {
 				assert.Nil(t, err)
 				assert.Equal(t, tt.want, got)
 			}
+			mockUserModule.AssertExpectations(t)
+			mockPostModule.AssertExpectations(t)
+			mockAuditModule.AssertExpectations(t)
 		})
 	}
 }
 
-func Test_service_FollowUser(t *testing.T)  {
+func Test_service_GetAuditLog(t *testing.T) {
 	var (
 		ctx = context.Background()
-		userID = "user-id"
-		followedID = "followed-id"
+		err = errors.New("some error")
+		admin = &entity.User{ID: "admin-id", Account: entity.Account{Role: constants.RoleAdmin}}
+		filter = entity.AuditLogFilter{TargetType: constants.AuditTargetPost}
+		logs = []entity.AuditLog{
+			{ID: "log-2", ActorID: "admin-id", Action: constants.AuditActionHidePost, TargetType: constants.AuditTargetPost, TargetID: "post-id"},
+			{ID: "log-1", ActorID: "admin-id", Action: constants.AuditActionDismissReport, TargetType: constants.AuditTargetPost, TargetID: "post-id"},
+		}
 	)
 	type args struct {
 		ctx context.Context
-		req api.FollowUserReq
+		req api.GetAuditLogReq
 	}
 	tests := []struct {
 		name    string
 		prepare func()
 		args    args
-		want    *api.FollowUserRes
+		want    *api.GetAuditLogRes
 		wantErr bool
 	}{
 		{
-			name:    "invalid request",
+			name:    "invalid target type",
 			prepare: nil,
 			args:    args{
 				ctx: ctx,
-				req: api.FollowUserReq{},
+				req: api.GetAuditLogReq{UserID: "admin-id", Filter: entity.AuditLogFilter{TargetType: "comment"}},
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name:    "error get followed user",
+			name:    "not admin",
 			prepare: func() {
-				mockUserModule.On("GetFollowedUserID", mock.Anything, userID).
-					Return(nil, errors.New("err"))
-				mockLogModule.On("Log", mock.Anything, mock.Anything, mock.Anything)
+				mockUserModule.On("FindUserByID", mock.Anything, "admin-id").
+					Return(&entity.User{ID: "admin-id", Account: entity.Account{Role: constants.RoleModerator}}, nil)
 			},
 			args:    args{
 				ctx: ctx,
-				req: api.FollowUserReq{
-					UserID:     userID,
-					FollowedID: followedID,
-				},
+				req: api.GetAuditLogReq{UserID: "admin-id"},
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name:    "error update follow status",
+			name:    "error when get audit log list",
 			prepare: func() {
-				mockUserModule.On("GetFollowedUserID", mock.Anything, userID).
-					Return(nil, nil)
-				mockUserModule.On("UpdateFollowStatus", mock.Anything, userID, followedID, constants.FollowStatusActive).
-					Return(errors.New("err"))
-				mockLogModule.On("Log", mock.Anything, mock.Anything, mock.Anything)
+				mockUserModule.On("FindUserByID", mock.Anything, "admin-id").
+					Return(admin, nil)
+				mockAuditModule.On("FindAuditLogList", mock.Anything, filter, api.PaginationReq{First: 20}).
+					Return(nil, nil, err)
+				mockLogModule.On("Log", err, mock.Anything, mock.Anything)
 			},
 			args:    args{
 				ctx: ctx,
-				req: api.FollowUserReq{
-					UserID:     userID,
-					FollowedID: followedID,
-				},
+				req: api.GetAuditLogReq{UserID: "admin-id", Filter: filter},
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name:    "success update follow status: active",
+			name:    "success",
 			prepare: func() {
-				mockUserModule.On("GetFollowedUserID", mock.Anything, userID).
-					Return(nil, nil)
-				mockUserModule.On("UpdateFollowStatus", mock.Anything, userID, followedID, constants.FollowStatusActive).
-					Return(nil)
+				mockUserModule.On("FindUserByID", mock.Anything, "admin-id").
+					Return(admin, nil)
+				mockAuditModule.On("FindAuditLogList", mock.Anything, filter, api.PaginationReq{First: 2, After: "log-3"}).
+					Return(logs, &api.PaginationRes{EndCursor: "log-1", HasNextPage: true}, nil)
 			},
 			args:    args{
 				ctx: ctx,
-				req: api.FollowUserReq{
-					UserID:     userID,
-					FollowedID: followedID,
-				},
-			},
-			want:    &api.FollowUserRes{Message: "success"},
-			wantErr: false,
-		},
-		{
-			name:    "success update follow status: inactive",
-			prepare: func() {
-				mockUserModule.On("GetFollowedUserID", mock.Anything, userID).
-					Return([]string{followedID}, nil)
-				mockUserModule.On("UpdateFollowStatus", mock.Anything, userID, followedID, constants.FollowStatusInactive).
-					Return(nil)
+				req: api.GetAuditLogReq{UserID: "admin-id", Filter: filter, Pagination: api.PaginationReq{First: 2, After: "log-3"}},
 			},
-			args:    args{
-				ctx: ctx,
-				req: api.FollowUserReq{
-					UserID:     userID,
-					FollowedID: followedID,
-				},
+			want:    &api.GetAuditLogRes{
+				AuditLogList: logs,
+				Pagination:   api.PaginationRes{EndCursor: "log-1", HasNextPage: true},
 			},
-			want:    &api.FollowUserRes{Message: "success"},
 			wantErr: false,
 		},
 	}
@@ -1340,7 +7925,7 @@ func Test_service_FollowUser(t *testing.T)  {
 			s := &service{
 				adapter: adapter,
 			}
-			got, err := s.FollowUser(tt.args.ctx, tt.args.req)
+			got, err := s.GetAuditLog(tt.args.ctx, tt.args.req)
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Nil(t, got)
@@ -1348,103 +7933,77 @@ func Test_service_FollowUser(t *testing.T)  {
 				assert.Nil(t, err)
 				assert.Equal(t, tt.want, got)
 			}
+			mockUserModule.AssertExpectations(t)
+			mockAuditModule.AssertExpectations(t)
 		})
 	}
 }
 
-func Test_service_Feed(t *testing.T)  {
+func Test_service_ExportAuditLog(t *testing.T) {
 	var (
 		ctx = context.Background()
-		reqAll = api.FeedReq{
-			UserID:     "id",
-			Type:       "all",
-			Pagination: api.PaginationReq{},
-		}
-		reqFollow = api.FeedReq{
-			UserID:     "id",
-			Type:       "follow",
-			Pagination: api.PaginationReq{},
-		}
+		admin = &entity.User{ID: "admin-id", Account: entity.Account{Role: constants.RoleAdmin}}
+		filter = entity.AuditLogFilter{ActorID: "moderator-id"}
+		firstPage = api.PaginationReq{First: constants.AuditLogExportBatchSize}
+		secondPage = api.PaginationReq{First: constants.AuditLogExportBatchSize, After: "log-2"}
 	)
 	type args struct {
 		ctx context.Context
-		req api.FeedReq
+		req api.ExportAuditLogReq
 	}
 	tests := []struct {
 		name    string
 		prepare func()
 		args    args
-		want    *api.FeedRes
+		want    *api.ExportAuditLogRes
 		wantErr bool
 	}{
 		{
-			name:    "invalid request",
-			prepare: nil,
-			args:    args{},
-			want:    nil,
-			wantErr: true,
-		},
-		{
-			name:    "type follow, error get followed ids",
-			prepare: func() {
-				mockUserModule.On("GetFollowedUserID", mock.Anything, reqFollow.UserID).
-					Return(nil, errors.New("err"))
-				mockLogModule.On("Log", mock.Anything, reqFollow, mock.Anything)
-			},
-			args:    args{
-				ctx: ctx,
-				req: reqFollow,
-			},
-			want:    nil,
-			wantErr: true,
-		},
-		{
-			name:    "type all, error get post list",
+			name:    "not admin",
 			prepare: func() {
-				mockPostModule.On("FindPostListByParentIDAndAuthorIDs", mock.Anything, "", mock.Anything, reqAll.UserID, reqAll.Pagination).
-					Return(nil, nil, errors.New("err"))
-				mockLogModule.On("Log", mock.Anything, reqAll, mock.Anything)
+				mockUserModule.On("FindUserByID", mock.Anything, "admin-id").
+					Return(&entity.User{ID: "admin-id"}, nil)
 			},
 			args:    args{
 				ctx: ctx,
-				req: reqAll,
+				req: api.ExportAuditLogReq{UserID: "admin-id", Filter: filter},
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name:    "success, type follow",
+			name:    "success across pages",
 			prepare: func() {
-				mockUserModule.On("GetFollowedUserID", mock.Anything, reqFollow.UserID).
-					Return([]string{"id"}, nil)
-				mockPostModule.On("FindPostListByParentIDAndAuthorIDs", mock.Anything, "", []string{"id"}, reqFollow.UserID, reqFollow.Pagination).
-					Return([]entity.Post{}, &api.PaginationRes{}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, "admin-id").
+					Return(admin, nil)
+				mockAuditModule.On("FindAuditLogList", mock.Anything, filter, firstPage).
+					Return([]entity.AuditLog{{ID: "log-3"}, {ID: "log-2"}}, &api.PaginationRes{EndCursor: "log-2", HasNextPage: true}, nil).Once()
+				mockAuditModule.On("FindAuditLogList", mock.Anything, filter, secondPage).
+					Return([]entity.AuditLog{{ID: "log-1"}}, &api.PaginationRes{EndCursor: "log-1", HasNextPage: false}, nil).Once()
 			},
 			args:    args{
 				ctx: ctx,
-				req: reqFollow,
+				req: api.ExportAuditLogReq{UserID: "admin-id", Filter: filter},
 			},
-			want:    &api.FeedRes{
-				PostList:   []entity.Post{},
-				Pagination: api.PaginationRes{},
+			want:    &api.ExportAuditLogRes{
+				AuditLogList: []entity.AuditLog{{ID: "log-3"}, {ID: "log-2"}, {ID: "log-1"}},
 			},
 			wantErr: false,
 		},
 		{
-			name:    "success, type all",
+			name:    "too many audit log",
 			prepare: func() {
-				mockPostModule.On("FindPostListByParentIDAndAuthorIDs", mock.Anything, "", mock.Anything, reqAll.UserID, reqAll.Pagination).
-					Return([]entity.Post{}, &api.PaginationRes{}, nil)
+				mockUserModule.On("FindUserByID", mock.Anything, "admin-id").
+					Return(admin, nil)
+				mockAuditModule.On("FindAuditLogList", mock.Anything, filter, mock.Anything).
+					Return(make([]entity.AuditLog, constants.AuditLogExportBatchSize), &api.PaginationRes{EndCursor: "log", HasNextPage: true}, nil)
 			},
 			args:    args{
 				ctx: ctx,
-				req: reqAll,
+				req: api.ExportAuditLogReq{UserID: "admin-id", Filter: filter},
 			},
-			want:    &api.FeedRes{
-				PostList:   []entity.Post{},
-				Pagination: api.PaginationRes{},
-			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
@@ -1456,7 +8015,7 @@ func Test_service_Feed(t *testing.T)  {
 			s := &service{
 				adapter: adapter,
 			}
-			got, err := s.Feed(tt.args.ctx, tt.args.req)
+			got, err := s.ExportAuditLog(tt.args.ctx, tt.args.req)
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Nil(t, got)
@@ -1464,6 +8023,8 @@ func Test_service_Feed(t *testing.T)  {
 				assert.Nil(t, err)
 				assert.Equal(t, tt.want, got)
 			}
+			mockUserModule.AssertExpectations(t)
+			mockAuditModule.AssertExpectations(t)
 		})
 	}
 }
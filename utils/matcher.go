@@ -0,0 +1,185 @@
+package utils
+
+import (
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+// leet is the digit commonly written in place of letter to get around word filter, like "4njing"
+var leet = map[rune]rune{
+	'0': 'o',
+	'1': 'i',
+	'3': 'e',
+	'4': 'a',
+	'5': 's',
+	'7': 't',
+}
+
+// NormalizeText lowercase the text, drop the diacritics, replace the leet digit next to a letter,
+// squeeze the repeated letter ("anjiiing" become "anjing") and replace punctuation with a single space,
+// so "Kata-kata" and "kata kata" are the same
+func NormalizeText(s string) string {
+	s, _, _ = transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	in := []rune(strings.ToLower(s))
+
+	var b strings.Builder
+	var last rune
+	space := true
+	for i, r := range in {
+		if v, ok := leet[r]; ok && (isLetterAt(in, i-1) || isLetterAt(in, i+1)) {
+			r = v
+		}
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+			if !space {
+				b.WriteRune(' ')
+				space = true
+			}
+			last = 0
+			continue
+		}
+		if r == last && unicode.IsLetter(r) {
+			continue
+		}
+		b.WriteRune(r)
+		last = r
+		space = false
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func isLetterAt(s []rune, i int) bool {
+	return i >= 0 && i < len(s) && unicode.IsLetter(s[i])
+}
+
+// Matcher find the whole words or phrases in a text at once with Aho-Corasick automaton.
+// The patterns and the text are normalized with NormalizeText, a pattern only match whole words,
+// so "ban" doesn't match "bantuan".
+type Matcher struct {
+	nodes    []matcherNode
+	patterns []string
+}
+
+type matcherNode struct {
+	next map[rune]int
+	fail int
+	// output is the index of the pattern ending on this node, -1 if none
+	output int
+	// dict is the nearest node on the fail chain with output, -1 if none
+	dict int
+}
+
+func NewMatcher(patterns []string) *Matcher {
+	m := &Matcher{nodes: []matcherNode{newMatcherNode()}}
+	for _, v := range patterns {
+		normalized := NormalizeText(v)
+		if normalized == "" {
+			continue
+		}
+		m.insert(" "+normalized+" ", v)
+	}
+	m.build()
+	return m
+}
+
+func newMatcherNode() matcherNode {
+	return matcherNode{next: make(map[rune]int), output: -1, dict: -1}
+}
+
+func (m *Matcher) insert(key string, pattern string) {
+	current := 0
+	for _, r := range key {
+		next, ok := m.nodes[current].next[r]
+		if !ok {
+			next = len(m.nodes)
+			m.nodes = append(m.nodes, newMatcherNode())
+			m.nodes[current].next[r] = next
+		}
+		current = next
+	}
+	if m.nodes[current].output == -1 {
+		m.nodes[current].output = len(m.patterns)
+		m.patterns = append(m.patterns, pattern)
+	}
+}
+
+// build set the fail link of every node breadth first, so the parent fail link is always ready
+func (m *Matcher) build() {
+	queue := make([]int, 0, len(m.nodes))
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[current].next {
+			fail := m.nodes[current].fail
+			for fail != 0 {
+				if _, ok := m.nodes[fail].next[r]; ok {
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+			if next, ok := m.nodes[fail].next[r]; ok && next != child {
+				m.nodes[child].fail = next
+			}
+			failNode := m.nodes[m.nodes[child].fail]
+			if failNode.output != -1 {
+				m.nodes[child].dict = m.nodes[child].fail
+			} else {
+				m.nodes[child].dict = failNode.dict
+			}
+			queue = append(queue, child)
+		}
+	}
+}
+
+// Match return the patterns found in the text, in the order they are first found
+func (m *Matcher) Match(text string) []string {
+	var result []string
+	found := make(map[int]bool)
+	m.scan(text, func(pattern int) bool {
+		if !found[pattern] {
+			found[pattern] = true
+			result = append(result, m.patterns[pattern])
+		}
+		return true
+	})
+	return result
+}
+
+// MatchAny return true if any of the patterns is found in the text
+func (m *Matcher) MatchAny(text string) bool {
+	matched := false
+	m.scan(text, func(int) bool {
+		matched = true
+		return false
+	})
+	return matched
+}
+
+// scan call fn for every pattern found until fn return false
+func (m *Matcher) scan(text string, fn func(pattern int) bool) {
+	if len(m.patterns) == 0 {
+		return
+	}
+	current := 0
+	for _, r := range " " + NormalizeText(text) + " " {
+		for current != 0 {
+			if _, ok := m.nodes[current].next[r]; ok {
+				break
+			}
+			current = m.nodes[current].fail
+		}
+		if next, ok := m.nodes[current].next[r]; ok {
+			current = next
+		}
+		for node := current; node != -1; node = m.nodes[node].dict {
+			if m.nodes[node].output != -1 && !fn(m.nodes[node].output) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "punctuation become single space", text: "  Kata-kata,   kasar!! ", want: "kata kata kasar"},
+		{name: "diacritics dropped", text: "Crème Ñandú", want: "creme nandu"},
+		{name: "leet digit next to letter", text: "4njing b4ngs4t", want: "anjing bangsat"},
+		{name: "number kept", text: "lantai 3 tahun 2021", want: "lantai 3 tahun 2021"},
+		{name: "repeated letter squeezed", text: "anjiiiing bangeeet", want: "anjing banget"},
+		{name: "repeated digit kept", text: "1000", want: "1000"},
+		{name: "empty", text: "", want: ""},
+		{name: "only punctuation", text: "?!...", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeText(tt.text))
+		})
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		text     string
+		want     []string
+	}{
+		{
+			name:     "overlapping patterns",
+			patterns: []string{"he", "she", "hers"},
+			text:     "she said he hers",
+			want:     []string{"she", "he", "hers"},
+		},
+		{
+			name:     "overlapping patterns only match whole word",
+			patterns: []string{"he", "she", "hers"},
+			text:     "ushers the sheep",
+			want:     nil,
+		},
+		{
+			name:     "pattern ending on the fail link of another",
+			patterns: []string{"a b", "b"},
+			text:     "x a b",
+			want:     []string{"a b", "b"},
+		},
+		{
+			name:     "phrase overlapping the end of other phrase",
+			patterns: []string{"a b c", "b c d"},
+			text:     "a b c d",
+			want:     []string{"a b c", "b c d"},
+		},
+		{
+			name:     "whole word only",
+			patterns: []string{"ban"},
+			text:     "minta bantuan dong, urban legend",
+			want:     nil,
+		},
+		{
+			name:     "whole word next to punctuation",
+			patterns: []string{"ban"},
+			text:     "akunnya kena di-ban!",
+			want:     []string{"ban"},
+		},
+		{
+			name:     "phrase",
+			patterns: []string{"kata kasar"},
+			text:     "Jangan pakai KATA-KASAR ya",
+			want:     []string{"kata kasar"},
+		},
+		{
+			name:     "phrase with other word in between",
+			patterns: []string{"kata kasar"},
+			text:     "kata yang kasar",
+			want:     nil,
+		},
+		{
+			name:     "repeated match reported once in first found order",
+			patterns: []string{"spoiler", "ending"},
+			text:     "ending spoiler, spoiler lagi, ending",
+			want:     []string{"ending", "spoiler"},
+		},
+		{
+			name:     "diacritics",
+			patterns: []string{"café"},
+			text:     "ke cafe yuk",
+			want:     []string{"café"},
+		},
+		{
+			name:     "leet digit",
+			patterns: []string{"anjing"},
+			text:     "dasar 4nj1ng",
+			want:     []string{"anjing"},
+		},
+		{
+			name:     "repeated letter",
+			patterns: []string{"anjing"},
+			text:     "ANJIIIIING",
+			want:     []string{"anjing"},
+		},
+		{
+			name:     "duplicate pattern keep the first",
+			patterns: []string{"Spoiler", "spoiler!"},
+			text:     "spoiler",
+			want:     []string{"Spoiler"},
+		},
+		{
+			name:     "empty pattern ignored",
+			patterns: []string{"", "  ", "!!", "ok"},
+			text:     "ok !! ",
+			want:     []string{"ok"},
+		},
+		{
+			name:     "no pattern",
+			patterns: nil,
+			text:     "anything",
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMatcher(tt.patterns)
+			assert.Equal(t, tt.want, m.Match(tt.text))
+			assert.Equal(t, len(tt.want) > 0, m.MatchAny(tt.text))
+		})
+	}
+}
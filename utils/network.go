@@ -0,0 +1,18 @@
+package utils
+
+import "net"
+
+// IPNetwork return the network of the ip as CIDR, ipv4 masked to ipv4Bits and ipv6 to ipv6Bits,
+// like "10.1.2.0/24". It return empty string if the ip isn't valid.
+func IPNetwork(ip string, ipv4Bits int, ipv6Bits int) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ""
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		mask := net.CIDRMask(ipv4Bits, 32)
+		return (&net.IPNet{IP: v4.Mask(mask), Mask: mask}).String()
+	}
+	mask := net.CIDRMask(ipv6Bits, 128)
+	return (&net.IPNet{IP: parsed.Mask(mask), Mask: mask}).String()
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIPNetwork(t *testing.T) {
+	tests := []struct {
+		name string
+		ip   string
+		want string
+	}{
+		{name: "ipv4", ip: "10.1.2.3", want: "10.1.2.0/24"},
+		{name: "ipv4 mapped ipv6 is ipv4", ip: "::ffff:10.1.2.3", want: "10.1.2.0/24"},
+		{name: "ipv6", ip: "2001:db8:1:2:3:4:5:6", want: "2001:db8:1::/48"},
+		{name: "invalid", ip: "not an ip", want: ""},
+		{name: "empty", ip: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IPNetwork(tt.ip, 24, 48))
+		})
+	}
+	assert.Equal(t, "10.1.2.3/32", IPNetwork("10.1.2.3", 32, 64))
+}
@@ -0,0 +1,191 @@
+package utils
+
+import (
+	"github.com/aeramu/menfess-backend/constants"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PersonalDataDetector find one type of personal data in a text
+type PersonalDataDetector interface {
+	// Type is one of constants.PersonalData*
+	Type() string
+	// Detect return the byte range [start, end) of each personal data found, in order of appearance
+	Detect(text string) [][2]int
+}
+
+// PersonalDataDetectors return every built-in detector
+func PersonalDataDetectors() []PersonalDataDetector {
+	return []PersonalDataDetector{
+		PhoneNumberDetector{},
+		NIKDetector{},
+		StudentIDDetector{},
+		InstagramHandleDetector{},
+		FullNameDetector{},
+	}
+}
+
+var (
+	// numberRegex find the digit sequence, the digits may be grouped with space, dot or dash like "0812-3456-7890"
+	numberRegex = regexp.MustCompile(`\+?\d(?:[ .-]?\d)+`)
+	fieldRegex  = regexp.MustCompile(`\S+`)
+	// studentIDRegex only match the number written after its label, a bare number is too common to tell
+	studentIDRegex = regexp.MustCompile(`(?i)\b(?:nim|npm|nrp|nis|nisn|nomor induk(?: mahasiswa| siswa)?)\b[\s:.#=-]*(\d{5,15})\b`)
+	// instagramRegex match the handle written after "ig" with @ or colon, or in the profile url.
+	// Plain @handle is a mention of the user in this app, so it isn't matched.
+	instagramRegex = regexp.MustCompile(`(?i)(?:\b(?:ig|insta|instagram)\s*(?:[:=-]\s*@?|@)|instagram\.com/)([a-z0-9._]{1,30})`)
+	// fullNameRegex match two to four capitalized words in a row
+	fullNameRegex = regexp.MustCompile(`\b\p{Lu}\p{Ll}+(?:[ \t]+\p{Lu}\p{Ll}+){1,3}\b`)
+)
+
+type PhoneNumberDetector struct{}
+
+func (PhoneNumberDetector) Type() string {
+	return constants.PersonalDataPhoneNumber
+}
+
+func (PhoneNumberDetector) Detect(text string) [][2]int {
+	return detectNumber(text, isPhoneNumber)
+}
+
+type NIKDetector struct{}
+
+func (NIKDetector) Type() string {
+	return constants.PersonalDataNIK
+}
+
+func (NIKDetector) Detect(text string) [][2]int {
+	return detectNumber(text, isNIK)
+}
+
+type StudentIDDetector struct{}
+
+func (StudentIDDetector) Type() string {
+	return constants.PersonalDataStudentID
+}
+
+// Detect return only the number, the label is kept
+func (StudentIDDetector) Detect(text string) [][2]int {
+	return detectGroup(studentIDRegex, text)
+}
+
+type InstagramHandleDetector struct{}
+
+func (InstagramHandleDetector) Type() string {
+	return constants.PersonalDataInstagramHandle
+}
+
+// Detect return only the handle, "ig:" or the url before it is kept
+func (InstagramHandleDetector) Detect(text string) [][2]int {
+	return detectGroup(instagramRegex, text)
+}
+
+// FullNameDetector guess the name from the capitalized words, so it miss name written in lowercase
+// and catch other capitalized phrase sometimes. The common word starting a sentence is skipped.
+type FullNameDetector struct{}
+
+func (FullNameDetector) Type() string {
+	return constants.PersonalDataFullName
+}
+
+func (FullNameDetector) Detect(text string) [][2]int {
+	var result [][2]int
+	for _, v := range fullNameRegex.FindAllStringIndex(text, -1) {
+		words := fieldRegex.FindAllStringIndex(text[v[0]:v[1]], -1)
+		for len(words) > 0 && nameStopWords[strings.ToLower(text[v[0]+words[0][0]:v[0]+words[0][1]])] {
+			words = words[1:]
+		}
+		if len(words) < 2 {
+			continue
+		}
+		result = append(result, [2]int{v[0] + words[0][0], v[1]})
+	}
+	return result
+}
+
+// nameStopWords is the capitalized word that isn't a name, commonly starting a sentence or before a name
+var nameStopWords = toSet(
+	"aku", "saya", "gue", "gw", "kamu", "lo", "lu", "dia", "kita", "kami", "mereka", "kalian",
+	"halo", "hai", "hi", "hello", "dear", "buat", "untuk", "dari", "ke", "di", "dan", "atau", "yang",
+	"ini", "itu", "tapi", "terus", "semoga", "tolong", "please", "selamat", "terima", "kasih", "maaf",
+	"kak", "kakak", "mas", "mbak", "bang", "abang", "pak", "bu", "ibu", "bapak", "the", "to", "for", "my",
+	"senin", "selasa", "rabu", "kamis", "jumat", "sabtu", "minggu", "pagi", "siang", "sore", "malam",
+	"januari", "februari", "maret", "april", "mei", "juni", "juli", "agustus", "september", "oktober",
+	"november", "desember",
+)
+
+func toSet(words ...string) map[string]bool {
+	result := make(map[string]bool, len(words))
+	for _, v := range words {
+		result[v] = true
+	}
+	return result
+}
+
+// detectGroup return the range of the first submatch of every match
+func detectGroup(re *regexp.Regexp, text string) [][2]int {
+	var result [][2]int
+	for _, v := range re.FindAllStringSubmatchIndex(text, -1) {
+		result = append(result, [2]int{v[2], v[3]})
+	}
+	return result
+}
+
+// detectNumber check every digit sequence in the text, both as a whole and split by space,
+// so two numbers written next to each other aren't read as a single long number
+func detectNumber(text string, is func(digits string) bool) [][2]int {
+	var result [][2]int
+	for _, v := range numberRegex.FindAllStringIndex(text, -1) {
+		if is(onlyDigits(text[v[0]:v[1]])) {
+			result = append(result, [2]int{v[0], v[1]})
+			continue
+		}
+		for _, part := range fieldRegex.FindAllStringIndex(text[v[0]:v[1]], -1) {
+			if is(onlyDigits(text[v[0]+part[0] : v[0]+part[1]])) {
+				result = append(result, [2]int{v[0] + part[0], v[0] + part[1]})
+			}
+		}
+	}
+	return result
+}
+
+func onlyDigits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// isPhoneNumber match Indonesian mobile number, written either local (08xx) or international (628xx)
+func isPhoneNumber(digits string) bool {
+	if strings.HasPrefix(digits, "08") {
+		return len(digits) >= 10 && len(digits) <= 13
+	}
+	if strings.HasPrefix(digits, "628") {
+		return len(digits) >= 11 && len(digits) <= 14
+	}
+	return false
+}
+
+// isNIK match the 16 digit NIK structure: province and regency code, birth date (day plus 40 for female)
+// and a non zero serial number, so random 16 digit number like a bank account rarely match
+func isNIK(digits string) bool {
+	if len(digits) != 16 {
+		return false
+	}
+	province, _ := strconv.Atoi(digits[0:2])
+	day, _ := strconv.Atoi(digits[6:8])
+	month, _ := strconv.Atoi(digits[8:10])
+	if day > 40 {
+		day -= 40
+	}
+	return province >= 11 && province <= 94 &&
+		digits[2:4] != "00" &&
+		day >= 1 && day <= 31 &&
+		month >= 1 && month <= 12 &&
+		digits[12:16] != "0000"
+}
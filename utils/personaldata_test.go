@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+// the fixtures are written the way the confessions usually are, both the personal data and the text that look alike
+func TestPersonalDataDetectors(t *testing.T) {
+	data, err := os.ReadFile("testdata/personal_data.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixtures []struct {
+		Type  string   `json:"type"`
+		Text  string   `json:"text"`
+		Found []string `json:"found"`
+	}
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		t.Fatal(err)
+	}
+
+	detectors := make(map[string]PersonalDataDetector)
+	for _, v := range PersonalDataDetectors() {
+		detectors[v.Type()] = v
+	}
+	for _, tt := range fixtures {
+		t.Run(tt.Type+"/"+tt.Text, func(t *testing.T) {
+			detector, ok := detectors[tt.Type]
+			if !ok {
+				t.Fatalf("no detector for %s", tt.Type)
+			}
+			found := []string{}
+			for _, v := range detector.Detect(tt.Text) {
+				found = append(found, tt.Text[v[0]:v[1]])
+			}
+			assert.Equal(t, tt.Found, found)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"hash/fnv"
+	"math/bits"
+)
+
+// simhashShingleSize is the length (in rune) of each shingle, short enough that a small edit
+// only change the few shingles around it
+const simhashShingleSize = 4
+
+// Simhash fingerprint the text from its normalized character shingles, so the text that differ
+// only a few words, the case or the punctuation get fingerprints a few bits apart.
+// Compare the fingerprints with HammingDistance.
+func Simhash(text string) uint64 {
+	in := []rune(NormalizeText(text))
+	if len(in) == 0 {
+		return 0
+	}
+
+	var weights [64]int
+	add := func(shingle []rune) {
+		h := fnv.New64a()
+		h.Write([]byte(string(shingle)))
+		sum := h.Sum64()
+		for i := 0; i < 64; i++ {
+			if sum&(1<<uint(i)) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+	if len(in) <= simhashShingleSize {
+		add(in)
+	}
+	for i := 0; i+simhashShingleSize <= len(in); i++ {
+		add(in[i : i+simhashShingleSize])
+	}
+
+	var result uint64
+	for i, v := range weights {
+		if v > 0 {
+			result |= 1 << uint(i)
+		}
+	}
+	return result
+}
+
+// HammingDistance count the different bits of the two fingerprints
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
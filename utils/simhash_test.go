@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"github.com/aeramu/menfess-backend/constants"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSimhash(t *testing.T) {
+	const original = "Buat kamu yang tadi pagi duduk di perpus lantai 3 pakai jaket biru, makasih udah minjemin charger"
+	tests := []struct {
+		name    string
+		text    string
+		similar bool
+	}{
+		{
+			name:    "only case and punctuation changed",
+			text:    "buat kamu yang tadi pagi duduk di perpus lantai 3 pakai jaket biru makasih udah minjemin charger!!",
+			similar: true,
+		},
+		{
+			name:    "a few words changed",
+			text:    "Buat kamu yang tadi pagi duduk di perpus lantai 2 pakai jaket merah, makasih ya udah minjemin charger",
+			similar: true,
+		},
+		{
+			name:    "same opening, different confession",
+			text:    "Buat kamu yang kemarin sore di kantin teknik pakai kemeja putih, boleh kenalan ga",
+			similar: false,
+		},
+		{
+			name:    "unrelated post",
+			text:    "Ada yang tau jadwal UTS kalkulus minggu depan? tolong info dong",
+			similar: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distance := HammingDistance(Simhash(original), Simhash(tt.text))
+			assert.Equal(t, tt.similar, distance <= constants.PostDuplicateMaxDistance, "distance %d", distance)
+		})
+	}
+}
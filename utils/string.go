@@ -1,8 +1,94 @@
 package utils
 
-import "net/mail"
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/aeramu/menfess-backend/constants"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+var (
+	hashtagRegex = regexp.MustCompile(`#([\p{L}\p{N}_]+)`)
+	urlRegex     = regexp.MustCompile(`https?://[^\s<>"'\x60]+`)
+	// mentionRegex doesn't match @ preceded by word character, so email address isn't a mention
+	mentionRegex = regexp.MustCompile(fmt.Sprintf(`(?:^|[^\w@])@(\w{%d,%d})\b`, constants.HandleMinLength, constants.HandleMaxLength))
+)
 
 func ValidateEmail(s string) error {
 	_, err :=  mail.ParseAddress(s)
 	return err
 }
+
+// ExtractHashtags return unique lowercased hashtag (without #) in order of appearance
+func ExtractHashtags(s string) []string {
+	var result []string
+	found := make(map[string]bool)
+	for _, v := range hashtagRegex.FindAllStringSubmatch(s, -1) {
+		tag := strings.ToLower(v[1])
+		if found[tag] {
+			continue
+		}
+		found[tag] = true
+		result = append(result, tag)
+	}
+	return result
+}
+
+// ExtractMentions return unique lowercased handle (without @) in order of appearance
+func ExtractMentions(s string) []string {
+	var result []string
+	found := make(map[string]bool)
+	for _, v := range mentionRegex.FindAllStringSubmatch(s, -1) {
+		handle := strings.ToLower(v[1])
+		if found[handle] {
+			continue
+		}
+		found[handle] = true
+		result = append(result, handle)
+	}
+	return result
+}
+
+// ExtractURLs return unique http(s) url in order of appearance, without the trailing punctuation of the sentence
+func ExtractURLs(s string) []string {
+	var result []string
+	found := make(map[string]bool)
+	for _, v := range urlRegex.FindAllString(s, -1) {
+		v = trimURL(v)
+		if found[v] {
+			continue
+		}
+		found[v] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+// trimURL drop trailing punctuation, closing parenthesis is kept if it's part of the url like wikipedia link
+func trimURL(s string) string {
+	for len(s) > 0 {
+		last := s[len(s)-1]
+		if strings.IndexByte(".,;:!?'\"", last) >= 0 {
+			s = s[:len(s)-1]
+			continue
+		}
+		if last == ')' && strings.Count(s, "(") < strings.Count(s, ")") {
+			s = s[:len(s)-1]
+			continue
+		}
+		break
+	}
+	return s
+}
+
+// RandomHex return cryptographically random hex string from n random bytes
+func RandomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}